@@ -7,4 +7,5 @@ require (
 	github.com/go-fed/httpsig v0.1.1-0.20190914113940-c2de3672e5b5
 	github.com/go-test/deep v1.0.1
 	github.com/golang/mock v1.2.0
+	github.com/piprate/json-gold v0.4.0
 )
@@ -62,8 +62,17 @@ type Actor interface {
 	// application to determine the correct authorization of the request and
 	// the resulting OrderedCollection to respond with. The Actor handles
 	// serializing this OrderedCollection and responding with the correct
-	// headers and http.StatusOK.
+	// headers, including an ETag, and http.StatusOK. If the request's
+	// If-None-Match header matches the ETag, http.StatusNotModified is
+	// written instead.
+	//
+	// The request and data of your application will be interpreted as
+	// having an HTTPS protocol scheme.
 	GetInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error)
+	// GetInboxScheme is similar to GetInbox, except clients are able to
+	// specify which protocol scheme to handle the incoming request and the
+	// data stored within the application (HTTP, HTTPS, etc).
+	GetInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error)
 	// PostOutbox returns true if the request was handled as an ActivityPub
 	// POST to an actor's outbox. If false, the request was not an
 	// ActivityPub request and may still be handled by the caller in another
@@ -102,8 +111,16 @@ type Actor interface {
 	// application to determine the correct authorization of the request and
 	// the resulting OrderedCollection to respond with. The Actor handles
 	// serializing this OrderedCollection and responding with the correct
-	// headers and http.StatusOK.
+	// headers, including an ETag, and http.StatusOK. If the request's
+	// If-None-Match header matches the ETag, http.StatusNotModified is
+	// written instead.
+	//
+	// The request will be interpreted as having an HTTPS scheme.
 	GetOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error)
+	// GetOutboxScheme is similar to GetOutbox, except clients are able to
+	// specify which protocol scheme to handle the incoming request and the
+	// data stored within the application (HTTP, HTTPS, etc).
+	GetOutboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error)
 }
 
 // FederatingActor is an Actor that allows programmatically delivering an
@@ -124,4 +141,27 @@ type FederatingActor interface {
 	// method will guaranteed work for non-custom Actors. For custom actors,
 	// care should be used to not call this method if only C2S is supported.
 	Send(c context.Context, outbox *url.URL, t vocab.Type) (Activity, error)
+	// ApproveFollowRequest accepts a Follow awaiting approval by the actor
+	// owning the inbox.
+	//
+	// Follow requests await approval when the OnFollow behavior of the
+	// FederatingWrappedCallbacks is OnFollowManuallyApprove. An Accept is
+	// delivered to the actors of the Follow, they are added to the
+	// 'followers' collection, and the Follow is removed from the pending
+	// follow requests.
+	ApproveFollowRequest(c context.Context, inbox, follow *url.URL) error
+	// DenyFollowRequest rejects a Follow awaiting approval by the actor
+	// owning the inbox.
+	//
+	// A Reject is delivered to the actors of the Follow, and the Follow is
+	// removed from the pending follow requests.
+	DenyFollowRequest(c context.Context, inbox, follow *url.URL) error
+	// ForwardReport sends a Report in the ModerationQueue to the servers
+	// owning its reported objects.
+	//
+	// A new Flag of the Report's remote objects is delivered to the actors
+	// owning them, or to the reported actors themselves, on behalf of the
+	// actor owning the inbox, without revealing the original reporter.
+	// The Report stays in the ModerationQueue until resolved.
+	ForwardReport(c context.Context, inbox, report *url.URL) error
 }
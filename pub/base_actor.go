@@ -185,84 +185,147 @@ func (b *baseActor) PostInboxScheme(c context.Context, w http.ResponseWriter, r
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return true, nil
 	}
-	// Check the peer request is authentic.
-	c, authenticated, err := b.delegate.AuthenticatePostInbox(c, w, r)
-	if err != nil {
+	c, inboxId, activity, raw, err := receiveInboxActivity(c, b.delegate, w, r, scheme)
+	if err != nil || activity == nil {
 		return true, err
-	} else if !authenticated {
+	}
+	if rejected, err := postInboxActivity(c, b.delegate, w, inboxId, activity, raw); err == ErrBlocked {
+		// Send the rejection to the blocked peer.
+		w.WriteHeader(http.StatusForbidden)
 		return true, nil
+	} else if err != nil || rejected {
+		return true, err
+	}
+	// Request has been processed. Begin responding to the request.
+	//
+	// Simply respond with an OK status to the peer.
+	w.WriteHeader(http.StatusOK)
+	return true, nil
+}
+
+// receiveInboxActivity authenticates a POST request to an inbox, and obtains
+// the Activity in its body once its digest and authorization are checked. The
+// raw body is returned alongside the Activity.
+//
+// If the request is rejected, the response has been written and a nil
+// Activity is returned.
+func receiveInboxActivity(c context.Context, delegate DelegateActor, w http.ResponseWriter, r *http.Request, scheme string) (out context.Context, inboxId *url.URL, activity Activity, raw []byte, err error) {
+	// Check the peer request is authentic.
+	c, authenticated, err := delegate.AuthenticatePostInbox(c, w, r)
+	if err != nil || !authenticated {
+		return
 	}
 	// Begin processing the request, but have not yet applied
 	// authorization (ex: blocks). Obtain the activity reject unknown
 	// activities.
-	raw, err := ioutil.ReadAll(r.Body)
+	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return true, err
+		return
+	}
+	// Ensure the body was not tampered with in transit.
+	inboxId = requestId(r, scheme)
+	if !verifyDigest(r.Header, body, delegate.PostInboxDigestAlgorithms(c, inboxId)) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
 	}
 	var m map[string]interface{}
-	if err = json.Unmarshal(raw, &m); err != nil {
-		return true, err
+	if err = json.Unmarshal(body, &m); err != nil {
+		// Respond with bad request -- the body is not JSON.
+		w.WriteHeader(http.StatusBadRequest)
+		err = nil
+		return
 	}
 	asValue, err := streams.ToType(c, m)
 	if err != nil && !streams.IsUnmatchedErr(err) {
-		return true, err
+		return
 	} else if streams.IsUnmatchedErr(err) {
 		// Respond with bad request -- we do not understand the type.
 		w.WriteHeader(http.StatusBadRequest)
-		return true, nil
+		err = nil
+		return
 	}
-	activity, ok := asValue.(Activity)
+	a, ok := asValue.(Activity)
 	if !ok {
-		return true, fmt.Errorf("activity streams value is not an Activity: %T", asValue)
+		err = fmt.Errorf("activity streams value is not an Activity: %T", asValue)
+		return
 	}
-	if activity.GetJSONLDId() == nil {
+	if a.GetJSONLDId() == nil {
 		w.WriteHeader(http.StatusBadRequest)
-		return true, nil
+		return
 	}
 	// Allow server implementations to set context data with a hook.
-	c, err = b.delegate.PostInboxRequestBodyHook(c, r, activity)
+	c, err = delegate.PostInboxRequestBodyHook(c, r, a)
 	if err != nil {
-		return true, err
+		return
 	}
 	// Check authorization of the activity.
-	authorized, err := b.delegate.AuthorizePostInbox(c, w, activity)
-	if err != nil {
-		return true, err
-	} else if !authorized {
-		return true, nil
+	authorized, err := delegate.AuthorizePostInbox(c, w, a)
+	if err != nil || !authorized {
+		return
 	}
+	out, activity, raw = c, a, body
+	return
+}
+
+// postInboxActivity posts the activity to the inbox, triggering its side
+// effects and inbox forwarding.
+//
+// The raw body the activity was received in is given to InboxForwarding, so
+// that it can be forwarded unchanged.
+//
+// If the activity is rejected as a bad request, the response has been written
+// and 'rejected' is true.
+func postInboxActivity(c context.Context, delegate DelegateActor, w http.ResponseWriter, inboxId *url.URL, activity Activity, raw []byte) (rejected bool, err error) {
 	// Post the activity to the actor's inbox and trigger side effects for
 	// that particular Activity type. It is up to the delegate to resolve
 	// the given map.
-	inboxId := requestId(r, scheme)
-	err = b.delegate.PostInbox(c, inboxId, activity)
+	err = delegate.PostInbox(c, inboxId, activity)
 	if err != nil {
 		// Special case: We know it is a bad request if the object or
-		// target properties needed to be populated, but weren't.
+		// target properties needed to be populated, but weren't, or if
+		// it is an invalid vote or one in a closed poll.
 		//
 		// Send the rejection to the peer.
-		if err == ErrObjectRequired || err == ErrTargetRequired {
+		if err == ErrObjectRequired || err == ErrTargetRequired || err == ErrPollClosed || err == ErrInvalidVote {
 			w.WriteHeader(http.StatusBadRequest)
 			return true, nil
 		}
-		return true, err
+		return false, err
 	}
 	// Our side effects are complete, now delegate determining whether to
 	// do inbox forwarding, as well as the action to do it.
-	if err := b.delegate.InboxForwarding(c, inboxId, activity); err != nil {
-		return true, err
-	}
-	// Request has been processed. Begin responding to the request.
-	//
-	// Simply respond with an OK status to the peer.
-	w.WriteHeader(http.StatusOK)
-	return true, nil
+	return false, delegate.InboxForwarding(withReceivedActivity(c, raw), inboxId, activity)
+}
+
+// withReceivedActivity returns a copy of the context holding the raw body an
+// activity was received in.
+func withReceivedActivity(c context.Context, raw []byte) context.Context {
+	return context.WithValue(c, receivedActivityContextKey, raw)
+}
+
+// receivedActivity returns the raw body an activity was received in, if it is
+// held by the context.
+func receivedActivity(c context.Context) (raw []byte, ok bool) {
+	raw, ok = c.Value(receivedActivityContextKey).([]byte)
+	return
 }
 
 // GetInbox implements the generic algorithm for handling a GET request to an
 // actor's inbox independent on an application. It relies on a delegate to
 // implement application specific functionality.
+//
+// Only supports serving data with identifiers having the HTTPS scheme.
 func (b *baseActor) GetInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	return b.GetInboxScheme(c, w, r, "https")
+}
+
+// GetInboxScheme implements the generic algorithm for handling a GET request to
+// an actor's inbox independent on an application. It relies on a delegate to
+// implement application specific functionality.
+//
+// Specifying the "scheme" allows for retrieving ActivityStreams content with
+// identifiers such as HTTP, HTTPS, or other protocol schemes.
+func (b *baseActor) GetInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
 	// Do nothing if it is not an ActivityPub GET request.
 	if !isActivityPubGet(r) {
 		return false, nil
@@ -275,36 +338,7 @@ func (b *baseActor) GetInbox(c context.Context, w http.ResponseWriter, r *http.R
 		return true, nil
 	}
 	// Everything is good to begin processing the request.
-	oc, err := b.delegate.GetInbox(c, r)
-	if err != nil {
-		return true, err
-	}
-	// Deduplicate the 'orderedItems' property by ID.
-	err = dedupeOrderedItems(oc)
-	if err != nil {
-		return true, err
-	}
-	// Request has been processed. Begin responding to the request.
-	//
-	// Serialize the OrderedCollection.
-	m, err := streams.Serialize(oc)
-	if err != nil {
-		return true, err
-	}
-	raw, err := json.Marshal(m)
-	if err != nil {
-		return true, err
-	}
-	// Write the response.
-	addResponseHeaders(w.Header(), b.clock, raw)
-	w.WriteHeader(http.StatusOK)
-	n, err := w.Write(raw)
-	if err != nil {
-		return true, err
-	} else if n != len(raw) {
-		return true, fmt.Errorf("ResponseWriter.Write wrote %d of %d bytes", n, len(raw))
-	}
-	return true, nil
+	return true, b.writeOrderedCollection(c, w, r, collectionId(r, scheme), b.delegate.GetInbox)
 }
 
 // PostOutbox implements the generic algorithm for handling a POST request to an
@@ -346,7 +380,9 @@ func (b *baseActor) PostOutboxScheme(c context.Context, w http.ResponseWriter, r
 	}
 	var m map[string]interface{}
 	if err = json.Unmarshal(raw, &m); err != nil {
-		return true, err
+		// Respond with bad request -- the body is not JSON.
+		w.WriteHeader(http.StatusBadRequest)
+		return true, nil
 	}
 	// Note that converting to a Type will NOT successfully convert types
 	// not known to go-fed. This prevents accidentally wrapping an Activity
@@ -388,7 +424,19 @@ func (b *baseActor) PostOutboxScheme(c context.Context, w http.ResponseWriter, r
 // GetOutbox implements the generic algorithm for handling a Get request to an
 // actor's outbox independent on an application. It relies on a delegate to
 // implement application specific functionality.
+//
+// Only supports serving data with identifiers having the HTTPS scheme.
 func (b *baseActor) GetOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	return b.GetOutboxScheme(c, w, r, "https")
+}
+
+// GetOutboxScheme implements the generic algorithm for handling a Get request
+// to an actor's outbox independent on an application. It relies on a delegate
+// to implement application specific functionality.
+//
+// Specifying the "scheme" allows for retrieving ActivityStreams content with
+// identifiers such as HTTP, HTTPS, or other protocol schemes.
+func (b *baseActor) GetOutboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
 	// Do nothing if it is not an ActivityPub GET request.
 	if !isActivityPubGet(r) {
 		return false, nil
@@ -401,31 +449,60 @@ func (b *baseActor) GetOutbox(c context.Context, w http.ResponseWriter, r *http.
 		return true, nil
 	}
 	// Everything is good to begin processing the request.
-	oc, err := b.delegate.GetOutbox(c, r)
+	return true, b.writeOrderedCollection(c, w, r, collectionId(r, scheme), b.delegate.GetOutbox)
+}
+
+// writeOrderedCollection responds to a GET request to the inbox or outbox with
+// the id.
+//
+// Requests for a page get the OrderedCollectionPage with the items selected by
+// the 'max_id' and 'min_id' query parameters, with duplicate items removed.
+// Otherwise, the OrderedCollection linking to its pages is returned.
+func (b *baseActor) writeOrderedCollection(c context.Context, w http.ResponseWriter, r *http.Request, id *url.URL, getItems func(context.Context, *http.Request, *url.URL, CollectionCursor) (vocab.ActivityStreamsOrderedItemsProperty, int, error)) error {
+	cursor, isPage, err := parseCollectionCursor(r, b.delegate.CollectionPageSize(c))
 	if err != nil {
-		return true, err
+		w.WriteHeader(http.StatusBadRequest)
+		return nil
+	}
+	items, totalItems, err := getItems(c, r, id, cursor)
+	if err != nil {
+		return err
+	}
+	var oc vocab.Type
+	if isPage {
+		oc, err = toOrderedCollectionPage(id, cursor, items)
+		if err != nil {
+			return err
+		}
+	} else {
+		oc = toOrderedCollection(id, totalItems)
 	}
 	// Request has been processed. Begin responding to the request.
 	//
 	// Serialize the OrderedCollection.
 	m, err := streams.Serialize(oc)
 	if err != nil {
-		return true, err
+		return err
 	}
 	raw, err := json.Marshal(m)
 	if err != nil {
-		return true, err
+		return err
 	}
 	// Write the response.
 	addResponseHeaders(w.Header(), b.clock, raw)
+	addCachingHeaders(w.Header(), oc, raw)
+	if isNotModified(r, w.Header()) {
+		writeNotModified(w)
+		return nil
+	}
 	w.WriteHeader(http.StatusOK)
 	n, err := w.Write(raw)
 	if err != nil {
-		return true, err
+		return err
 	} else if n != len(raw) {
-		return true, fmt.Errorf("ResponseWriter.Write wrote %d of %d bytes", n, len(raw))
+		return fmt.Errorf("ResponseWriter.Write wrote %d of %d bytes", n, len(raw))
 	}
-	return true, nil
+	return nil
 }
 
 // deliver delegates all outbox handling steps and optionally will federate the
@@ -492,3 +569,21 @@ func (b *baseActor) deliver(c context.Context, outbox *url.URL, asValue vocab.Ty
 func (b *baseActorFederating) Send(c context.Context, outbox *url.URL, t vocab.Type) (Activity, error) {
 	return b.deliver(c, outbox, t, nil)
 }
+
+// ApproveFollowRequest is programmatically accessible if the federated
+// protocol is enabled.
+func (b *baseActorFederating) ApproveFollowRequest(c context.Context, inbox, follow *url.URL) error {
+	return b.delegate.RespondToFollowRequest(c, inbox, follow, true)
+}
+
+// DenyFollowRequest is programmatically accessible if the federated protocol is
+// enabled.
+func (b *baseActorFederating) DenyFollowRequest(c context.Context, inbox, follow *url.URL) error {
+	return b.delegate.RespondToFollowRequest(c, inbox, follow, false)
+}
+
+// ForwardReport is programmatically accessible if the federated protocol is
+// enabled.
+func (b *baseActorFederating) ForwardReport(c context.Context, inbox, report *url.URL) error {
+	return b.delegate.ForwardReport(c, inbox, report)
+}
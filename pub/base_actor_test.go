@@ -1,6 +1,7 @@
 package pub
 
 import (
+	"bytes"
 	"context"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/golang/mock/gomock"
@@ -96,7 +97,8 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toGetInboxRequest())
 		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().GetInbox(ctx, req).Return(testOrderedCollectionUniqueElems, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{}).Return(nil, 2, nil)
 		clock.EXPECT().Now().Return(now())
 		// Run the test
 		handled, err := a.GetInbox(ctx, resp, req)
@@ -110,17 +112,110 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		assertNotEqual(t, len(respV.Header.Get(digestHeader)), 0)
 		b, err := ioutil.ReadAll(respV.Body)
 		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(`{"@context":"https://www.w3.org/ns/activitystreams","first":"https://example.com/addison/inbox?page=true","id":"https://example.com/addison/inbox","last":"https://example.com/addison/inbox?min_id=\u0026page=true","totalItems":2,"type":"OrderedCollection"}`))
+	})
+	t.Run("GetInboxRespondsNotModifiedForMatchingETag", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toGetInboxRequest())
+		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{}).Return(nil, 2, nil)
+		clock.EXPECT().Now().Return(now())
+		_, err := a.GetInbox(ctx, resp, req)
+		assertEqual(t, err, nil)
+		etag := resp.Result().Header.Get(etagHeader)
+		assertNotEqual(t, len(etag), 0)
+		resp = httptest.NewRecorder()
+		req = toAPRequest(toGetInboxRequest())
+		req.Header.Set(ifNoneMatchHeader, etag)
+		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{}).Return(nil, 2, nil)
+		clock.EXPECT().Now().Return(now())
+		// Run the test
+		handled, err := a.GetInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusNotModified)
+		respV := resp.Result()
+		assertEqual(t, respV.Header.Get(etagHeader), etag)
+		b, err := ioutil.ReadAll(respV.Body)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(b), 0)
+	})
+	t.Run("GetInboxRespondsWithPage", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toGetInboxPageRequest())
+		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{Limit: collectionPageSize}).Return(testOrderedCollectionUniqueElems.GetActivityStreamsOrderedItems(), 2, nil)
+		clock.EXPECT().Now().Return(now())
+		// Run the test
+		handled, err := a.GetInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
 		assertByteEqual(t, b, []byte(testOrderedCollectionUniqueElemsString))
 	})
+	t.Run("GetInboxSchemeRespondsWithPageOfConfiguredSize", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", "http://example.com/addison/inbox?page=true", nil))
+		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(2)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse("http://example.com/addison/inbox"), CollectionCursor{Limit: 2}).Return(nil, 0, nil)
+		clock.EXPECT().Now().Return(now())
+		// Run the test
+		handled, err := a.GetInboxScheme(ctx, resp, req, "http")
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(`{"@context":"https://www.w3.org/ns/activitystreams","id":"http://example.com/addison/inbox?page=true","partOf":"http://example.com/addison/inbox","type":"OrderedCollectionPage"}`))
+	})
+	t.Run("GetInboxRejectsInvalidCursor", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testMyInboxPageIRI+"&max_id=%3A", nil))
+		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		// Run the test
+		handled, err := a.GetInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
 	t.Run("GetInboxDeduplicatesData", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		delegate, clock, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
-		req := toAPRequest(toGetInboxRequest())
+		req := toAPRequest(toGetInboxPageRequest())
 		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().GetInbox(ctx, req).Return(testOrderedCollectionDupedElems, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{Limit: collectionPageSize}).Return(testOrderedCollectionDupedElems.GetActivityStreamsOrderedItems(), 2, nil)
 		clock.EXPECT().Now().Return(now())
 		// Run the test
 		_, err := a.GetInbox(ctx, resp, req)
@@ -178,6 +273,21 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		assertEqual(t, handled, true)
 		assertEqual(t, resp.Code, http.StatusBadRequest)
 	})
+	t.Run("PostOutboxBadRequestIfBodyIsNotJSON", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("POST", testMyOutboxIRI, bytes.NewBufferString("{")))
+		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
+		// Run the test
+		handled, err := a.PostOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
 	t.Run("PostOutboxRespondsWithDataAndHeaders", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -327,7 +437,8 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toGetOutboxRequest())
 		delegate.EXPECT().AuthenticateGetOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().GetOutbox(ctx, req).Return(testOrderedCollectionUniqueElems, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetOutbox(ctx, req, mustParse(testMyOutboxIRI), CollectionCursor{}).Return(nil, 2, nil)
 		clock.EXPECT().Now().Return(now())
 		// Run the test
 		handled, err := a.GetOutbox(ctx, resp, req)
@@ -341,7 +452,7 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		assertNotEqual(t, len(respV.Header.Get(digestHeader)), 0)
 		b, err := ioutil.ReadAll(respV.Body)
 		assertEqual(t, err, nil)
-		assertByteEqual(t, b, []byte(testOrderedCollectionUniqueElemsString))
+		assertByteEqual(t, b, []byte(`{"@context":"https://www.w3.org/ns/activitystreams","first":"https://example.com/addison/outbox?page=true","id":"https://example.com/addison/outbox","last":"https://example.com/addison/outbox?min_id=\u0026page=true","totalItems":2,"type":"OrderedCollection"}`))
 	})
 }
 
@@ -402,6 +513,56 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxUnknownRequest())
 		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
+	t.Run("PostInboxBadRequestIfBodyIsNotJSON", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("POST", testMyInboxIRI, bytes.NewBufferString("{")))
+		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
+	t.Run("PostInboxBadRequestIfDigestMismatch", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		setDigestHeaders(req.Header, DigestSha256, mustSerializeToBytes(testCreate2))
+		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
+	t.Run("PostInboxBadRequestIfRequiredDigestMissing", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return([]DigestAlgorithm{DigestSha256})
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -417,6 +578,7 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
 		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyOutboxIRI)).Return(nil)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -432,6 +594,7 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
 		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
 		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
 		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).DoAndReturn(func(ctx context.Context, resp http.ResponseWriter, activity Activity) (bool, error) {
 			resp.WriteHeader(http.StatusForbidden)
@@ -452,10 +615,11 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
 		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
 		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
 		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
 		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
-		delegate.EXPECT().InboxForwarding(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		delegate.EXPECT().InboxForwarding(withReceivedActivity(ctx, toPostInboxBody(testCreate)), mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -471,6 +635,7 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
 		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
 		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
 		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
 		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrObjectRequired)
@@ -481,6 +646,25 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		assertEqual(t, handled, true)
 		assertEqual(t, resp.Code, http.StatusBadRequest)
 	})
+	t.Run("PostInboxForbiddenForErrBlocked", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
+		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrBlocked)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusForbidden)
+	})
 	t.Run("PostInboxBadRequestForErrTargetRequired", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -489,6 +673,7 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
 		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
 		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
 		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
 		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrTargetRequired)
@@ -539,7 +724,8 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toGetInboxRequest())
 		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().GetInbox(ctx, req).Return(testOrderedCollectionUniqueElems, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{}).Return(nil, 2, nil)
 		clock.EXPECT().Now().Return(now())
 		// Run the test
 		handled, err := a.GetInbox(ctx, resp, req)
@@ -553,17 +739,55 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		assertNotEqual(t, len(respV.Header.Get(digestHeader)), 0)
 		b, err := ioutil.ReadAll(respV.Body)
 		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(`{"@context":"https://www.w3.org/ns/activitystreams","first":"https://example.com/addison/inbox?page=true","id":"https://example.com/addison/inbox","last":"https://example.com/addison/inbox?min_id=\u0026page=true","totalItems":2,"type":"OrderedCollection"}`))
+	})
+	t.Run("GetInboxRespondsWithPage", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toGetInboxPageRequest())
+		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{Limit: collectionPageSize}).Return(testOrderedCollectionUniqueElems.GetActivityStreamsOrderedItems(), 2, nil)
+		clock.EXPECT().Now().Return(now())
+		// Run the test
+		handled, err := a.GetInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
 		assertByteEqual(t, b, []byte(testOrderedCollectionUniqueElemsString))
 	})
+	t.Run("GetInboxRejectsInvalidCursor", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testMyInboxPageIRI+"&max_id=%3A", nil))
+		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		// Run the test
+		handled, err := a.GetInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
 	t.Run("GetInboxDeduplicatesData", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		delegate, clock, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
-		req := toAPRequest(toGetInboxRequest())
+		req := toAPRequest(toGetInboxPageRequest())
 		delegate.EXPECT().AuthenticateGetInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().GetInbox(ctx, req).Return(testOrderedCollectionDupedElems, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetInbox(ctx, req, mustParse(testMyInboxIRI), CollectionCursor{Limit: collectionPageSize}).Return(testOrderedCollectionDupedElems.GetActivityStreamsOrderedItems(), 2, nil)
 		clock.EXPECT().Now().Return(now())
 		// Run the test
 		_, err := a.GetInbox(ctx, resp, req)
@@ -642,7 +866,8 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toGetOutboxRequest())
 		delegate.EXPECT().AuthenticateGetOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().GetOutbox(ctx, req).Return(testOrderedCollectionUniqueElems, nil)
+		delegate.EXPECT().CollectionPageSize(ctx).Return(collectionPageSize)
+		delegate.EXPECT().GetOutbox(ctx, req, mustParse(testMyOutboxIRI), CollectionCursor{}).Return(nil, 2, nil)
 		clock.EXPECT().Now().Return(now())
 		// Run the test
 		handled, err := a.GetOutbox(ctx, resp, req)
@@ -656,7 +881,7 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		assertNotEqual(t, len(respV.Header.Get(digestHeader)), 0)
 		b, err := ioutil.ReadAll(respV.Body)
 		assertEqual(t, err, nil)
-		assertByteEqual(t, b, []byte(testOrderedCollectionUniqueElemsString))
+		assertByteEqual(t, b, []byte(`{"@context":"https://www.w3.org/ns/activitystreams","first":"https://example.com/addison/outbox?page=true","id":"https://example.com/addison/outbox","last":"https://example.com/addison/outbox?min_id=\u0026page=true","totalItems":2,"type":"OrderedCollection"}`))
 	})
 }
 
@@ -685,10 +910,11 @@ func TestBaseActor(t *testing.T) {
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
 		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return(nil)
 		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
 		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
 		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
-		delegate.EXPECT().InboxForwarding(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		delegate.EXPECT().InboxForwarding(withReceivedActivity(ctx, toPostInboxBody(testCreate)), mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
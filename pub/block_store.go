@@ -0,0 +1,93 @@
+package pub
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+// BlockStore records which actors each local actor has blocked.
+//
+// It is obtained from the BlockStoreProvider's BlockStore method, and is
+// updated when a local actor sends a Block or an Undo of a Block through the
+// Social API. Activities federated from blocked actors are rejected when
+// posted to the inbox of the local actor that blocked them.
+//
+// It must be safe to use concurrently.
+type BlockStore interface {
+	// AddBlocks records that the actor has blocked the other actors.
+	AddBlocks(c context.Context, actorIRI *url.URL, blocked []*url.URL) error
+	// RemoveBlocks records that the actor no longer blocks the other
+	// actors.
+	RemoveBlocks(c context.Context, actorIRI *url.URL, unblocked []*url.URL) error
+	// Blocked determines whether the actor has blocked any of the other
+	// actors.
+	Blocked(c context.Context, actorIRI *url.URL, others []*url.URL) (blocked bool, err error)
+}
+
+// BlockStore must be implemented by MemoryBlockStore.
+var _ BlockStore = &MemoryBlockStore{}
+
+// MemoryBlockStore is a BlockStore that keeps blocks in memory.
+//
+// Blocks are lost when the process exits, so it is best suited for tests and
+// applications that persist blocks elsewhere as well.
+//
+// It is safe to use concurrently.
+type MemoryBlockStore struct {
+	mu     *sync.RWMutex
+	blocks map[string]map[string]bool
+}
+
+// NewMemoryBlockStore returns a new, empty MemoryBlockStore.
+func NewMemoryBlockStore() *MemoryBlockStore {
+	return &MemoryBlockStore{
+		mu:     &sync.RWMutex{},
+		blocks: make(map[string]map[string]bool),
+	}
+}
+
+// AddBlocks records that the actor has blocked the other actors.
+func (m *MemoryBlockStore) AddBlocks(c context.Context, actorIRI *url.URL, blocked []*url.URL) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.blocks[actorIRI.String()]
+	if !ok {
+		b = make(map[string]bool, len(blocked))
+		m.blocks[actorIRI.String()] = b
+	}
+	for _, iri := range blocked {
+		b[iri.String()] = true
+	}
+	return nil
+}
+
+// RemoveBlocks records that the actor no longer blocks the other actors.
+func (m *MemoryBlockStore) RemoveBlocks(c context.Context, actorIRI *url.URL, unblocked []*url.URL) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.blocks[actorIRI.String()]
+	if !ok {
+		return nil
+	}
+	for _, iri := range unblocked {
+		delete(b, iri.String())
+	}
+	if len(b) == 0 {
+		delete(m.blocks, actorIRI.String())
+	}
+	return nil
+}
+
+// Blocked determines whether the actor has blocked any of the other actors.
+func (m *MemoryBlockStore) Blocked(c context.Context, actorIRI *url.URL, others []*url.URL) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	b := m.blocks[actorIRI.String()]
+	for _, iri := range others {
+		if b[iri.String()] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,105 @@
+package pub
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// The ETag header.
+	etagHeader = "ETag"
+	// The Last-Modified header.
+	lastModifiedHeader = "Last-Modified"
+	// The Cache-Control header.
+	cacheControlHeader = "Cache-Control"
+	// The If-None-Match header.
+	ifNoneMatchHeader = "If-None-Match"
+	// The If-Modified-Since header.
+	ifModifiedSinceHeader = "If-Modified-Since"
+)
+
+// CacheControlPolicy maps the names of ActivityStreams types, such as "Person"
+// or "Tombstone", to the Cache-Control header served with values of that type.
+//
+// The entry for the empty type name, if any, is used for types without their
+// own entry. No Cache-Control header is served for a type without an entry
+// when there is no default.
+type CacheControlPolicy map[string]string
+
+// cacheControl returns the Cache-Control header value for the value, or the
+// empty string if none should be served.
+func (p CacheControlPolicy) cacheControl(t vocab.Type) string {
+	if cc, ok := p[t.GetTypeName()]; ok {
+		return cc
+	}
+	return p[""]
+}
+
+// addCachingHeaders sets the ETag header, derived from the response content,
+// and the Last-Modified header, from the 'updated' or else 'published' time of
+// the value if it has one.
+func addCachingHeaders(h http.Header, t vocab.Type, responseContent []byte) {
+	sum := sha256.Sum256(responseContent)
+	h.Set(etagHeader, "\""+hex.EncodeToString(sum[:])+"\"")
+	if lastModified, ok := lastModifiedTime(t); ok {
+		h.Set(lastModifiedHeader, lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// lastModifiedTime returns the 'updated' time of the value, or its
+// 'published' time if it was never updated.
+func lastModifiedTime(t vocab.Type) (time.Time, bool) {
+	if u, ok := t.(updateder); ok {
+		if updated := u.GetActivityStreamsUpdated(); updated != nil && updated.IsXMLSchemaDateTime() {
+			return updated.Get(), true
+		}
+	}
+	if p, ok := t.(publisheder); ok {
+		if published := p.GetActivityStreamsPublished(); published != nil && published.IsXMLSchemaDateTime() {
+			return published.Get(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isNotModified determines whether the conditional headers of the request show
+// that the client already has the response described by the caching headers,
+// as defined in RFC 7232.
+//
+// If-Modified-Since is only considered when there is no If-None-Match header.
+func isNotModified(r *http.Request, h http.Header) bool {
+	if ifNoneMatch := r.Header.Get(ifNoneMatchHeader); len(ifNoneMatch) > 0 {
+		etag := h.Get(etagHeader)
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// If-None-Match uses the weak comparison function.
+			candidate = strings.TrimPrefix(candidate, "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	ifModifiedSince, err := http.ParseTime(r.Header.Get(ifModifiedSinceHeader))
+	if err != nil {
+		return false
+	}
+	lastModified, err := http.ParseTime(h.Get(lastModifiedHeader))
+	if err != nil {
+		return false
+	}
+	return !lastModified.After(ifModifiedSince)
+}
+
+// writeNotModified responds with the http.StatusNotModified status code,
+// keeping only the headers that describe the cached response.
+func writeNotModified(w http.ResponseWriter) {
+	w.Header().Del(contentTypeHeader)
+	w.Header().Del(digestHeader)
+	w.WriteHeader(http.StatusNotModified)
+}
@@ -0,0 +1,246 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// The Expires header.
+	expiresHeader = "Expires"
+	// The Age header.
+	ageHeader = "Age"
+	// The Vary header.
+	varyHeader = "Vary"
+)
+
+// DereferenceCacheEntry is an ActivityStreams value previously fetched by a
+// CachingTransport, along with what is needed to decide whether it may be
+// reused and to revalidate it with the peer.
+type DereferenceCacheEntry struct {
+	// Body is the content of the response.
+	Body []byte
+	// ETag is the ETag header of the response, if any.
+	ETag string
+	// LastModified is the Last-Modified header of the response, if any.
+	LastModified string
+	// Expires is when the entry is no longer fresh and must be
+	// revalidated with the peer before it is reused.
+	Expires time.Time
+}
+
+// DereferenceCache stores the values fetched by a CachingTransport.
+//
+// It must be safe to use concurrently.
+type DereferenceCache interface {
+	// Get returns the entry for the IRI, or nil if there is none.
+	Get(c context.Context, iri *url.URL) (entry *DereferenceCacheEntry, err error)
+	// Put stores the entry for the IRI, replacing any existing one.
+	Put(c context.Context, iri *url.URL, entry *DereferenceCacheEntry) error
+	// Remove deletes the entry for the IRI, if any.
+	Remove(c context.Context, iri *url.URL) error
+}
+
+// Transport must be implemented by CachingTransport.
+var _ Transport = &CachingTransport{}
+
+// CachingTransport wraps a ConditionalTransport so that dereferenced values
+// are cached according to the Cache-Control, Expires, ETag and Last-Modified
+// headers of the peer's response.
+//
+// A fresh value is returned from the cache without a request. A stale value
+// that has an ETag or Last-Modified header is revalidated with a conditional
+// GET request, and is reused if the peer responds that it is not modified.
+//
+// The same DereferenceCache is meant to be shared by the Transports of all
+// local actors, so that values fetched for one actor are reused for the
+// others. Responses with the "private" or "no-store" Cache-Control directives,
+// or that vary with the signature or authorization of the request, are
+// therefore never stored, and the "s-maxage" directive takes precedence over
+// "max-age".
+//
+// Deliveries are passed through to the wrapped Transport.
+type CachingTransport struct {
+	t     ConditionalTransport
+	cache DereferenceCache
+	clock Clock
+}
+
+// NewCachingTransport returns a CachingTransport that fetches values with the
+// ConditionalTransport and stores them in the DereferenceCache.
+func NewCachingTransport(t ConditionalTransport, cache DereferenceCache, clock Clock) *CachingTransport {
+	return &CachingTransport{
+		t:     t,
+		cache: cache,
+		clock: clock,
+	}
+}
+
+// Dereference returns the cached value of the IRI if it is fresh, or else
+// fetches it with the wrapped Transport, revalidating any stale cached value.
+func (t *CachingTransport) Dereference(c context.Context, iri *url.URL) ([]byte, error) {
+	cached, err := t.cache.Get(c, iri)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && t.clock.Now().Before(cached.Expires) {
+		return cached.Body, nil
+	}
+	reqHeader := make(http.Header)
+	if cached != nil {
+		if len(cached.ETag) > 0 {
+			reqHeader.Set(ifNoneMatchHeader, cached.ETag)
+		}
+		if len(cached.LastModified) > 0 {
+			reqHeader.Set(ifModifiedSinceHeader, cached.LastModified)
+		}
+	}
+	b, respHeader, notModified, err := t.t.ConditionalDereference(c, iri, reqHeader)
+	if err != nil {
+		return nil, err
+	}
+	if notModified {
+		if cached == nil {
+			return nil, fmt.Errorf("GET request to %s was not modified, but nothing is cached", iri)
+		}
+		b = cached.Body
+	}
+	entry, ok := newDereferenceCacheEntry(t.clock.Now(), b, respHeader)
+	if !ok {
+		if cached != nil {
+			if err = t.cache.Remove(c, iri); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	// A not modified response need not repeat the validators.
+	if notModified {
+		if len(entry.ETag) == 0 {
+			entry.ETag = cached.ETag
+		}
+		if len(entry.LastModified) == 0 {
+			entry.LastModified = cached.LastModified
+		}
+	}
+	if err = t.cache.Put(c, iri, entry); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Deliver sends an ActivityStreams object with the wrapped Transport.
+func (t *CachingTransport) Deliver(c context.Context, b []byte, to *url.URL) error {
+	return t.t.Deliver(c, b, to)
+}
+
+// BatchDeliver sends an ActivityStreams object to multiple recipients with the
+// wrapped Transport.
+func (t *CachingTransport) BatchDeliver(c context.Context, b []byte, recipients []*url.URL) error {
+	return t.t.BatchDeliver(c, b, recipients)
+}
+
+// newDereferenceCacheEntry builds the cache entry for a response received at
+// the given time, as described by RFC 7234 for a shared cache. Returns false
+// if the response must not be stored, or could never be reused.
+func newDereferenceCacheEntry(now time.Time, b []byte, h http.Header) (*DereferenceCacheEntry, bool) {
+	directives := parseCacheControl(h.Get(cacheControlHeader))
+	if _, ok := directives["no-store"]; ok {
+		return nil, false
+	} else if _, ok := directives["private"]; ok {
+		return nil, false
+	} else if variesBySigner(h) {
+		return nil, false
+	}
+	entry := &DereferenceCacheEntry{
+		Body:         b,
+		ETag:         h.Get(etagHeader),
+		LastModified: h.Get(lastModifiedHeader),
+		Expires:      now,
+	}
+	// A "no-cache" response is always revalidated.
+	if _, ok := directives["no-cache"]; !ok {
+		entry.Expires = freshUntil(now, directives, h)
+	}
+	if !now.Before(entry.Expires) && len(entry.ETag) == 0 && len(entry.LastModified) == 0 {
+		return nil, false
+	}
+	return entry, true
+}
+
+// variesBySigner determines whether the Vary header of a response indicates
+// that it may differ for each signer of the request, such as a peer serving
+// values only to some authorized fetches. Such a response must not be reused
+// for other local actors, so it is not stored.
+func variesBySigner(h http.Header) bool {
+	for _, v := range h[varyHeader] {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" ||
+				strings.EqualFold(name, signatureHeader) ||
+				strings.EqualFold(name, authorizationHeader) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// freshUntil returns when a response received at the given time stops being
+// fresh, which is immediately if the headers give no explicit lifetime.
+func freshUntil(now time.Time, directives map[string]string, h http.Header) time.Time {
+	if maxAge, ok := directiveSeconds(directives, "s-maxage"); ok {
+		return now.Add(maxAge - ageOf(h))
+	} else if maxAge, ok := directiveSeconds(directives, "max-age"); ok {
+		return now.Add(maxAge - ageOf(h))
+	} else if expires, err := http.ParseTime(h.Get(expiresHeader)); err == nil {
+		return expires
+	}
+	return now
+}
+
+// parseCacheControl returns the lowercased directives of a Cache-Control
+// header mapped to their unquoted values, if any.
+func parseCacheControl(cc string) map[string]string {
+	directives := make(map[string]string)
+	for _, d := range strings.Split(cc, ",") {
+		d = strings.TrimSpace(d)
+		if len(d) == 0 {
+			continue
+		}
+		var v string
+		if i := strings.Index(d, "="); i >= 0 {
+			d, v = d[:i], strings.Trim(d[i+1:], "\"")
+		}
+		directives[strings.ToLower(d)] = v
+	}
+	return directives
+}
+
+// directiveSeconds returns the duration of a Cache-Control directive whose
+// value is a number of seconds.
+func directiveSeconds(directives map[string]string, name string) (time.Duration, bool) {
+	v, ok := directives[name]
+	if !ok {
+		return 0, false
+	}
+	s, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || s < 0 {
+		return 0, false
+	}
+	return time.Duration(s) * time.Second, true
+}
+
+// ageOf returns the duration in the Age header, or zero if absent.
+func ageOf(h http.Header) time.Duration {
+	s, err := strconv.ParseInt(h.Get(ageHeader), 10, 64)
+	if err != nil || s < 0 {
+		return 0
+	}
+	return time.Duration(s) * time.Second
+}
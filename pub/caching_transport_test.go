@@ -0,0 +1,177 @@
+package pub
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestCachingTransportDereference tests caching and revalidating dereferenced
+// values.
+func TestCachingTransportDereference(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (ct *MockConditionalTransport, c *MockClock, tp *CachingTransport) {
+		ct = NewMockConditionalTransport(ctl)
+		c = NewMockClock(ctl)
+		tp = NewCachingTransport(ct, NewMemoryDereferenceCache(10), c)
+		return
+	}
+	t.Run("ReusesFreshResponse", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		ct, c, tp := setupFn(ctl)
+		respHeader := http.Header{}
+		respHeader.Set(cacheControlHeader, "public, max-age=60")
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ct.EXPECT().ConditionalDereference(ctx, mustParse(testFederatedActorIRI), http.Header{}).Return(testRespBody, respHeader, false, nil)
+		c.EXPECT().Now().Return(now().Add(59 * time.Second))
+		// Run
+		b, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, testRespBody)
+		b, err = tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, testRespBody)
+	})
+	t.Run("RevalidatesStaleResponse", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		ct, c, tp := setupFn(ctl)
+		respHeader := http.Header{}
+		respHeader.Set(cacheControlHeader, "max-age=60, s-maxage=30")
+		respHeader.Set(etagHeader, "\"1\"")
+		respHeader.Set(lastModifiedHeader, nowDateHeader())
+		expectHeader := http.Header{}
+		expectHeader.Set(ifNoneMatchHeader, "\"1\"")
+		expectHeader.Set(ifModifiedSinceHeader, nowDateHeader())
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ct.EXPECT().ConditionalDereference(ctx, mustParse(testFederatedActorIRI), http.Header{}).Return(testRespBody, respHeader, false, nil)
+		c.EXPECT().Now().Return(now().Add(30 * time.Second)).Times(2)
+		ct.EXPECT().ConditionalDereference(ctx, mustParse(testFederatedActorIRI), expectHeader).Return(nil, http.Header{}, true, nil)
+		// Run
+		_, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+		b, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, testRespBody)
+	})
+	t.Run("DoesNotStorePrivateResponse", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		ct, c, tp := setupFn(ctl)
+		respHeader := http.Header{}
+		respHeader.Set(cacheControlHeader, "private, max-age=60")
+		respHeader.Set(etagHeader, "\"1\"")
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		ct.EXPECT().ConditionalDereference(ctx, mustParse(testFederatedActorIRI), http.Header{}).Return(testRespBody, respHeader, false, nil).Times(2)
+		// Run
+		_, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+		b, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, testRespBody)
+	})
+	t.Run("DoesNotStoreResponseVaryingBySignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		ct, c, tp := setupFn(ctl)
+		respHeader := http.Header{}
+		respHeader.Set(cacheControlHeader, "max-age=60")
+		respHeader.Set(etagHeader, "\"1\"")
+		respHeader.Add(varyHeader, "Accept")
+		respHeader.Add(varyHeader, "signature")
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		ct.EXPECT().ConditionalDereference(ctx, mustParse(testFederatedActorIRI), http.Header{}).Return(testRespBody, respHeader, false, nil).Times(2)
+		// Run
+		_, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+		b, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, testRespBody)
+	})
+	t.Run("DoesNotStoreResponseVaryingByAuthorization", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		ct, c, tp := setupFn(ctl)
+		respHeader := http.Header{}
+		respHeader.Set(cacheControlHeader, "max-age=60")
+		respHeader.Set(varyHeader, "Accept, Authorization")
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		ct.EXPECT().ConditionalDereference(ctx, mustParse(testFederatedActorIRI), http.Header{}).Return(testRespBody, respHeader, false, nil).Times(2)
+		// Run
+		_, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+		b, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, testRespBody)
+	})
+	t.Run("ReturnsErrorWhenNotModifiedButNotCached", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		ct, _, tp := setupFn(ctl)
+		// Mock
+		ct.EXPECT().ConditionalDereference(ctx, mustParse(testFederatedActorIRI), http.Header{}).Return(nil, http.Header{}, true, nil)
+		// Run
+		b, err := tp.Dereference(ctx, mustParse(testFederatedActorIRI))
+		// Verify
+		assertNotEqual(t, err, nil)
+		assertEqual(t, len(b), 0)
+	})
+}
+
+// TestMemoryDereferenceCache tests the least recently used eviction of the
+// MemoryDereferenceCache.
+func TestMemoryDereferenceCache(t *testing.T) {
+	ctx := context.Background()
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		// Setup
+		m := NewMemoryDereferenceCache(2)
+		assertEqual(t, m.Put(ctx, mustParse(testNoteId1), &DereferenceCacheEntry{Body: []byte("1")}), nil)
+		assertEqual(t, m.Put(ctx, mustParse(testNoteId2), &DereferenceCacheEntry{Body: []byte("2")}), nil)
+		_, err := m.Get(ctx, mustParse(testNoteId1))
+		assertEqual(t, err, nil)
+		// Run
+		assertEqual(t, m.Put(ctx, mustParse(testFederatedActorIRI), &DereferenceCacheEntry{Body: []byte("3")}), nil)
+		// Verify
+		e, err := m.Get(ctx, mustParse(testNoteId1))
+		assertEqual(t, err, nil)
+		assertByteEqual(t, e.Body, []byte("1"))
+		e, err = m.Get(ctx, mustParse(testNoteId2))
+		assertEqual(t, err, nil)
+		assertEqual(t, e == nil, true)
+		e, err = m.Get(ctx, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+		assertByteEqual(t, e.Body, []byte("3"))
+	})
+	t.Run("RemovesEntry", func(t *testing.T) {
+		// Setup
+		m := NewMemoryDereferenceCache(2)
+		assertEqual(t, m.Put(ctx, mustParse(testNoteId1), &DereferenceCacheEntry{Body: []byte("1")}), nil)
+		// Run
+		err := m.Remove(ctx, mustParse(testNoteId1))
+		// Verify
+		assertEqual(t, err, nil)
+		e, err := m.Get(ctx, mustParse(testNoteId1))
+		assertEqual(t, err, nil)
+		assertEqual(t, e == nil, true)
+	})
+}
@@ -0,0 +1,170 @@
+package pub
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// The default number of pages of a collection owned by a peer that
+	// are dereferenced when it is targeted to receive a delivery.
+	deliveryCollectionPages = 100
+	// The default number of items of a collection owned by a peer that
+	// are delivered to when it is targeted to receive a delivery.
+	deliveryCollectionItems = 10000
+)
+
+// CollectionIterator walks the items of a Collection or OrderedCollection,
+// including the items on its pages.
+//
+// The items on the collection itself are visited first. Then its 'first'
+// page is followed, and from each CollectionPage or OrderedCollectionPage its
+// 'next' page, dereferencing pages through the Transport as needed. Starting
+// from a page instead visits it and the pages after it.
+//
+// A page is never visited twice, so cycles between pages are not followed.
+//
+// It is not safe to use concurrently.
+type CollectionIterator struct {
+	t        Transport
+	maxPages int
+	maxItems int
+	pages    int
+	items    int
+	pending  []IdProperty
+	// page is the next page to visit, and is nil when there are no more.
+	page    IdProperty
+	visited map[string]bool
+}
+
+// NewCollectionIterator returns a CollectionIterator over the items of the
+// Collection, OrderedCollection, CollectionPage, or OrderedCollectionPage.
+//
+// No more than maxPages pages are dereferenced, and no more than maxItems
+// items are returned. Zero or negative numbers indicate no limit.
+func NewCollectionIterator(t Transport, collection vocab.Type, maxPages, maxItems int) *CollectionIterator {
+	i := &CollectionIterator{
+		t:        t,
+		maxPages: maxPages,
+		maxItems: maxItems,
+		visited:  make(map[string]bool),
+	}
+	if id, err := GetId(collection); err == nil {
+		i.visited[id.String()] = true
+	}
+	i.visit(collection)
+	if f, ok := collection.(firster); ok {
+		if first := f.GetActivityStreamsFirst(); first != nil && first.HasAny() {
+			i.page = first
+		}
+	}
+	return i
+}
+
+// Next returns the next item, dereferencing the next page if needed.
+//
+// Returns a nil item when there are no more items, or a limit is reached.
+func (i *CollectionIterator) Next(c context.Context) (item IdProperty, err error) {
+	if i.maxItems > 0 && i.items >= i.maxItems {
+		return
+	}
+	for len(i.pending) == 0 {
+		if i.page == nil {
+			return
+		}
+		page := i.page
+		i.page = nil
+		var t vocab.Type
+		t, err = i.resolvePage(c, page)
+		if err != nil {
+			return
+		} else if t == nil {
+			return
+		}
+		i.visit(t)
+	}
+	item = i.pending[0]
+	i.pending = i.pending[1:]
+	i.items++
+	return
+}
+
+// resolvePage obtains the page, dereferencing it if it is not embedded.
+//
+// Returns nil if the page has already been visited or the page limit has been
+// reached.
+func (i *CollectionIterator) resolvePage(c context.Context, page IdProperty) (t vocab.Type, err error) {
+	if t = page.GetType(); t != nil && hasItems(t) {
+		if id, idErr := GetId(t); idErr == nil {
+			if i.visited[id.String()] {
+				return nil, nil
+			}
+			i.visited[id.String()] = true
+		}
+		return
+	}
+	var id *url.URL
+	id, err = ToId(page)
+	if err != nil {
+		return
+	} else if i.visited[id.String()] {
+		return nil, nil
+	} else if i.maxPages > 0 && i.pages >= i.maxPages {
+		return nil, nil
+	}
+	i.visited[id.String()] = true
+	i.pages++
+	var b []byte
+	b, err = i.t.Dereference(c, id)
+	if err != nil {
+		return
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(b, &m); err != nil {
+		return
+	}
+	t, err = streams.ToType(c, m)
+	if err != nil {
+		return
+	}
+	if tId, idErr := GetId(t); idErr == nil {
+		i.visited[tId.String()] = true
+	}
+	return
+}
+
+// hasItems determines whether the type has an 'items' or 'orderedItems'
+// property.
+func hasItems(t vocab.Type) bool {
+	if _, ok := t.(itemser); ok {
+		return true
+	}
+	_, ok := t.(orderedItemser)
+	return ok
+}
+
+// visit queues the items of the collection or page, and its 'next' page.
+func (i *CollectionIterator) visit(t vocab.Type) {
+	if v, ok := t.(itemser); ok {
+		if it := v.GetActivityStreamsItems(); it != nil {
+			for iter := it.Begin(); iter != it.End(); iter = iter.Next() {
+				i.pending = append(i.pending, iter)
+			}
+		}
+	} else if v, ok := t.(orderedItemser); ok {
+		if it := v.GetActivityStreamsOrderedItems(); it != nil {
+			for iter := it.Begin(); iter != it.End(); iter = iter.Next() {
+				i.pending = append(i.pending, iter)
+			}
+		}
+	}
+	if n, ok := t.(nexter); ok {
+		if next := n.GetActivityStreamsNext(); next != nil && next.HasAny() {
+			i.page = next
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testFollowersIRI      = "https://other.example.com/dakota/followers"
+	testFollowersPageIRI  = "https://other.example.com/dakota/followers?page=1"
+	testFollowersPageIRI2 = "https://other.example.com/dakota/followers?page=2"
+)
+
+// newPagedFollowers creates an OrderedCollection whose 'first' page contains
+// the first two actors, and whose second page contains the third actor and
+// links back to the first page.
+func newPagedFollowers() (col vocab.ActivityStreamsOrderedCollection, page1, page2 vocab.ActivityStreamsOrderedCollectionPage) {
+	col = streams.NewActivityStreamsOrderedCollection()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(mustParse(testFollowersIRI))
+	col.SetJSONLDId(id)
+	first := streams.NewActivityStreamsFirstProperty()
+	first.SetIRI(mustParse(testFollowersPageIRI))
+	col.SetActivityStreamsFirst(first)
+	newPage := func(pageId, nextId string, actors ...string) vocab.ActivityStreamsOrderedCollectionPage {
+		p := streams.NewActivityStreamsOrderedCollectionPage()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(pageId))
+		p.SetJSONLDId(id)
+		oi := streams.NewActivityStreamsOrderedItemsProperty()
+		for _, a := range actors {
+			oi.AppendIRI(mustParse(a))
+		}
+		p.SetActivityStreamsOrderedItems(oi)
+		next := streams.NewActivityStreamsNextProperty()
+		next.SetIRI(mustParse(nextId))
+		p.SetActivityStreamsNext(next)
+		return p
+	}
+	page1 = newPage(testFollowersPageIRI, testFollowersPageIRI2, testFederatedActorIRI, testFederatedActorIRI2)
+	page2 = newPage(testFollowersPageIRI2, testFollowersPageIRI, testFederatedActorIRI3)
+	return
+}
+
+// iterateIds returns the ids of all the items of the iterator.
+func iterateIds(c context.Context, i *CollectionIterator) (ids []string, err error) {
+	for {
+		item, err := i.Next(c)
+		if err != nil {
+			return ids, err
+		} else if item == nil {
+			return ids, nil
+		}
+		id, err := ToId(item)
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id.String())
+	}
+}
+
+// TestCollectionIterator tests walking the pages of a collection.
+func TestCollectionIterator(t *testing.T) {
+	ctx := context.Background()
+	setupData()
+	col, page1, page2 := newPagedFollowers()
+	t.Run("WalksAllPages", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI)).Return(mustSerializeToBytes(page1), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI2)).Return(mustSerializeToBytes(page2), nil)
+		// Run
+		ids, err := iterateIds(ctx, NewCollectionIterator(tp, col, 0, 0))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{testFederatedActorIRI, testFederatedActorIRI2, testFederatedActorIRI3}))
+	})
+	t.Run("StopsAtMaxPages", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI)).Return(mustSerializeToBytes(page1), nil)
+		// Run
+		ids, err := iterateIds(ctx, NewCollectionIterator(tp, col, 1, 0))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{testFederatedActorIRI, testFederatedActorIRI2}))
+	})
+	t.Run("StopsAtMaxItems", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI)).Return(mustSerializeToBytes(page1), nil)
+		// Run
+		ids, err := iterateIds(ctx, NewCollectionIterator(tp, col, 0, 1))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{testFederatedActorIRI}))
+	})
+	t.Run("StartsFromPage", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI)).Return(mustSerializeToBytes(page1), nil)
+		// Run
+		ids, err := iterateIds(ctx, NewCollectionIterator(tp, page2, 0, 0))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{testFederatedActorIRI3, testFederatedActorIRI, testFederatedActorIRI2}))
+	})
+	t.Run("ReturnsInlineItemsWithoutPages", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Run
+		ids, err := iterateIds(ctx, NewCollectionIterator(tp, testCollectionOfActors, 0, 0))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{testFederatedActorIRI, testFederatedActorIRI2}))
+	})
+	t.Run("ReturnsErrorIfPageCannotBeDereferenced", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		testErr := fmt.Errorf("test error")
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI)).Return(nil, testErr)
+		// Run
+		_, err := iterateIds(ctx, NewCollectionIterator(tp, col, 0, 0))
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+}
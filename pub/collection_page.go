@@ -0,0 +1,155 @@
+package pub
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// The query parameter requesting a page of an inbox or outbox.
+	pageQueryParam = "page"
+	// The query parameter selecting items older than an item.
+	maxIdQueryParam = "max_id"
+	// The query parameter selecting items newer than an item.
+	minIdQueryParam = "min_id"
+	// The value of the page query parameter requesting a page.
+	pageQueryValue = "true"
+	// The default number of items on a page of an inbox or outbox.
+	collectionPageSize = 20
+)
+
+// CollectionCursor selects a page of the items of an inbox or outbox, which
+// are ordered from newest to oldest.
+type CollectionCursor struct {
+	// MaxId, if not nil, selects only items older than the item with this
+	// id.
+	MaxId *url.URL
+	// MinId, if not nil, selects only items newer than the item with this
+	// id. When there are more than Limit of them, the ones closest to the
+	// item with this id are selected.
+	MinId *url.URL
+	// Oldest selects the oldest items instead of the newest ones, when
+	// MaxId and MinId are nil.
+	Oldest bool
+	// Limit is the maximum number of items to select. When zero, no items
+	// are selected, as only the total number of items is needed.
+	Limit int
+}
+
+// parseCollectionCursor determines the page of an inbox or outbox requested
+// by the 'page', 'max_id', and 'min_id' query parameters.
+//
+// When the request is not for a page, then isPage is false and the cursor
+// selects no items. Otherwise it selects up to pageSize items. An empty
+// 'min_id' selects the oldest items.
+func parseCollectionCursor(r *http.Request, pageSize int) (cursor CollectionCursor, isPage bool, err error) {
+	q := r.URL.Query()
+	if q.Get(pageQueryParam) != pageQueryValue {
+		return
+	}
+	isPage = true
+	cursor.Limit = pageSize
+	if v := q.Get(maxIdQueryParam); len(v) > 0 {
+		if cursor.MaxId, err = url.Parse(v); err != nil {
+			return
+		}
+	}
+	if v, ok := q[minIdQueryParam]; ok {
+		if len(v[0]) == 0 {
+			cursor.Oldest = true
+		} else if cursor.MinId, err = url.Parse(v[0]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// collectionPageId returns the IRI of a page of the inbox or outbox with the
+// given query parameters.
+func collectionPageId(id *url.URL, q url.Values) *url.URL {
+	u := *id
+	if q == nil {
+		q = make(url.Values, 1)
+	}
+	q.Set(pageQueryParam, pageQueryValue)
+	u.RawQuery = q.Encode()
+	return &u
+}
+
+// toOrderedCollection creates the OrderedCollection of an inbox or outbox,
+// linking to its first and last pages.
+func toOrderedCollection(id *url.URL, totalItems int) vocab.ActivityStreamsOrderedCollection {
+	oc := streams.NewActivityStreamsOrderedCollection()
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(id)
+	oc.SetJSONLDId(idProp)
+	total := streams.NewActivityStreamsTotalItemsProperty()
+	total.Set(totalItems)
+	oc.SetActivityStreamsTotalItems(total)
+	first := streams.NewActivityStreamsFirstProperty()
+	first.SetIRI(collectionPageId(id, nil))
+	oc.SetActivityStreamsFirst(first)
+	last := streams.NewActivityStreamsLastProperty()
+	last.SetIRI(collectionPageId(id, url.Values{minIdQueryParam: []string{""}}))
+	oc.SetActivityStreamsLast(last)
+	return oc
+}
+
+// toOrderedCollectionPage creates the OrderedCollectionPage of an inbox or
+// outbox with the items selected by the cursor, linking to the pages with
+// newer and older items.
+//
+// Items with duplicate ids are removed.
+func toOrderedCollectionPage(id *url.URL, cursor CollectionCursor, items vocab.ActivityStreamsOrderedItemsProperty) (page vocab.ActivityStreamsOrderedCollectionPage, err error) {
+	page = streams.NewActivityStreamsOrderedCollectionPage()
+	q := make(url.Values, 2)
+	if cursor.MaxId != nil {
+		q.Set(maxIdQueryParam, cursor.MaxId.String())
+	}
+	if cursor.MinId != nil {
+		q.Set(minIdQueryParam, cursor.MinId.String())
+	} else if cursor.Oldest {
+		q.Set(minIdQueryParam, "")
+	}
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(collectionPageId(id, q))
+	page.SetJSONLDId(idProp)
+	partOf := streams.NewActivityStreamsPartOfProperty()
+	partOf.SetIRI(id)
+	page.SetActivityStreamsPartOf(partOf)
+	if items == nil || items.Len() == 0 {
+		return
+	}
+	n := items.Len()
+	page.SetActivityStreamsOrderedItems(items)
+	if err = dedupeOrderedItems(page); err != nil {
+		return
+	}
+	full := n >= cursor.Limit
+	// Link to the older items.
+	if !cursor.Oldest && (full || cursor.MinId != nil) {
+		var last *url.URL
+		last, err = ToId(items.At(items.Len() - 1))
+		if err != nil {
+			return
+		}
+		next := streams.NewActivityStreamsNextProperty()
+		next.SetIRI(collectionPageId(id, url.Values{maxIdQueryParam: []string{last.String()}}))
+		page.SetActivityStreamsNext(next)
+	}
+	// Link to the newer items.
+	if cursor.MaxId != nil || (full && (cursor.MinId != nil || cursor.Oldest)) {
+		var first *url.URL
+		first, err = ToId(items.At(0))
+		if err != nil {
+			return
+		}
+		prev := streams.NewActivityStreamsPrevProperty()
+		prev.SetIRI(collectionPageId(id, url.Values{minIdQueryParam: []string{first.String()}}))
+		page.SetActivityStreamsPrev(prev)
+	}
+	return
+}
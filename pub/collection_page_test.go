@@ -0,0 +1,174 @@
+package pub
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// newOrderedItems creates the orderedItems property for numbered notes, from
+// newest to oldest.
+func newOrderedItems(from, to int) vocab.ActivityStreamsOrderedItemsProperty {
+	oi := streams.NewActivityStreamsOrderedItemsProperty()
+	for i := from; i >= to; i-- {
+		oi.AppendIRI(mustParse(fmt.Sprintf("https://example.com/note/%d", i)))
+	}
+	return oi
+}
+
+// pageLinks returns the 'next' and 'prev' IRIs of the page, or empty strings
+// if they are not set.
+func pageLinks(p vocab.ActivityStreamsOrderedCollectionPage) (next, prev string) {
+	if n := p.GetActivityStreamsNext(); n != nil {
+		next = n.GetIRI().String()
+	}
+	if v := p.GetActivityStreamsPrev(); v != nil {
+		prev = v.GetIRI().String()
+	}
+	return
+}
+
+// TestParseCollectionCursor tests determining the requested page of an inbox
+// or outbox.
+func TestParseCollectionCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		isPage bool
+		maxId  string
+		minId  string
+		oldest bool
+		limit  int
+		err    bool
+	}{
+		{name: "Collection", query: ""},
+		{name: "CollectionIgnoresIds", query: "?max_id=https://example.com/note/1"},
+		{name: "FirstPage", query: "?page=true", isPage: true, limit: collectionPageSize},
+		{name: "LastPage", query: "?min_id=&page=true", isPage: true, oldest: true, limit: collectionPageSize},
+		{name: "OlderPage", query: "?max_id=https%3A%2F%2Fexample.com%2Fnote%2F1&page=true", isPage: true, maxId: "https://example.com/note/1", limit: collectionPageSize},
+		{name: "NewerPage", query: "?min_id=https%3A%2F%2Fexample.com%2Fnote%2F1&page=true", isPage: true, minId: "https://example.com/note/1", limit: collectionPageSize},
+		{name: "InvalidId", query: "?max_id=%3A&page=true", isPage: true, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Run
+			cursor, isPage, err := parseCollectionCursor(httptest.NewRequest("GET", testMyInboxIRI+test.query, nil), collectionPageSize)
+			// Verify
+			assertEqual(t, err != nil, test.err)
+			assertEqual(t, isPage, test.isPage)
+			if test.err {
+				return
+			}
+			var maxId, minId string
+			if cursor.MaxId != nil {
+				maxId = cursor.MaxId.String()
+			}
+			if cursor.MinId != nil {
+				minId = cursor.MinId.String()
+			}
+			assertEqual(t, maxId, test.maxId)
+			assertEqual(t, minId, test.minId)
+			assertEqual(t, cursor.Oldest, test.oldest)
+			assertEqual(t, cursor.Limit, test.limit)
+		})
+	}
+}
+
+// TestToOrderedCollectionPage tests linking a page of an inbox or outbox to
+// the pages with newer and older items.
+func TestToOrderedCollectionPage(t *testing.T) {
+	inbox := mustParse(testMyInboxIRI)
+	note := func(i int) string {
+		return fmt.Sprintf("https://example.com/note/%d", i)
+	}
+	olderThan := func(i int) string {
+		return collectionPageId(inbox, map[string][]string{maxIdQueryParam: {note(i)}}).String()
+	}
+	newerThan := func(i int) string {
+		return collectionPageId(inbox, map[string][]string{minIdQueryParam: {note(i)}}).String()
+	}
+	tests := []struct {
+		name   string
+		cursor CollectionCursor
+		from   int
+		to     int
+		id     string
+		next   string
+		prev   string
+	}{
+		{
+			name:   "FullFirstPage",
+			cursor: CollectionCursor{Limit: 2},
+			from:   5,
+			to:     4,
+			id:     testMyInboxPageIRI,
+			next:   olderThan(4),
+		},
+		{
+			name:   "PartialFirstPage",
+			cursor: CollectionCursor{Limit: 3},
+			from:   2,
+			to:     1,
+			id:     testMyInboxPageIRI,
+		},
+		{
+			name:   "OlderPage",
+			cursor: CollectionCursor{MaxId: mustParse(note(4)), Limit: 2},
+			from:   3,
+			to:     2,
+			id:     olderThan(4),
+			next:   olderThan(2),
+			prev:   newerThan(3),
+		},
+		{
+			name:   "OldestPage",
+			cursor: CollectionCursor{MaxId: mustParse(note(2)), Limit: 2},
+			from:   1,
+			to:     1,
+			id:     olderThan(2),
+			prev:   newerThan(1),
+		},
+		{
+			name:   "NewerPage",
+			cursor: CollectionCursor{MinId: mustParse(note(1)), Limit: 2},
+			from:   3,
+			to:     2,
+			id:     newerThan(1),
+			next:   olderThan(2),
+			prev:   newerThan(3),
+		},
+		{
+			name:   "LastPage",
+			cursor: CollectionCursor{Oldest: true, Limit: 2},
+			from:   2,
+			to:     1,
+			id:     collectionPageId(inbox, map[string][]string{minIdQueryParam: {""}}).String(),
+			prev:   newerThan(2),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Run
+			p, err := toOrderedCollectionPage(inbox, test.cursor, newOrderedItems(test.from, test.to))
+			// Verify
+			assertEqual(t, err, nil)
+			assertEqual(t, p.GetJSONLDId().Get().String(), test.id)
+			assertEqual(t, p.GetActivityStreamsPartOf().GetIRI().String(), testMyInboxIRI)
+			next, prev := pageLinks(p)
+			assertEqual(t, next, test.next)
+			assertEqual(t, prev, test.prev)
+		})
+	}
+	t.Run("EmptyPageHasNoLinks", func(t *testing.T) {
+		// Run
+		p, err := toOrderedCollectionPage(inbox, CollectionCursor{MaxId: mustParse(note(1)), Limit: 2}, nil)
+		// Verify
+		assertEqual(t, err, nil)
+		next, prev := pageLinks(p)
+		assertEqual(t, next, "")
+		assertEqual(t, prev, "")
+	})
+}
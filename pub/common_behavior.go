@@ -2,7 +2,6 @@ package pub
 
 import (
 	"context"
-	"github.com/go-fed/activity/streams/vocab"
 	"net/http"
 	"net/url"
 )
@@ -31,7 +30,9 @@ type CommonBehavior interface {
 	//
 	// Finally, if the authentication and authorization succeeds, then
 	// authenticated must be true and error nil. The request will continue
-	// to be processed.
+	// to be processed, and the returned context is given to the
+	// Database's GetOrderedCollectionItems to select the items for the
+	// kind of authorization given in the request.
 	AuthenticateGetInbox(c context.Context, w http.ResponseWriter, r *http.Request) (out context.Context, authenticated bool, err error)
 	// AuthenticateGetOutbox delegates the authentication of a GET to an
 	// outbox.
@@ -51,17 +52,10 @@ type CommonBehavior interface {
 	//
 	// Finally, if the authentication and authorization succeeds, then
 	// authenticated must be true and error nil. The request will continue
-	// to be processed.
+	// to be processed, and the returned context is given to the
+	// Database's GetOrderedCollectionItems to select the items for the
+	// kind of authorization given in the request.
 	AuthenticateGetOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (out context.Context, authenticated bool, err error)
-	// GetOutbox returns the OrderedCollection inbox of the actor for this
-	// context. It is up to the implementation to provide the correct
-	// collection for the kind of authorization given in the request.
-	//
-	// AuthenticateGetOutbox will be called prior to this.
-	//
-	// Always called, regardless whether the Federated Protocol or Social
-	// API is enabled.
-	GetOutbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error)
 	// NewTransport returns a new Transport on behalf of a specific actor.
 	//
 	// The actorBoxIRI will be either the inbox or outbox of an actor who is
@@ -82,8 +76,26 @@ type CommonBehavior interface {
 	// Any retry logic should also be handled by the Transport
 	// implementation.
 	//
+	// Dereferenced values may be cached across actors by wrapping the
+	// Transport in a CachingTransport that shares one DereferenceCache.
+	//
+	// Applications rotating keys with a KeyManager should sign with the
+	// actor's key obtained from its CurrentKey method.
+	//
 	// Note that the library will not maintain a long-lived pointer to the
 	// returned Transport so that any private credentials are able to be
 	// garbage collected.
 	NewTransport(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (t Transport, err error)
 }
+
+// CollectionPagePolicy may be implemented by a CommonBehavior to configure the
+// pages of inboxes and outboxes.
+//
+// If the CommonBehavior does not implement it, then pages have 20 items.
+type CollectionPagePolicy interface {
+	// CollectionPageSize returns the maximum number of items on a page of
+	// an inbox or outbox.
+	//
+	// If zero or less, the default of 20 items is used.
+	CollectionPageSize(c context.Context) int
+}
@@ -108,6 +108,16 @@ type Database interface {
 	//
 	// The library makes this call only after acquiring a lock first.
 	SetOutbox(c context.Context, outbox vocab.ActivityStreamsOrderedCollectionPage) error
+	// GetOrderedCollectionItems returns the items of the inbox or outbox
+	// at the specified IRI selected by the cursor, ordered from newest to
+	// oldest, along with the total number of items in it.
+	//
+	// The context is the one returned when authenticating the GET request,
+	// so the implementation can provide the correct items for the kind of
+	// authorization given in the request.
+	//
+	// The library makes this call only after acquiring a lock first.
+	GetOrderedCollectionItems(c context.Context, collectionIRI *url.URL, cursor CollectionCursor) (items vocab.ActivityStreamsOrderedItemsProperty, totalItems int, err error)
 	// NewID creates a new IRI id for the provided activity or object. The
 	// implementation does not need to set the 'id' property and simply
 	// needs to determine the value.
@@ -137,3 +147,17 @@ type Database interface {
 	// The library makes this call only after acquiring a lock first.
 	Liked(c context.Context, actorIRI *url.URL) (liked vocab.ActivityStreamsCollection, err error)
 }
+
+// FollowRequestsDatabase may be implemented by a Database to store the Follow
+// requests awaiting approval by local actors.
+//
+// It must be implemented to use the OnFollowManuallyApprove behavior.
+type FollowRequestsDatabase interface {
+	// FollowRequests obtains the Collection of the ids of the Follow
+	// requests awaiting approval by the actor with the given id.
+	//
+	// If modified, the library will then call Update.
+	//
+	// The library makes this call only after acquiring a lock first.
+	FollowRequests(c context.Context, actorIRI *url.URL) (followRequests vocab.ActivityStreamsCollection, err error)
+}
@@ -76,6 +76,16 @@ type DelegateActor interface {
 	// authenticated must be true and error nil. The request will continue
 	// to be processed.
 	AuthenticatePostInbox(c context.Context, w http.ResponseWriter, r *http.Request) (out context.Context, authenticated bool, err error)
+	// PostInboxDigestAlgorithms determines which digest algorithms a POST
+	// to the inbox must use in its Digest or Content-Digest header.
+	//
+	// Only called if the Federated Protocol is enabled.
+	//
+	// If none are returned, then neither header is required. Regardless,
+	// every digest present in the request using a supported algorithm
+	// must match the request body, otherwise an http.StatusBadRequest is
+	// written in the response.
+	PostInboxDigestAlgorithms(c context.Context, inboxIRI *url.URL) []DigestAlgorithm
 	// AuthenticateGetInbox delegates the authentication of a GET to an
 	// inbox.
 	//
@@ -124,8 +134,9 @@ type DelegateActor interface {
 	// later) must decide whether it has seen this activity before in order
 	// to determine whether to do the forwarding algorithm.
 	//
-	// If the error is ErrObjectRequired or ErrTargetRequired, then a Bad
-	// Request status is sent in the response.
+	// If the error is ErrObjectRequired, ErrTargetRequired, ErrPollClosed,
+	// or ErrInvalidVote, then a Bad Request status is sent in the response.
+	// If it is ErrBlocked, then a Forbidden status is sent instead.
 	PostInbox(c context.Context, inboxIRI *url.URL, activity Activity) error
 	// InboxForwarding delegates inbox forwarding logic when a POST request
 	// is received in the Actor's inbox.
@@ -142,7 +153,9 @@ type DelegateActor interface {
 	//
 	// The provided url is the inbox of the recipient of the Activity. The
 	// Activity is examined for the information about who to inbox forward
-	// to.
+	// to. The context holds the raw body the Activity was received in,
+	// which must be forwarded unchanged so that its Linked Data Signature
+	// remains valid.
 	//
 	// If an error is returned, it is returned to the caller of PostInbox.
 	InboxForwarding(c context.Context, inboxIRI *url.URL, activity Activity) error
@@ -183,6 +196,28 @@ type DelegateActor interface {
 	//
 	// If an error is returned, it is returned to the caller of PostOutbox.
 	Deliver(c context.Context, outbox *url.URL, activity Activity) error
+	// RespondToFollowRequest accepts or rejects a Follow awaiting approval
+	// by the actor owning the inbox.
+	//
+	// Only called if the Federated Protocol is enabled.
+	//
+	// The Accept or Reject is delivered to the actors of the Follow. If
+	// accepted, they are also added to the 'followers' collection. The
+	// Follow is then removed from the pending follow requests.
+	//
+	// If an error is returned, it is returned to the caller of
+	// ApproveFollowRequest or DenyFollowRequest.
+	RespondToFollowRequest(c context.Context, inboxIRI, followIRI *url.URL, accept bool) error
+	// ForwardReport sends a Flag of the objects of a Report in the
+	// ModerationQueue that are owned by other servers to the actors owning
+	// them, on behalf of the actor owning the inbox that received the
+	// report.
+	//
+	// Only called if the Federated Protocol is enabled.
+	//
+	// If an error is returned, it is returned to the caller of
+	// ForwardReport.
+	ForwardReport(c context.Context, inboxIRI, reportIRI *url.URL) error
 	// AuthenticatePostOutbox delegates the authentication and authorization
 	// of a POST to an outbox.
 	//
@@ -227,22 +262,32 @@ type DelegateActor interface {
 	//
 	// Only called if the Social API is enabled.
 	WrapInCreate(c context.Context, value vocab.Type, outboxIRI *url.URL) (vocab.ActivityStreamsCreate, error)
-	// GetOutbox returns the OrderedCollection inbox of the actor for this
-	// context. It is up to the implementation to provide the correct
-	// collection for the kind of authorization given in the request.
+	// GetOutbox returns the items of the outbox at the specified IRI
+	// selected by the cursor, ordered from newest to oldest, along with the
+	// total number of items in the outbox. It is up to the
+	// implementation to provide the correct items for the kind of
+	// authorization given in the request.
 	//
 	// AuthenticateGetOutbox will be called prior to this.
 	//
 	// Always called, regardless whether the Federated Protocol or Social
 	// API is enabled.
-	GetOutbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error)
-	// GetInbox returns the OrderedCollection inbox of the actor for this
-	// context. It is up to the implementation to provide the correct
-	// collection for the kind of authorization given in the request.
+	GetOutbox(c context.Context, r *http.Request, outboxIRI *url.URL, cursor CollectionCursor) (items vocab.ActivityStreamsOrderedItemsProperty, totalItems int, err error)
+	// GetInbox returns the items of the inbox at the specified IRI selected
+	// by the cursor, ordered from newest to oldest, along with the total
+	// number of items in the inbox. It is up to the
+	// implementation to provide the correct items for the kind of
+	// authorization given in the request.
 	//
 	// AuthenticateGetInbox will be called prior to this.
 	//
 	// Always called, regardless whether the Federated Protocol or Social
 	// API is enabled.
-	GetInbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error)
+	GetInbox(c context.Context, r *http.Request, inboxIRI *url.URL, cursor CollectionCursor) (items vocab.ActivityStreamsOrderedItemsProperty, totalItems int, err error)
+	// CollectionPageSize returns the maximum number of items on a page of
+	// an inbox or outbox.
+	//
+	// Always called, regardless whether the Federated Protocol or Social
+	// API is enabled.
+	CollectionPageSize(c context.Context) int
 }
@@ -0,0 +1,260 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Delivery is a single pending delivery of an ActivityStreams payload to one
+// recipient's inbox.
+type Delivery struct {
+	// Id uniquely identifies the Delivery, and is assigned by the
+	// DeliveryStore when it is added.
+	Id string
+	// BoxIRI is the inbox or outbox of the actor on whose behalf the
+	// delivery is made, and is used to obtain the Transport.
+	BoxIRI *url.URL
+	// To is the inbox of the recipient.
+	To *url.URL
+	// Payload is the serialized ActivityStreams value to deliver.
+	Payload []byte
+	// Created is when the Delivery was first enqueued.
+	Created time.Time
+	// Attempts is the number of failed delivery attempts so far.
+	Attempts int
+	// NextAttempt is the earliest time the next attempt can be made.
+	NextAttempt time.Time
+	// LastError is the error of the most recent failed attempt.
+	LastError string
+}
+
+// DeliveryQueue sends deliveries on behalf of the library, instead of calling
+// the Transport's BatchDeliver directly.
+//
+// It is obtained from the FederatingProtocol's DeliveryQueue method.
+type DeliveryQueue interface {
+	// Enqueue schedules the payload to be delivered to each recipient on
+	// behalf of the actor owning the box.
+	//
+	// An error is returned only if the deliveries could not be scheduled.
+	// Failing to deliver to a recipient is handled by the DeliveryQueue.
+	Enqueue(c context.Context, boxIRI *url.URL, b []byte, recipients []*url.URL) error
+}
+
+// DeliveryStore persists the pending and dead-lettered deliveries of a
+// RetryingDeliveryQueue.
+//
+// It must be safe to use concurrently.
+type DeliveryStore interface {
+	// Add saves new pending deliveries, assigning each a unique Id.
+	Add(c context.Context, d []*Delivery) error
+	// Due returns the pending deliveries whose NextAttempt is not after
+	// the given time.
+	Due(c context.Context, now time.Time) ([]*Delivery, error)
+	// Update saves the changes made to a pending delivery.
+	Update(c context.Context, d *Delivery) error
+	// Remove deletes a pending delivery.
+	Remove(c context.Context, id string) error
+	// DeadLetter removes a pending delivery and adds it to the dead-letter
+	// list.
+	DeadLetter(c context.Context, d *Delivery) error
+	// DeadLetters returns the dead-letter list.
+	DeadLetters(c context.Context) ([]*Delivery, error)
+}
+
+// DeliveryQueue must be implemented by RetryingDeliveryQueue.
+var _ DeliveryQueue = &RetryingDeliveryQueue{}
+
+// RetryingDeliveryQueue is a DeliveryQueue that persists deliveries in a
+// DeliveryStore and retries failures with an exponential backoff.
+//
+// Enqueued deliveries are attempted immediately. Failed ones are retried by
+// calling ProcessDue, or Run which calls it on every tick. The delay before
+// each retry doubles starting at minBackoff up to maxBackoff, with random
+// jitter applied. Once a delivery has been failing for longer than the
+// horizon, it is moved to the dead-letter list and no longer retried.
+//
+// A delivery is never attempted concurrently by the same
+// RetryingDeliveryQueue, but multiple instances sharing a DeliveryStore may
+// do so.
+type RetryingDeliveryQueue struct {
+	common     CommonBehavior
+	store      DeliveryStore
+	clock      Clock
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	horizon    time.Duration
+	// int63n returns a random number in [0, n) and is used for jitter.
+	int63n     func(n int64) int64
+	inFlight   map[string]bool
+	inFlightMu *sync.Mutex
+}
+
+// NewRetryingDeliveryQueue returns a new RetryingDeliveryQueue.
+//
+// The CommonBehavior's NewTransport is used to obtain a Transport for each
+// delivery attempt.
+func NewRetryingDeliveryQueue(common CommonBehavior,
+	store DeliveryStore,
+	clock Clock,
+	minBackoff, maxBackoff, horizon time.Duration) *RetryingDeliveryQueue {
+	return &RetryingDeliveryQueue{
+		common:     common,
+		store:      store,
+		clock:      clock,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+		horizon:    horizon,
+		int63n:     rand.Int63n,
+		inFlight:   make(map[string]bool),
+		inFlightMu: &sync.Mutex{},
+	}
+}
+
+// Enqueue saves a pending delivery for each recipient and immediately attempts
+// them.
+func (q *RetryingDeliveryQueue) Enqueue(c context.Context, boxIRI *url.URL, b []byte, recipients []*url.URL) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	now := q.clock.Now()
+	ds := make([]*Delivery, 0, len(recipients))
+	for _, r := range recipients {
+		ds = append(ds, &Delivery{
+			BoxIRI:      boxIRI,
+			To:          r,
+			Payload:     b,
+			Created:     now,
+			NextAttempt: now,
+		})
+	}
+	if err := q.store.Add(c, ds); err != nil {
+		return err
+	}
+	return q.attempt(c, ds)
+}
+
+// ProcessDue attempts every pending delivery that is due.
+//
+// An error is returned if the DeliveryStore fails. Failing to deliver to a
+// recipient is not an error.
+func (q *RetryingDeliveryQueue) ProcessDue(c context.Context) error {
+	ds, err := q.store.Due(c, q.clock.Now())
+	if err != nil {
+		return err
+	}
+	return q.attempt(c, ds)
+}
+
+// Run calls ProcessDue on every tick until the context is done or processing
+// returns an error.
+//
+// The ticks are typically the channel of a time.Ticker, which the caller is
+// responsible for stopping. Whether a delivery is due is always determined by
+// the Clock.
+func (q *RetryingDeliveryQueue) Run(c context.Context, tick <-chan time.Time) error {
+	for {
+		select {
+		case <-c.Done():
+			return c.Err()
+		case <-tick:
+			if err := q.ProcessDue(c); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// attempt concurrently delivers each delivery that is not already in flight,
+// and updates the DeliveryStore with the outcomes.
+func (q *RetryingDeliveryQueue) attempt(c context.Context, ds []*Delivery) error {
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(ds))
+	for _, d := range ds {
+		if !q.claim(d.Id) {
+			continue
+		}
+		wg.Add(1)
+		go func(d *Delivery) {
+			defer wg.Done()
+			defer q.release(d.Id)
+			if err := q.deliver(c, d); err != nil {
+				errCh <- err
+			}
+		}(d)
+	}
+	wg.Wait()
+	errs := make([]string, 0, len(ds))
+outer:
+	for {
+		select {
+		case e := <-errCh:
+			errs = append(errs, e.Error())
+		default:
+			break outer
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("delivery queue had at least one failure: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// deliver makes a single attempt at the delivery. Only errors from the
+// DeliveryStore are returned.
+func (q *RetryingDeliveryQueue) deliver(c context.Context, d *Delivery) error {
+	tp, err := q.common.NewTransport(c, d.BoxIRI, goFedUserAgent())
+	if err == nil {
+		err = tp.Deliver(c, d.Payload, d.To)
+	}
+	if err == nil {
+		return q.store.Remove(c, d.Id)
+	}
+	now := q.clock.Now()
+	d.Attempts++
+	d.LastError = err.Error()
+	if now.Sub(d.Created) >= q.horizon {
+		return q.store.DeadLetter(c, d)
+	}
+	d.NextAttempt = now.Add(q.backoff(d.Attempts))
+	return q.store.Update(c, d)
+}
+
+// backoff determines the delay before the next attempt after the given number
+// of failed attempts.
+//
+// The delay is between half and all of the exponential backoff.
+func (q *RetryingDeliveryQueue) backoff(attempts int) time.Duration {
+	delay := q.minBackoff
+	for i := 1; i < attempts && delay < q.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > q.maxBackoff {
+		delay = q.maxBackoff
+	}
+	half := int64(delay / 2)
+	return time.Duration(half + q.int63n(int64(delay)-half+1))
+}
+
+// claim marks the delivery as in flight, returning false if it already was.
+func (q *RetryingDeliveryQueue) claim(id string) bool {
+	q.inFlightMu.Lock()
+	defer q.inFlightMu.Unlock()
+	if q.inFlight[id] {
+		return false
+	}
+	q.inFlight[id] = true
+	return true
+}
+
+// release marks the delivery as no longer in flight.
+func (q *RetryingDeliveryQueue) release(id string) {
+	q.inFlightMu.Lock()
+	defer q.inFlightMu.Unlock()
+	delete(q.inFlight, id)
+}
@@ -0,0 +1,203 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestRetryingDeliveryQueue tests the persisting and retrying of deliveries.
+func TestRetryingDeliveryQueue(t *testing.T) {
+	ctx := context.Background()
+	testErr := fmt.Errorf("test error")
+	recipients := []*url.URL{
+		mustParse(testFederatedInboxIRI),
+		mustParse(testFederatedInboxIRI2),
+	}
+	setupFn := func(ctl *gomock.Controller) (q *RetryingDeliveryQueue, store *MemoryDeliveryStore, cm *MockCommonBehavior, c *MockClock, tp *MockTransport) {
+		store = NewMemoryDeliveryStore()
+		cm = NewMockCommonBehavior(ctl)
+		c = NewMockClock(ctl)
+		tp = NewMockTransport(ctl)
+		q = NewRetryingDeliveryQueue(cm, store, c, time.Minute, time.Hour, 24*time.Hour)
+		// Remove the jitter.
+		q.int63n = func(n int64) int64 { return n - 1 }
+		return
+	}
+	t.Run("DeliversImmediately", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, store, cm, c, tp := setupFn(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil).Times(2)
+		tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI))
+		tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI2))
+		// Run
+		err := q.Enqueue(ctx, mustParse(testMyOutboxIRI), testRespBody, recipients)
+		// Verify
+		assertEqual(t, err, nil)
+		pending, _ := store.Due(ctx, now().Add(48*time.Hour))
+		assertEqual(t, len(pending), 0)
+	})
+	t.Run("SchedulesRetryOnFailure", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, store, cm, c, tp := setupFn(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil).Times(2)
+		tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI))
+		tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI2)).Return(testErr)
+		// Run
+		err := q.Enqueue(ctx, mustParse(testMyOutboxIRI), testRespBody, recipients)
+		// Verify
+		assertEqual(t, err, nil)
+		pending, _ := store.Due(ctx, now())
+		assertEqual(t, len(pending), 0)
+		pending, _ = store.Due(ctx, now().Add(time.Minute))
+		assertEqual(t, len(pending), 1)
+		assertEqual(t, pending[0].To.String(), testFederatedInboxIRI2)
+		assertEqual(t, pending[0].Attempts, 1)
+		assertEqual(t, pending[0].LastError, testErr.Error())
+	})
+	t.Run("ProcessDueRetries", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, store, cm, c, tp := setupFn(ctl)
+		// Mock
+		gomock.InOrder(
+			c.EXPECT().Now().Return(now()),
+			cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil),
+			tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI)).Return(testErr),
+			c.EXPECT().Now().Return(now()),
+			c.EXPECT().Now().Return(now().Add(time.Minute)),
+			cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil),
+			tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI)),
+		)
+		// Run
+		err := q.Enqueue(ctx, mustParse(testMyOutboxIRI), testRespBody, recipients[:1])
+		assertEqual(t, err, nil)
+		err = q.ProcessDue(ctx)
+		// Verify
+		assertEqual(t, err, nil)
+		pending, _ := store.Due(ctx, now().Add(48*time.Hour))
+		assertEqual(t, len(pending), 0)
+	})
+	t.Run("ProcessDueSkipsNotDue", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, store, cm, c, tp := setupFn(ctl)
+		// Mock
+		gomock.InOrder(
+			c.EXPECT().Now().Return(now()),
+			cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil),
+			tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI)).Return(testErr),
+			c.EXPECT().Now().Return(now()),
+			c.EXPECT().Now().Return(now().Add(time.Second)),
+		)
+		// Run
+		err := q.Enqueue(ctx, mustParse(testMyOutboxIRI), testRespBody, recipients[:1])
+		assertEqual(t, err, nil)
+		err = q.ProcessDue(ctx)
+		// Verify
+		assertEqual(t, err, nil)
+		pending, _ := store.Due(ctx, now().Add(time.Minute))
+		assertEqual(t, len(pending), 1)
+	})
+	t.Run("DeadLettersAfterHorizon", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, store, cm, c, tp := setupFn(ctl)
+		// Mock
+		gomock.InOrder(
+			c.EXPECT().Now().Return(now()),
+			cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil),
+			tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI)).Return(testErr),
+			c.EXPECT().Now().Return(now()),
+			c.EXPECT().Now().Return(now().Add(24*time.Hour)),
+			cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil),
+			tp.EXPECT().Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI)).Return(testErr),
+			c.EXPECT().Now().Return(now().Add(24*time.Hour)),
+		)
+		// Run
+		err := q.Enqueue(ctx, mustParse(testMyOutboxIRI), testRespBody, recipients[:1])
+		assertEqual(t, err, nil)
+		err = q.ProcessDue(ctx)
+		// Verify
+		assertEqual(t, err, nil)
+		pending, _ := store.Due(ctx, now().Add(48*time.Hour))
+		assertEqual(t, len(pending), 0)
+		dl, _ := store.DeadLetters(ctx)
+		assertEqual(t, len(dl), 1)
+		assertEqual(t, dl[0].Attempts, 2)
+	})
+	t.Run("RetriesIfTransportCannotBeCreated", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, store, cm, c, _ := setupFn(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		cm.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(nil, testErr)
+		// Run
+		err := q.Enqueue(ctx, mustParse(testMyOutboxIRI), testRespBody, recipients[:1])
+		// Verify
+		assertEqual(t, err, nil)
+		pending, _ := store.Due(ctx, now().Add(time.Minute))
+		assertEqual(t, len(pending), 1)
+	})
+	t.Run("RunProcessesDueOnTick", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, store, cm, c, tp := setupFn(ctl)
+		store.Add(ctx, []*Delivery{{
+			BoxIRI:      mustParse(testMyOutboxIRI),
+			To:          mustParse(testFederatedInboxIRI),
+			Payload:     testRespBody,
+			Created:     now(),
+			NextAttempt: now(),
+		}})
+		runCtx, cancel := context.WithCancel(ctx)
+		tick := make(chan time.Time)
+		done := make(chan error)
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		cm.EXPECT().NewTransport(runCtx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(tp, nil)
+		tp.EXPECT().Deliver(runCtx, testRespBody, mustParse(testFederatedInboxIRI))
+		// Run
+		go func() { done <- q.Run(runCtx, tick) }()
+		tick <- now()
+		tick <- now()
+		cancel()
+		err := <-done
+		// Verify
+		assertEqual(t, err, context.Canceled)
+		pending, _ := store.Due(ctx, now().Add(48*time.Hour))
+		assertEqual(t, len(pending), 0)
+	})
+	t.Run("BacksOffExponentially", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		q, _, _, _, _ := setupFn(ctl)
+		// Run & Verify
+		assertEqual(t, q.backoff(1), time.Minute)
+		assertEqual(t, q.backoff(2), 2*time.Minute)
+		assertEqual(t, q.backoff(3), 4*time.Minute)
+		assertEqual(t, q.backoff(7), time.Hour)
+		assertEqual(t, q.backoff(100), time.Hour)
+		q.int63n = func(n int64) int64 { return 0 }
+		assertEqual(t, q.backoff(2), time.Minute)
+	})
+}
@@ -0,0 +1,152 @@
+package pub
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	// Register the hash functions backing the DigestAlgorithms.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// DigestAlgorithm is a hashing algorithm used to compute the digest of an HTTP
+// body, as found in the Digest (RFC 3230) and Content-Digest (RFC 9530)
+// headers.
+type DigestAlgorithm string
+
+const (
+	// DigestSha256 is the SHA-256 digest algorithm.
+	DigestSha256 DigestAlgorithm = "SHA-256"
+	// DigestSha512 is the SHA-512 digest algorithm.
+	DigestSha512 DigestAlgorithm = "SHA-512"
+)
+
+const (
+	// The Content-Digest header.
+	contentDigestHeader = "Content-Digest"
+	// The delimiter between values in the Digest and Content-Digest headers.
+	digestListDelimiter = ","
+	// The delimiter around byte sequences in the Content-Digest header.
+	contentDigestByteDelimiter = ":"
+)
+
+// hash returns the hash function for the algorithm, and false if the
+// algorithm is not supported.
+func (d DigestAlgorithm) hash() (crypto.Hash, bool) {
+	switch DigestAlgorithm(strings.ToUpper(string(d))) {
+	case DigestSha256:
+		return crypto.SHA256, true
+	case DigestSha512:
+		return crypto.SHA512, true
+	default:
+		return 0, false
+	}
+}
+
+// sum computes the digest of the bytes. The algorithm must be supported.
+func (d DigestAlgorithm) sum(b []byte) []byte {
+	h, _ := d.hash()
+	hasher := h.New()
+	hasher.Write(b)
+	return hasher.Sum(nil)
+}
+
+// digestValue is a single algorithm and digest pair found in either the Digest
+// or Content-Digest header.
+type digestValue struct {
+	algo   DigestAlgorithm
+	digest []byte
+}
+
+// digestHeaderValue returns the value of the Digest header for the body.
+func digestHeaderValue(algo DigestAlgorithm, b []byte) string {
+	return string(algo) + digestDelimiter + base64.StdEncoding.EncodeToString(algo.sum(b))
+}
+
+// contentDigestHeaderValue returns the value of the Content-Digest header for
+// the body.
+func contentDigestHeaderValue(algo DigestAlgorithm, b []byte) string {
+	return strings.ToLower(string(algo)) +
+		digestDelimiter +
+		contentDigestByteDelimiter +
+		base64.StdEncoding.EncodeToString(algo.sum(b)) +
+		contentDigestByteDelimiter
+}
+
+// setDigestHeaders sets both the Digest and Content-Digest headers for the
+// body.
+func setDigestHeaders(h http.Header, algo DigestAlgorithm, b []byte) {
+	h.Set(digestHeader, digestHeaderValue(algo, b))
+	h.Set(contentDigestHeader, contentDigestHeaderValue(algo, b))
+}
+
+// getDigestValues parses the Digest and Content-Digest headers, returning the
+// values for the supported algorithms. Values that cannot be parsed or use an
+// unsupported algorithm are ignored.
+func getDigestValues(h http.Header) (v []digestValue) {
+	for _, header := range h[http.CanonicalHeaderKey(digestHeader)] {
+		for _, elem := range strings.Split(header, digestListDelimiter) {
+			kv := strings.SplitN(strings.TrimSpace(elem), digestDelimiter, 2)
+			if len(kv) != 2 {
+				continue
+			}
+			algo := DigestAlgorithm(strings.ToUpper(kv[0]))
+			if _, ok := algo.hash(); !ok {
+				continue
+			}
+			b, err := base64.StdEncoding.DecodeString(kv[1])
+			if err != nil {
+				continue
+			}
+			v = append(v, digestValue{algo: algo, digest: b})
+		}
+	}
+	for _, header := range h[http.CanonicalHeaderKey(contentDigestHeader)] {
+		for _, elem := range strings.Split(header, digestListDelimiter) {
+			kv := strings.SplitN(strings.TrimSpace(elem), digestDelimiter, 2)
+			if len(kv) != 2 {
+				continue
+			}
+			algo := DigestAlgorithm(strings.ToUpper(kv[0]))
+			if _, ok := algo.hash(); !ok {
+				continue
+			}
+			enc := strings.TrimSpace(kv[1])
+			if len(enc) < 2 ||
+				!strings.HasPrefix(enc, contentDigestByteDelimiter) ||
+				!strings.HasSuffix(enc, contentDigestByteDelimiter) {
+				continue
+			}
+			b, err := base64.StdEncoding.DecodeString(enc[1 : len(enc)-1])
+			if err != nil {
+				continue
+			}
+			v = append(v, digestValue{algo: algo, digest: b})
+		}
+	}
+	return
+}
+
+// verifyDigest determines whether the Digest and Content-Digest headers are
+// valid for the body.
+//
+// Every digest using a supported algorithm must match the body. If any
+// required algorithms are given, then at least one of them must be present.
+func verifyDigest(h http.Header, body []byte, required []DigestAlgorithm) bool {
+	values := getDigestValues(h)
+	found := len(required) == 0
+	for _, v := range values {
+		if subtle.ConstantTimeCompare(v.algo.sum(body), v.digest) != 1 {
+			return false
+		}
+		for _, r := range required {
+			if DigestAlgorithm(strings.ToUpper(string(r))) == v.algo {
+				found = true
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,104 @@
+package pub
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestVerifyDigest(t *testing.T) {
+	body := []byte("hello world")
+	other := []byte("goodbye world")
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		required []DigestAlgorithm
+		expected bool
+	}{
+		{
+			"No Digest Not Required",
+			nil,
+			nil,
+			true,
+		},
+		{
+			"No Digest Required",
+			nil,
+			[]DigestAlgorithm{DigestSha256},
+			false,
+		},
+		{
+			"Matching Digest",
+			map[string]string{digestHeader: digestHeaderValue(DigestSha256, body)},
+			[]DigestAlgorithm{DigestSha256},
+			true,
+		},
+		{
+			"Matching Lowercase Digest",
+			map[string]string{digestHeader: "sha-256=uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek="},
+			[]DigestAlgorithm{DigestSha256},
+			true,
+		},
+		{
+			"Mismatched Digest",
+			map[string]string{digestHeader: digestHeaderValue(DigestSha256, other)},
+			nil,
+			false,
+		},
+		{
+			"Matching Content-Digest",
+			map[string]string{contentDigestHeader: contentDigestHeaderValue(DigestSha512, body)},
+			[]DigestAlgorithm{DigestSha512},
+			true,
+		},
+		{
+			"Mismatched Content-Digest",
+			map[string]string{contentDigestHeader: contentDigestHeaderValue(DigestSha256, other)},
+			nil,
+			false,
+		},
+		{
+			"Required Algorithm Absent",
+			map[string]string{digestHeader: digestHeaderValue(DigestSha512, body)},
+			[]DigestAlgorithm{DigestSha256},
+			false,
+		},
+		{
+			"Unsupported Algorithm Ignored",
+			map[string]string{digestHeader: "MD5=XrY7u+Ae7tCTyyK7j1rNww==, " + digestHeaderValue(DigestSha256, body)},
+			nil,
+			true,
+		},
+		{
+			"One Of Many Mismatched",
+			map[string]string{
+				digestHeader:        digestHeaderValue(DigestSha256, body),
+				contentDigestHeader: contentDigestHeaderValue(DigestSha512, other),
+			},
+			nil,
+			false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := make(http.Header)
+			for k, v := range test.headers {
+				h.Set(k, v)
+			}
+			if actual := verifyDigest(h, body, test.required); actual != test.expected {
+				t.Fatalf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSetDigestHeaders(t *testing.T) {
+	body := []byte("hello world")
+	h := make(http.Header)
+	setDigestHeaders(h, DigestSha256, body)
+	if actual := h.Get(digestHeader); actual != "SHA-256=uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=" {
+		t.Fatalf("unexpected Digest: %s", actual)
+	}
+	if actual := h.Get(contentDigestHeader); actual != "sha-256=:uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=:" {
+		t.Fatalf("unexpected Content-Digest: %s", actual)
+	}
+}
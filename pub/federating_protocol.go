@@ -2,7 +2,6 @@ package pub
 
 import (
 	"context"
-	"github.com/go-fed/activity/streams/vocab"
 	"net/http"
 	"net/url"
 )
@@ -64,6 +63,10 @@ type FederatingProtocol interface {
 	// Finally, if the authentication and authorization succeeds, then
 	// blocked must be false and error nil. The request will continue
 	// to be processed.
+	//
+	// Regardless, activities from actors blocked in the BlockStore of a
+	// BlockStoreProvider by the actor owning the inbox are rejected
+	// afterwards.
 	Blocked(c context.Context, actorIRIs []*url.URL) (blocked bool, err error)
 	// FederatingCallbacks returns the application logic that handles
 	// ActivityStreams received from federating peers.
@@ -112,13 +115,127 @@ type FederatingProtocol interface {
 	// The activity is provided as a reference for more intelligent
 	// logic to be used, but the implementation must not modify it.
 	FilterForwarding(c context.Context, potentialRecipients []*url.URL, a Activity) (filteredRecipients []*url.URL, err error)
-	// GetInbox returns the OrderedCollection inbox of the actor for this
-	// context. It is up to the implementation to provide the correct
-	// collection for the kind of authorization given in the request.
-	//
-	// AuthenticateGetInbox will be called prior to this.
+}
+
+// BlockStoreProvider may be implemented by a FederatingProtocol to record the
+// Blocks sent by local actors, and to reject the activities of the blocked
+// actors that are posted to the inboxes of the local actors that blocked them.
+//
+// If the FederatingProtocol does not implement it, then Blocks are not
+// recorded.
+type BlockStoreProvider interface {
+	// BlockStore returns the BlockStore in which the Blocks sent by local
+	// actors are recorded, and from which they are removed when undone.
+	//
+	// If nil, then Blocks are not recorded.
+	BlockStore(c context.Context) BlockStore
+}
+
+// PostInboxDigestPolicy may be implemented by a FederatingProtocol to require
+// digests of the bodies of POSTs to inboxes.
+//
+// If the FederatingProtocol does not implement it, then neither the Digest nor
+// the Content-Digest header is required.
+type PostInboxDigestPolicy interface {
+	// PostInboxDigestAlgorithms determines which digest algorithms a POST
+	// to the inbox must use in its Digest or Content-Digest header.
+	//
+	// If none are returned, then neither header is required. Regardless,
+	// every digest present in the request using a supported algorithm
+	// must match the request body, otherwise an http.StatusBadRequest
+	// will be written in the response.
+	PostInboxDigestAlgorithms(c context.Context, inboxIRI *url.URL) []DigestAlgorithm
+}
+
+// DeliveryCollectionPolicy may be implemented by a FederatingProtocol to limit
+// the traversal of collections owned by peers that are targeted to receive a
+// delivery.
+//
+// If the FederatingProtocol does not implement it, then no more than 100 pages
+// and 10000 items of each collection are used.
+type DeliveryCollectionPolicy interface {
+	// MaxDeliveryCollectionPages determines how many pages of a
+	// collection owned by a peer to dereference when it is targeted to
+	// receive a delivery.
+	//
+	// Zero or negative numbers indicate no limit.
+	MaxDeliveryCollectionPages(c context.Context) int
+	// MaxDeliveryCollectionItems determines how many items of a
+	// collection owned by a peer to deliver to when it is targeted to
+	// receive a delivery.
+	//
+	// Zero or negative numbers indicate no limit.
+	MaxDeliveryCollectionItems(c context.Context) int
+}
+
+// PublicSharedInboxesProvider may be implemented by a FederatingProtocol to
+// deliver activities addressed to the Public collection to the known
+// sharedInbox endpoints on the network.
+//
+// If the FederatingProtocol does not implement it, then public activities are
+// only delivered to the addressed recipients.
+type PublicSharedInboxesProvider interface {
+	// PublicSharedInboxes returns the known sharedInbox endpoints on the
+	// network that an activity addressed to the Public collection is
+	// additionally delivered to.
+	//
+	// Only called when delivering an activity addressed to the Public
+	// collection. Return nil to only deliver to the addressed
+	// recipients.
+	PublicSharedInboxes(c context.Context) ([]*url.URL, error)
+}
+
+// DeliveryQueueProvider may be implemented by a FederatingProtocol to send
+// deliveries through a DeliveryQueue.
+//
+// If the FederatingProtocol does not implement it, then deliveries are sent
+// directly with the Transport's BatchDeliver and failures are not retried.
+type DeliveryQueueProvider interface {
+	// DeliveryQueue returns the DeliveryQueue used to send deliveries,
+	// such as RetryingDeliveryQueue.
+	//
+	// If nil is returned, deliveries are sent directly with the
+	// Transport's BatchDeliver and failures are not retried.
+	DeliveryQueue(c context.Context) DeliveryQueue
+}
+
+// ModerationQueueProvider may be implemented by a FederatingProtocol to queue
+// received Flags for moderators.
+//
+// If the FederatingProtocol does not implement it, then Flags are not queued
+// and reports cannot be forwarded.
+type ModerationQueueProvider interface {
+	// ModerationQueue returns the ModerationQueue in which Flags of
+	// objects owned by this server are queued as Reports for moderators.
+	//
+	// If nil, then Flags are not queued.
+	ModerationQueue(c context.Context) ModerationQueue
+}
+
+// VoteStoreProvider may be implemented by a FederatingProtocol to count votes
+// in local Questions.
+//
+// If the FederatingProtocol does not implement it, then votes are not counted.
+type VoteStoreProvider interface {
+	// VoteStore returns the VoteStore in which the votes received for
+	// local Questions are recorded.
 	//
-	// Always called, regardless whether the Federated Protocol or Social
-	// API is enabled.
-	GetInbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error)
+	// If nil, then votes are not counted.
+	VoteStore(c context.Context) VoteStore
+}
+
+// LDSignerProvider may be implemented by a FederatingProtocol to attach a
+// Linked Data Signature to public activities delivered from an outbox.
+//
+// If the FederatingProtocol does not implement it, then activities are
+// delivered unsigned.
+type LDSignerProvider interface {
+	// LDSigner returns the LDSigner used to sign activities addressed to
+	// the Public collection that are delivered from the outbox, such as
+	// an RsaSignature2017Signer with the key of the outbox's actor.
+	//
+	// Peers use the signature to authenticate the activity when it is
+	// forwarded to them by other servers. If nil is returned, then
+	// activities are delivered unsigned.
+	LDSigner(c context.Context, outboxIRI *url.URL) (LDSigner, error)
 }
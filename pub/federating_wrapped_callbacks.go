@@ -23,6 +23,12 @@ const (
 	// OnFollowAutomaticallyAccept triggers the side effect of sending a
 	// Reject of this Follow request in response.
 	OnFollowAutomaticallyReject
+	// OnFollowManuallyApprove stores the Follow request in the actor's
+	// follow requests collection, without responding to it. The request is
+	// later accepted or rejected by calling ApproveFollowRequest or
+	// DenyFollowRequest on the FederatingActor. The Database must
+	// implement FollowRequestsDatabase.
+	OnFollowManuallyApprove
 )
 
 // FederatingWrappedCallbacks lists the callback functions that already have
@@ -37,7 +43,26 @@ type FederatingWrappedCallbacks struct {
 	// 'object' property is created in the database.
 	//
 	// Create calls Create for each object in the federated Activity.
+	//
+	// A Note with a 'name' in reply to a Question of the actor owning this
+	// inbox is counted as a vote, if the FederatingProtocol implements
+	// VoteStoreProvider: the vote is recorded in the VoteStore, and the
+	// 'totalItems' of the 'replies' of each option in 'oneOf' or 'anyOf'
+	// and the 'votersCount' are updated. The voters themselves are not
+	// stored in the Question. An Update of the Question is then sent to
+	// its voters. Votes in a Question that is 'closed' or past its
+	// 'endTime' are rejected with ErrPollClosed, and votes for an unknown
+	// option or not attributed to the actor of the Create are rejected
+	// with ErrInvalidVote.
 	Create func(context.Context, vocab.ActivityStreamsCreate) error
+	// MaintainReplies determines whether the objects of a federated Create
+	// are added to the 'replies' collection of the objects they are
+	// 'inReplyTo' that are owned by this server, keeping its 'totalItems'
+	// up to date. They are removed again when a Delete of the reply, or an
+	// Undo of its Create, is received.
+	//
+	// Votes counted in a local Question are not added to its 'replies'.
+	MaintainReplies bool
 	// Update handles additional side effects for the Update ActivityStreams
 	// type, specific to the application using go-fed.
 	//
@@ -50,7 +75,9 @@ type FederatingWrappedCallbacks struct {
 	// Delete handles additional side effects for the Delete ActivityStreams
 	// type, specific to the application using go-fed.
 	//
-	// Delete removes the federated entry from the database.
+	// Delete removes the federated entry from the database. If
+	// MaintainReplies is set, it is first removed from the 'replies'
+	// collection of the objects it is 'inReplyTo'.
 	Delete func(context.Context, vocab.ActivityStreamsDelete) error
 	// Follow handles additional side effects for the Follow ActivityStreams
 	// type, specific to the application using go-fed.
@@ -64,19 +91,19 @@ type FederatingWrappedCallbacks struct {
 	// Accept handles additional side effects for the Accept ActivityStreams
 	// type, specific to the application using go-fed.
 	//
-	// The wrapping function determines if this 'Accept' is in response to a
-	// 'Follow'. If so, then the 'actor' is added to the original 'actor's
-	// 'following' collection.
+	// The wrapping function determines if this 'Accept' is in response to
+	// one or more 'Follow's sent by this actor. If so, then the 'actor' is
+	// added to the original 'actor's 'following' collection.
 	//
 	// Otherwise, no side effects are done by go-fed.
 	Accept func(context.Context, vocab.ActivityStreamsAccept) error
 	// Reject handles additional side effects for the Reject ActivityStreams
 	// type, specific to the application using go-fed.
 	//
-	// The wrapping function has no default side effects. However, if this
-	// 'Reject' is in response to a 'Follow' then the client MUST NOT go
-	// forward with adding the 'actor' to the original 'actor's 'following'
-	// collection by the client application.
+	// The wrapping function determines if this 'Reject' is in response to
+	// one or more 'Follow's sent by this actor. If so, then the 'actor' is
+	// removed from the original 'actor's 'following' collection, so that
+	// revoked follows are no longer listed.
 	Reject func(context.Context, vocab.ActivityStreamsReject) error
 	// Add handles additional side effects for the Add ActivityStreams
 	// type, specific to the application using go-fed.
@@ -112,8 +139,17 @@ type FederatingWrappedCallbacks struct {
 	// It enforces that the actors on the Undo must correspond to all of the
 	// 'object' actors in some manner.
 	//
+	// It also reverses the side effects of the wrapping functions for
+	// Follow, Like, and Announce: the Follow's actors are removed from the
+	// 'followers' collection, a pending Follow is removed from the follow
+	// requests, and the activity is removed from the
+	// "likes" or "shares" collection of all 'object' targets owned by this
+	// server. If MaintainReplies is set, the objects of an undone Create are
+	// removed from the 'replies' collection of the objects they are
+	// 'inReplyTo'.
+	//
 	// It is expected that the application will implement the proper
-	// reversal of activities that are being undone.
+	// reversal of any other activities that are being undone.
 	Undo func(context.Context, vocab.ActivityStreamsUndo) error
 	// Block handles additional side effects for the Block ActivityStreams
 	// type, specific to the application using go-fed.
@@ -123,6 +159,24 @@ type FederatingWrappedCallbacks struct {
 	// received from a federated peer, as delivering Blocks explicitly
 	// deviates from the original ActivityPub specification.
 	Block func(context.Context, vocab.ActivityStreamsBlock) error
+	// Move handles additional side effects for the Move ActivityStreams
+	// type, specific to the application using go-fed.
+	//
+	// The wrapping function ensures the 'object' of the Move is one of its
+	// 'actor's, and that the 'target' lists the 'object' in its
+	// 'alsoKnownAs' property. If this actor follows the 'object', it is
+	// removed from the 'following' collection and a Follow is sent to the
+	// 'target' instead.
+	Move func(context.Context, vocab.ActivityStreamsMove) error
+	// Flag handles additional side effects for the Flag ActivityStreams
+	// type, specific to the application using go-fed.
+	//
+	// The wrapping function determines which of the reported 'object's are
+	// owned by this server. If any are, the Flag is added as a Report to
+	// the ModerationQueue, if the FederatingProtocol implements
+	// ModerationQueueProvider. Flags
+	// that only report objects on other servers are not queued.
+	Flag func(context.Context, vocab.ActivityStreamsFlag) error
 
 	// Sidechannel data -- this is set at request handling time. These must
 	// be set before the callbacks are used.
@@ -131,6 +185,10 @@ type FederatingWrappedCallbacks struct {
 	db Database
 	// inboxIRI is the inboxIRI that is handling this callback.
 	inboxIRI *url.URL
+	// recipientOnly is set when the activity was already posted to the
+	// inbox of another local recipient of a shared inbox. Only the side
+	// effects specific to the actor owning this inbox are then applied.
+	recipientOnly bool
 	// addNewIds creates new 'id' entries on an activity and its objects if
 	// it is a Create activity.
 	addNewIds func(c context.Context, activity Activity) error
@@ -138,6 +196,14 @@ type FederatingWrappedCallbacks struct {
 	deliver func(c context.Context, outboxIRI *url.URL, activity Activity) error
 	// newTransport creates a new Transport.
 	newTransport func(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (t Transport, err error)
+	// moderationQueue obtains the ModerationQueue, which is nil if Flags
+	// are not queued.
+	moderationQueue func(c context.Context) ModerationQueue
+	// voteStore obtains the VoteStore, which is nil if votes are not
+	// counted.
+	voteStore func(c context.Context) VoteStore
+	// clock is the server's clock.
+	clock Clock
 }
 
 // callbacks returns the WrappedCallbacks members into a single interface slice
@@ -158,6 +224,8 @@ func (w FederatingWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 	enableAnnounce := true
 	enableUndo := true
 	enableBlock := true
+	enableMove := true
+	enableFlag := true
 	for _, fn := range fns {
 		switch fn.(type) {
 		default:
@@ -186,6 +254,10 @@ func (w FederatingWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 			enableUndo = false
 		case func(context.Context, vocab.ActivityStreamsBlock) error:
 			enableBlock = false
+		case func(context.Context, vocab.ActivityStreamsMove) error:
+			enableMove = false
+		case func(context.Context, vocab.ActivityStreamsFlag) error:
+			enableFlag = false
 		}
 	}
 	if enableCreate {
@@ -224,6 +296,12 @@ func (w FederatingWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 	if enableBlock {
 		fns = append(fns, w.block)
 	}
+	if enableMove {
+		fns = append(fns, w.move)
+	}
+	if enableFlag {
+		fns = append(fns, w.flag)
+	}
 	return fns
 }
 
@@ -233,6 +311,7 @@ func (w FederatingWrappedCallbacks) create(c context.Context, a vocab.ActivitySt
 	if op == nil || op.Len() == 0 {
 		return ErrObjectRequired
 	}
+	var created []vocab.Type
 	// Create anonymous loop function to be able to properly scope the defer
 	// for the database lock at each iteration.
 	loopFn := func(iter vocab.ActivityStreamsObjectPropertyIterator) error {
@@ -262,6 +341,11 @@ func (w FederatingWrappedCallbacks) create(c context.Context, a vocab.ActivitySt
 		if err != nil {
 			return err
 		}
+		if err = w.vote(c, a, t); err != nil {
+			return err
+		} else if w.recipientOnly {
+			return nil
+		}
 		err = w.db.Lock(c, id)
 		if err != nil {
 			return err
@@ -270,6 +354,7 @@ func (w FederatingWrappedCallbacks) create(c context.Context, a vocab.ActivitySt
 		if err := w.db.Create(c, t); err != nil {
 			return err
 		}
+		created = append(created, t)
 		return nil
 	}
 	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
@@ -277,12 +362,315 @@ func (w FederatingWrappedCallbacks) create(c context.Context, a vocab.ActivitySt
 			return err
 		}
 	}
+	if w.MaintainReplies {
+		for _, t := range created {
+			if err := w.addReply(c, t); err != nil {
+				return err
+			}
+		}
+	}
 	if w.Create != nil {
 		return w.Create(c, a)
 	}
 	return nil
 }
 
+// vote counts the vote if the value is one in a local Question owned by the
+// actor of this inbox, and sends the updated Question to its voters.
+func (w FederatingWrappedCallbacks) vote(c context.Context, a vocab.ActivityStreamsCreate, t vocab.Type) error {
+	questionIRI, optionName, voter, ok := voteFor(t)
+	if !ok {
+		return nil
+	}
+	store := w.getVoteStore(c)
+	if store == nil {
+		return nil
+	}
+	actorIRI, err := w.actorForInbox(c)
+	if err != nil {
+		return err
+	}
+	if err = w.db.Lock(c, questionIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	question, voters, err := w.tallyVote(c, store, questionIRI, actorIRI, optionName, voter, isOnlyActor(a, voter))
+	w.db.Unlock(c, questionIRI)
+	// Unlock must be called by now and every branch above.
+	if err != nil || question == nil {
+		return err
+	}
+	return w.sendPollUpdate(c, actorIRI, question, voters)
+}
+
+// voteFor determines the Question IRI, chosen option and voter if the value
+// looks like a vote: a Note with a 'name', in reply to exactly one IRI.
+func voteFor(t vocab.Type) (questionIRI *url.URL, optionName string, voter *url.URL, ok bool) {
+	if !streams.IsOrExtendsActivityStreamsNote(t) {
+		return
+	}
+	n, isNamer := t.(namer)
+	irt, isInReplyToer := t.(inReplyToer)
+	at, isAttributedToer := t.(attributedToer)
+	if !isNamer || !isInReplyToer || !isAttributedToer {
+		return
+	}
+	optionName = firstName(n)
+	if len(optionName) == 0 {
+		return
+	}
+	irtProp := irt.GetActivityStreamsInReplyTo()
+	if irtProp == nil || irtProp.Len() != 1 || !irtProp.At(0).IsIRI() {
+		return
+	}
+	atProp := at.GetActivityStreamsAttributedTo()
+	if atProp == nil || atProp.Len() != 1 {
+		return
+	}
+	var err error
+	if voter, err = ToId(atProp.At(0)); err != nil {
+		return
+	}
+	questionIRI = irtProp.At(0).GetIRI()
+	ok = true
+	return
+}
+
+// firstName returns the first plain string 'name' of the value, if any.
+func firstName(n namer) string {
+	np := n.GetActivityStreamsName()
+	if np == nil {
+		return ""
+	}
+	for iter := np.Begin(); iter != np.End(); iter = iter.Next() {
+		if iter.IsXMLSchemaString() {
+			return iter.GetXMLSchemaString()
+		}
+	}
+	return ""
+}
+
+// tallyVote records the vote for the chosen option of the Question in the
+// VoteStore, and updates the 'totalItems' of the 'replies' of its options and
+// its 'votersCount'. It returns the updated Question and all of its voters, or
+// a nil Question if the vote is not counted because it is not for a Question
+// of the actor or the voter has already voted.
+//
+// A vote for a Question of the actor that was not sent by the voter, or for
+// an option the Question does not have, is rejected with ErrInvalidVote.
+//
+// The lock for the Question must be held.
+func (w FederatingWrappedCallbacks) tallyVote(c context.Context, store VoteStore, questionIRI, actorIRI *url.URL, optionName string, voter *url.URL, fromVoter bool) (question vocab.ActivityStreamsQuestion, voters []*url.URL, err error) {
+	if owns, err := w.db.Owns(c, questionIRI); err != nil || !owns {
+		return nil, nil, err
+	}
+	t, err := w.db.Get(c, questionIRI)
+	if err != nil {
+		return nil, nil, err
+	}
+	question, ok := t.(vocab.ActivityStreamsQuestion)
+	if !ok {
+		return nil, nil, nil
+	}
+	if !isAttributedTo(question, actorIRI) {
+		return nil, nil, nil
+	}
+	if !fromVoter {
+		return nil, nil, ErrInvalidVote
+	}
+	if w.pollClosed(question) {
+		return nil, nil, ErrPollClosed
+	}
+	options, single := pollOptions(question)
+	known := false
+	for _, option := range options {
+		if n, ok := option.(namer); ok && firstName(n) == optionName {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, nil, ErrInvalidVote
+	}
+	votes, err := store.Votes(c, questionIRI)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, v := range votes {
+		if v.Voter.String() == voter.String() && (single || v.Option == optionName) {
+			// Already voted.
+			return nil, nil, nil
+		}
+	}
+	vote := Vote{
+		Voter:  voter,
+		Option: optionName,
+	}
+	if err = store.AddVote(c, questionIRI, vote); err != nil {
+		return nil, nil, err
+	}
+	votes = append(votes, vote)
+	// Count the votes for each option, and who voted.
+	counts := make(map[string]int, len(options))
+	seen := make(map[string]bool, len(votes))
+	for _, v := range votes {
+		counts[v.Option]++
+		if !seen[v.Voter.String()] {
+			seen[v.Voter.String()] = true
+			voters = append(voters, v.Voter)
+		}
+	}
+	for _, option := range options {
+		n, ok := option.(namer)
+		if !ok {
+			continue
+		}
+		r, ok := option.(replieser)
+		if !ok {
+			continue
+		}
+		if err = setOptionCount(r, counts[firstName(n)]); err != nil {
+			return nil, nil, err
+		}
+	}
+	votersCount := streams.NewTootVotersCountProperty()
+	votersCount.Set(len(voters))
+	question.SetTootVotersCount(votersCount)
+	if err = w.db.Update(c, question); err != nil {
+		return nil, nil, err
+	}
+	return question, voters, nil
+}
+
+// pollOptions returns the embedded options of the Question, and whether only
+// one of them may be chosen.
+func pollOptions(question vocab.ActivityStreamsQuestion) (options []vocab.Type, single bool) {
+	single = true
+	if oneOf := question.GetActivityStreamsOneOf(); oneOf != nil {
+		for iter := oneOf.Begin(); iter != oneOf.End(); iter = iter.Next() {
+			if t := iter.GetType(); t != nil {
+				options = append(options, t)
+			}
+		}
+	}
+	if anyOf := question.GetActivityStreamsAnyOf(); anyOf != nil && anyOf.Len() > 0 {
+		single = false
+		for iter := anyOf.Begin(); iter != anyOf.End(); iter = iter.Next() {
+			if t := iter.GetType(); t != nil {
+				options = append(options, t)
+			}
+		}
+	}
+	return
+}
+
+// isAttributedTo determines whether the actor is one of the 'attributedTo' of
+// the value.
+func isAttributedTo(at attributedToer, actorIRI *url.URL) bool {
+	atProp := at.GetActivityStreamsAttributedTo()
+	if atProp == nil {
+		return false
+	}
+	for iter := atProp.Begin(); iter != atProp.End(); iter = iter.Next() {
+		if id, err := ToId(iter); err == nil && id.String() == actorIRI.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// isOnlyActor determines whether the actor is the only 'actor' of the
+// activity.
+func isOnlyActor(a Activity, actorIRI *url.URL) bool {
+	actors := a.GetActivityStreamsActor()
+	if actors == nil || actors.Len() != 1 {
+		return false
+	}
+	id, err := ToId(actors.At(0))
+	return err == nil && id.String() == actorIRI.String()
+}
+
+// pollClosed determines whether the Question no longer accepts votes, because
+// it is 'closed' or its 'endTime' has passed.
+func (w FederatingWrappedCallbacks) pollClosed(question vocab.ActivityStreamsQuestion) bool {
+	now := w.clock.Now()
+	if closed := question.GetActivityStreamsClosed(); closed != nil {
+		for iter := closed.Begin(); iter != closed.End(); iter = iter.Next() {
+			if iter.IsXMLSchemaBoolean() {
+				if iter.GetXMLSchemaBoolean() {
+					return true
+				}
+			} else if iter.IsXMLSchemaDateTime() {
+				if !now.Before(iter.GetXMLSchemaDateTime()) {
+					return true
+				}
+			} else {
+				return true
+			}
+		}
+	}
+	if endTime := question.GetActivityStreamsEndTime(); endTime != nil && endTime.IsXMLSchemaDateTime() {
+		if !now.Before(endTime.Get()) {
+			return true
+		}
+	}
+	return false
+}
+
+// setOptionCount sets the 'totalItems' of the 'replies' Collection of a poll
+// option to the number of votes for it, creating the Collection if needed.
+// Any voters listed in its 'items' are removed.
+func setOptionCount(r replieser, n int) error {
+	replies := r.GetActivityStreamsReplies()
+	if replies == nil {
+		replies = streams.NewActivityStreamsRepliesProperty()
+		r.SetActivityStreamsReplies(replies)
+	}
+	if !replies.IsActivityStreamsCollection() {
+		if replies.IsIRI() || replies.GetType() != nil {
+			return fmt.Errorf("poll option replies are not an embedded Collection")
+		}
+		replies.SetActivityStreamsCollection(streams.NewActivityStreamsCollection())
+	}
+	col := replies.GetActivityStreamsCollection()
+	col.SetActivityStreamsItems(nil)
+	totalItems := streams.NewActivityStreamsTotalItemsProperty()
+	totalItems.Set(n)
+	col.SetActivityStreamsTotalItems(totalItems)
+	return nil
+}
+
+// sendPollUpdate sends an Update of the Question to its voters.
+func (w FederatingWrappedCallbacks) sendPollUpdate(c context.Context, actorIRI *url.URL, question vocab.ActivityStreamsQuestion, voters []*url.URL) error {
+	update := streams.NewActivityStreamsUpdate()
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendIRI(actorIRI)
+	update.SetActivityStreamsActor(actor)
+	op := streams.NewActivityStreamsObjectProperty()
+	op.AppendActivityStreamsQuestion(question)
+	update.SetActivityStreamsObject(op)
+	to := streams.NewActivityStreamsToProperty()
+	for _, voter := range voters {
+		to.AppendIRI(voter)
+	}
+	update.SetActivityStreamsTo(to)
+	if err := w.addNewIds(c, update); err != nil {
+		return err
+	}
+	if err := w.db.Lock(c, w.inboxIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	outboxIRI, err := w.db.OutboxForInbox(c, w.inboxIRI)
+	if err != nil {
+		w.db.Unlock(c, w.inboxIRI)
+		return err
+	}
+	w.db.Unlock(c, w.inboxIRI)
+	// Unlock must be called by now and every branch above.
+	return w.deliver(c, outboxIRI, update)
+}
+
 // update implements the federating Update activity side effects.
 func (w FederatingWrappedCallbacks) update(c context.Context, a vocab.ActivityStreamsUpdate) error {
 	op := a.GetActivityStreamsObject()
@@ -298,6 +686,8 @@ func (w FederatingWrappedCallbacks) update(c context.Context, a vocab.ActivitySt
 		t := iter.GetType()
 		if t == nil {
 			return fmt.Errorf("update requires an object to be wholly provided")
+		} else if w.recipientOnly {
+			return nil
 		}
 		id, err := GetId(t)
 		if err != nil {
@@ -350,7 +740,16 @@ func (w FederatingWrappedCallbacks) deleteFn(c context.Context, a vocab.Activity
 		}
 		return nil
 	}
-	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+	for iter := op.Begin(); iter != op.End() && !w.recipientOnly; iter = iter.Next() {
+		if w.MaintainReplies {
+			id, err := ToId(iter)
+			if err != nil {
+				return err
+			}
+			if err = w.removeReply(c, id); err != nil {
+				return err
+			}
+		}
 		if err := loopFn(iter); err != nil {
 			return err
 		}
@@ -372,17 +771,10 @@ func (w FederatingWrappedCallbacks) follow(c context.Context, a vocab.ActivitySt
 	//
 	// If not then don't send a response. It was federated to us as an FYI,
 	// by mistake, or some other reason.
-	if err := w.db.Lock(c, w.inboxIRI); err != nil {
-		return err
-	}
-	// WARNING: Unlock not deferred.
-	actorIRI, err := w.db.ActorForInbox(c, w.inboxIRI)
+	actorIRI, err := w.actorForInbox(c)
 	if err != nil {
-		w.db.Unlock(c, w.inboxIRI)
 		return err
 	}
-	w.db.Unlock(c, w.inboxIRI)
-	// Unlock must be called by now and every branch above.
 	isMe := false
 	if w.OnFollow != OnFollowDoNothing {
 		for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
@@ -397,78 +789,17 @@ func (w FederatingWrappedCallbacks) follow(c context.Context, a vocab.ActivitySt
 		}
 	}
 	if isMe {
-		// Prepare the response.
-		var response Activity
-		if w.OnFollow == OnFollowAutomaticallyAccept {
-			response = streams.NewActivityStreamsAccept()
-		} else if w.OnFollow == OnFollowAutomaticallyReject {
-			response = streams.NewActivityStreamsReject()
-		} else {
-			return fmt.Errorf("unknown OnFollowBehavior: %d", w.OnFollow)
-		}
-		// Set us as the 'actor'.
-		me := streams.NewActivityStreamsActorProperty()
-		response.SetActivityStreamsActor(me)
-		me.AppendIRI(actorIRI)
-		// Set the Follow as the 'object' property.
-		op := streams.NewActivityStreamsObjectProperty()
-		response.SetActivityStreamsObject(op)
-		op.AppendActivityStreamsFollow(a)
-		// Add all actors on the original Follow to the 'to' property.
-		recipients := make([]*url.URL, 0)
-		to := streams.NewActivityStreamsToProperty()
-		response.SetActivityStreamsTo(to)
-		followActors := a.GetActivityStreamsActor()
-		for iter := followActors.Begin(); iter != followActors.End(); iter = iter.Next() {
-			id, err := ToId(iter)
-			if err != nil {
-				return err
-			}
-			to.AppendIRI(id)
-			recipients = append(recipients, id)
-		}
-		if w.OnFollow == OnFollowAutomaticallyAccept {
-			// If automatically accepting, then also update our
-			// followers collection with the new actors.
-			//
-			// If automatically rejecting, do not update the
-			// followers collection.
-			if err := w.db.Lock(c, actorIRI); err != nil {
-				return err
-			}
-			// WARNING: Unlock not deferred.
-			followers, err := w.db.Followers(c, actorIRI)
-			if err != nil {
-				w.db.Unlock(c, actorIRI)
-				return err
-			}
-			items := followers.GetActivityStreamsItems()
-			if items == nil {
-				items = streams.NewActivityStreamsItemsProperty()
-				followers.SetActivityStreamsItems(items)
-			}
-			for _, elem := range recipients {
-				items.PrependIRI(elem)
-			}
-			if err = w.db.Update(c, followers); err != nil {
-				w.db.Unlock(c, actorIRI)
-				return err
-			}
-			w.db.Unlock(c, actorIRI)
-			// Unlock must be called by now and every branch above.
+		switch w.OnFollow {
+		case OnFollowAutomaticallyAccept:
+			err = w.respondToFollow(c, a, actorIRI, true)
+		case OnFollowAutomaticallyReject:
+			err = w.respondToFollow(c, a, actorIRI, false)
+		case OnFollowManuallyApprove:
+			err = w.addFollowRequest(c, a, actorIRI)
+		default:
+			err = fmt.Errorf("unknown OnFollowBehavior: %d", w.OnFollow)
 		}
-		// Lock without defer!
-		w.db.Lock(c, w.inboxIRI)
-		outboxIRI, err := w.db.OutboxForInbox(c, w.inboxIRI)
 		if err != nil {
-			w.db.Unlock(c, w.inboxIRI)
-			return err
-		}
-		w.db.Unlock(c, w.inboxIRI)
-		// Everything must be unlocked by now.
-		if err := w.addNewIds(c, response); err != nil {
-			return err
-		} else if err := w.deliver(c, outboxIRI, response); err != nil {
 			return err
 		}
 	}
@@ -478,156 +809,241 @@ func (w FederatingWrappedCallbacks) follow(c context.Context, a vocab.ActivitySt
 	return nil
 }
 
-// accept implements the federating Accept activity side effects.
-func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivityStreamsAccept) error {
-	op := a.GetActivityStreamsObject()
-	if op != nil && op.Len() > 0 {
-		// Get this actor's id.
-		if err := w.db.Lock(c, w.inboxIRI); err != nil {
+// actorForInbox obtains the IRI of the actor owning this inbox.
+func (w FederatingWrappedCallbacks) actorForInbox(c context.Context) (*url.URL, error) {
+	if err := w.db.Lock(c, w.inboxIRI); err != nil {
+		return nil, err
+	}
+	defer w.db.Unlock(c, w.inboxIRI)
+	return w.db.ActorForInbox(c, w.inboxIRI)
+}
+
+// respondToFollow sends an Accept or Reject of the Follow from the actor owning
+// this inbox. When accepting, the actors of the Follow are also added to the
+// 'followers' collection.
+func (w FederatingWrappedCallbacks) respondToFollow(c context.Context, a vocab.ActivityStreamsFollow, actorIRI *url.URL, accept bool) error {
+	// Prepare the response.
+	var response Activity
+	if accept {
+		response = streams.NewActivityStreamsAccept()
+	} else {
+		response = streams.NewActivityStreamsReject()
+	}
+	// Set us as the 'actor'.
+	me := streams.NewActivityStreamsActorProperty()
+	response.SetActivityStreamsActor(me)
+	me.AppendIRI(actorIRI)
+	// Set the Follow as the 'object' property.
+	op := streams.NewActivityStreamsObjectProperty()
+	response.SetActivityStreamsObject(op)
+	op.AppendActivityStreamsFollow(a)
+	// Add all actors on the original Follow to the 'to' property.
+	recipients := make([]*url.URL, 0)
+	to := streams.NewActivityStreamsToProperty()
+	response.SetActivityStreamsTo(to)
+	followActors := a.GetActivityStreamsActor()
+	for iter := followActors.Begin(); iter != followActors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		to.AppendIRI(id)
+		recipients = append(recipients, id)
+	}
+	if accept {
+		// If accepting, then also update our followers collection with
+		// the new actors.
+		//
+		// If rejecting, do not update the followers collection.
+		if err := w.db.Lock(c, actorIRI); err != nil {
 			return err
 		}
 		// WARNING: Unlock not deferred.
-		actorIRI, err := w.db.ActorForInbox(c, w.inboxIRI)
+		followers, err := w.db.Followers(c, actorIRI)
 		if err != nil {
-			w.db.Unlock(c, w.inboxIRI)
+			w.db.Unlock(c, actorIRI)
 			return err
 		}
-		w.db.Unlock(c, w.inboxIRI)
+		items := followers.GetActivityStreamsItems()
+		if items == nil {
+			items = streams.NewActivityStreamsItemsProperty()
+			followers.SetActivityStreamsItems(items)
+		}
+		for _, elem := range recipients {
+			items.PrependIRI(elem)
+		}
+		if err = w.db.Update(c, followers); err != nil {
+			w.db.Unlock(c, actorIRI)
+			return err
+		}
+		w.db.Unlock(c, actorIRI)
 		// Unlock must be called by now and every branch above.
-		//
-		// Determine if we are in a follow on the 'object' property.
-		//
-		// TODO: Handle Accept multiple Follow.
-		var maybeMyFollowIRI *url.URL
-		for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
-			t := iter.GetType()
-			if t == nil && iter.IsIRI() {
-				// Attempt to dereference the IRI instead
-				tport, err := w.newTransport(c, w.inboxIRI, goFedUserAgent())
-				if err != nil {
-					return err
-				}
-				b, err := tport.Dereference(c, iter.GetIRI())
-				if err != nil {
-					return err
-				}
-				var m map[string]interface{}
-				if err = json.Unmarshal(b, &m); err != nil {
-					return err
-				}
-				t, err = streams.ToType(c, m)
-				if err != nil {
-					return err
-				}
-			} else if t == nil {
-				return fmt.Errorf("cannot handle federated create: object is neither a value nor IRI")
-			}
-			// Ensure it is a Follow.
-			if !streams.IsOrExtendsActivityStreamsFollow(t) {
-				continue
-			}
-			follow, ok := t.(Activity)
-			if !ok {
-				return fmt.Errorf("a Follow in an Accept does not satisfy the Activity interface")
-			}
-			followId, err := GetId(follow)
-			if err != nil {
-				return err
-			}
-			// Ensure that we are one of the actors on the Follow.
-			actors := follow.GetActivityStreamsActor()
-			for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
-				id, err := ToId(iter)
-				if err != nil {
-					return err
-				}
-				if id.String() == actorIRI.String() {
-					maybeMyFollowIRI = followId
-					break
-				}
-			}
-			// Continue breaking if we found ourselves
-			if maybeMyFollowIRI != nil {
-				break
-			}
+	}
+	// Lock without defer!
+	w.db.Lock(c, w.inboxIRI)
+	outboxIRI, err := w.db.OutboxForInbox(c, w.inboxIRI)
+	if err != nil {
+		w.db.Unlock(c, w.inboxIRI)
+		return err
+	}
+	w.db.Unlock(c, w.inboxIRI)
+	// Everything must be unlocked by now.
+	if err := w.addNewIds(c, response); err != nil {
+		return err
+	} else if err := w.deliver(c, outboxIRI, response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// addFollowRequest saves the Follow and adds it to the 'follow requests'
+// collection of the actor owning this inbox, to be approved or denied later.
+func (w FederatingWrappedCallbacks) addFollowRequest(c context.Context, a vocab.ActivityStreamsFollow, actorIRI *url.URL) error {
+	id, err := GetId(a)
+	if err != nil {
+		return err
+	}
+	if err = w.createIfNotExists(c, id, a); err != nil {
+		return err
+	}
+	if err = w.db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, actorIRI)
+	requests, err := w.followRequests(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	items := requests.GetActivityStreamsItems()
+	if items == nil {
+		items = streams.NewActivityStreamsItemsProperty()
+		requests.SetActivityStreamsItems(items)
+	}
+	for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI().String() == id.String() {
+			return nil
 		}
-		// If we received an Accept whose 'object' is a Follow with an
-		// Accept that we sent, add to the following collection.
-		if maybeMyFollowIRI != nil {
-			// Verify our Follow request exists and the peer didn't
-			// fabricate it.
-			activityActors := a.GetActivityStreamsActor()
-			if activityActors == nil || activityActors.Len() == 0 {
-				return fmt.Errorf("an Accept with a Follow has no actors")
-			}
-			// This may be a duplicate check if we dereferenced the
-			// Follow above. TODO: Separate this logic to avoid
-			// redundancy.
-			//
-			// Use an anonymous function to properly scope the
-			// database lock, immediately call it.
-			err = func() error {
-				if err := w.db.Lock(c, maybeMyFollowIRI); err != nil {
-					return err
-				}
-				defer w.db.Unlock(c, maybeMyFollowIRI)
-				t, err := w.db.Get(c, maybeMyFollowIRI)
-				if err != nil {
-					return err
-				}
-				if !streams.IsOrExtendsActivityStreamsFollow(t) {
-					return fmt.Errorf("peer gave an Accept wrapping a Follow but provided a non-Follow id")
-				}
-				follow, ok := t.(Activity)
-				if !ok {
-					return fmt.Errorf("a Follow in an Accept does not satisfy the Activity interface")
-				}
-				// Ensure that we are one of the actors on the Follow.
-				ok = false
-				actors := follow.GetActivityStreamsActor()
-				for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
-						return err
-					}
-					if id.String() == actorIRI.String() {
-						ok = true
-						break
-					}
-				}
-				if !ok {
-					return fmt.Errorf("peer gave an Accept wrapping a Follow but we are not the actor on that Follow")
-				}
-				// Build map of original Accept actors
-				acceptActors := make(map[string]bool)
-				for iter := activityActors.Begin(); iter != activityActors.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
-						return err
-					}
-					acceptActors[id.String()] = false
-				}
-				// Verify all actor(s) were on the original Follow.
-				followObj := follow.GetActivityStreamsObject()
-				for iter := followObj.Begin(); iter != followObj.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
-						return err
-					}
-					if _, ok := acceptActors[id.String()]; ok {
-						acceptActors[id.String()] = true
-					}
-				}
-				for _, found := range acceptActors {
-					if !found {
-						return fmt.Errorf("peer gave an Accept wrapping a Follow but was not an object in the original Follow")
-					}
-				}
-				return nil
-			}()
+	}
+	items.PrependIRI(id)
+	return w.db.Update(c, requests)
+}
+
+// createIfNotExists saves the value in the database if it is not already
+// there.
+func (w FederatingWrappedCallbacks) createIfNotExists(c context.Context, id *url.URL, t vocab.Type) error {
+	if err := w.db.Lock(c, id); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, id)
+	if exists, err := w.db.Exists(c, id); err != nil {
+		return err
+	} else if exists {
+		return nil
+	}
+	return w.db.Create(c, t)
+}
+
+// resolveFollowRequest accepts or rejects a Follow in the 'follow requests'
+// collection of the actor owning this inbox.
+//
+// The Follow is removed from the collection before responding, so that only
+// one of concurrent calls responds to it.
+func (w FederatingWrappedCallbacks) resolveFollowRequest(c context.Context, followIRI *url.URL, accept bool) error {
+	actorIRI, err := w.actorForInbox(c)
+	if err != nil {
+		return err
+	}
+	if err = w.removeFollowRequest(c, followIRI, actorIRI); err != nil {
+		return err
+	}
+	if err = w.db.Lock(c, followIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	t, err := w.db.Get(c, followIRI)
+	w.db.Unlock(c, followIRI)
+	if err != nil {
+		return err
+	}
+	// Unlock must be called by now and every branch above.
+	follow, ok := t.(vocab.ActivityStreamsFollow)
+	if !ok || !streams.IsOrExtendsActivityStreamsFollow(t) {
+		return fmt.Errorf("follow request %s is not a Follow: %T", followIRI, t)
+	}
+	return w.respondToFollow(c, follow, actorIRI, accept)
+}
+
+// removeFollowRequest removes a Follow from the 'follow requests' collection
+// of the actor, returning an error if it is not pending.
+func (w FederatingWrappedCallbacks) removeFollowRequest(c context.Context, followIRI, actorIRI *url.URL) error {
+	if err := w.db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, actorIRI)
+	requests, err := w.followRequests(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	pending := false
+	if items := requests.GetActivityStreamsItems(); items != nil {
+		for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+			id, err := ToId(iter)
 			if err != nil {
 				return err
 			}
+			if id.String() == followIRI.String() {
+				pending = true
+				break
+			}
+		}
+	}
+	if !pending {
+		return fmt.Errorf("no pending follow request %s for %s", followIRI, actorIRI)
+	}
+	if err = removeFromCollection(requests, map[string]bool{followIRI.String(): true}); err != nil {
+		return err
+	}
+	return w.db.Update(c, requests)
+}
+
+// followRequests obtains the 'follow requests' collection of the actor, which
+// requires the Database to implement FollowRequestsDatabase.
+func (w FederatingWrappedCallbacks) followRequests(c context.Context, actorIRI *url.URL) (vocab.ActivityStreamsCollection, error) {
+	db, ok := w.db.(FollowRequestsDatabase)
+	if !ok {
+		return nil, fmt.Errorf("cannot obtain follow requests of %s: Database does not implement FollowRequestsDatabase", actorIRI)
+	}
+	return db.FollowRequests(c, actorIRI)
+}
+
+// accept implements the federating Accept activity side effects.
+func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivityStreamsAccept) error {
+	op := a.GetActivityStreamsObject()
+	if op != nil && op.Len() > 0 {
+		// Get this actor's id.
+		actorIRI, err := w.actorForInbox(c)
+		if err != nil {
+			return err
+		}
+		// Determine if we are in a follow on the 'object' property.
+		myFollowIRIs, err := w.myFollows(c, op, actorIRI)
+		if err != nil {
+			return err
+		}
+		// If we received an Accept whose 'object' is a Follow that we
+		// sent, add to the following collection.
+		if len(myFollowIRIs) > 0 {
+			activityActors := a.GetActivityStreamsActor()
+			if activityActors == nil || activityActors.Len() == 0 {
+				return fmt.Errorf("an Accept with a Follow has no actors")
+			}
+			// Verify our Follow requests exist and the peer didn't
+			// fabricate them.
+			for _, followIRI := range myFollowIRIs {
+				if err = w.verifyMyFollow(c, followIRI, actorIRI, activityActors); err != nil {
+					return err
+				}
+			}
 			// Add the peer to our following collection.
 			if err := w.db.Lock(c, actorIRI); err != nil {
 				return err
@@ -667,12 +1083,211 @@ func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivitySt
 
 // reject implements the federating Reject activity side effects.
 func (w FederatingWrappedCallbacks) reject(c context.Context, a vocab.ActivityStreamsReject) error {
+	op := a.GetActivityStreamsObject()
+	if op != nil && op.Len() > 0 {
+		// Get this actor's id.
+		actorIRI, err := w.actorForInbox(c)
+		if err != nil {
+			return err
+		}
+		// Determine if we are in a follow on the 'object' property.
+		myFollowIRIs, err := w.myFollows(c, op, actorIRI)
+		if err != nil {
+			return err
+		}
+		// If we received a Reject whose 'object' is a Follow that we
+		// sent, remove the peer from the following collection. The
+		// peer may be rejecting a pending Follow, or revoking one it
+		// had previously accepted.
+		if len(myFollowIRIs) > 0 {
+			activityActors := a.GetActivityStreamsActor()
+			if activityActors == nil || activityActors.Len() == 0 {
+				return fmt.Errorf("a Reject with a Follow has no actors")
+			}
+			// Verify our Follow requests exist and the peer didn't
+			// fabricate them.
+			for _, followIRI := range myFollowIRIs {
+				if err = w.verifyMyFollow(c, followIRI, actorIRI, activityActors); err != nil {
+					return err
+				}
+			}
+			rejected := make(map[string]bool, activityActors.Len())
+			for iter := activityActors.Begin(); iter != activityActors.End(); iter = iter.Next() {
+				id, err := ToId(iter)
+				if err != nil {
+					return err
+				}
+				rejected[id.String()] = true
+			}
+			// Remove the peer from our following collection.
+			if err := w.db.Lock(c, actorIRI); err != nil {
+				return err
+			}
+			// WARNING: Unlock not deferred.
+			following, err := w.db.Following(c, actorIRI)
+			if err != nil {
+				w.db.Unlock(c, actorIRI)
+				return err
+			}
+			if err = removeFromCollection(following, rejected); err != nil {
+				w.db.Unlock(c, actorIRI)
+				return err
+			}
+			if err = w.db.Update(c, following); err != nil {
+				w.db.Unlock(c, actorIRI)
+				return err
+			}
+			w.db.Unlock(c, actorIRI)
+			// Unlock must be called by now and every branch above.
+		}
+	}
 	if w.Reject != nil {
 		return w.Reject(c, a)
 	}
 	return nil
 }
 
+// myFollows determines the ids of the Follows on the 'object' property of an
+// Accept or Reject that have the actor owning this inbox as one of their
+// actors. Follows referenced by IRI are dereferenced.
+func (w FederatingWrappedCallbacks) myFollows(c context.Context, op vocab.ActivityStreamsObjectProperty, actorIRI *url.URL) ([]*url.URL, error) {
+	var myFollowIRIs []*url.URL
+	seen := make(map[string]bool)
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		t := iter.GetType()
+		if t == nil && iter.IsIRI() {
+			// Attempt to dereference the IRI instead
+			tport, err := w.newTransport(c, w.inboxIRI, goFedUserAgent())
+			if err != nil {
+				return nil, err
+			}
+			b, err := tport.Dereference(c, iter.GetIRI())
+			if err != nil {
+				return nil, err
+			}
+			var m map[string]interface{}
+			if err = json.Unmarshal(b, &m); err != nil {
+				return nil, err
+			}
+			t, err = streams.ToType(c, m)
+			if err != nil {
+				return nil, err
+			}
+		} else if t == nil {
+			return nil, fmt.Errorf("cannot determine Follow: object is neither a value nor IRI")
+		}
+		// Ensure it is a Follow.
+		if !streams.IsOrExtendsActivityStreamsFollow(t) {
+			continue
+		}
+		follow, ok := t.(Activity)
+		if !ok {
+			return nil, fmt.Errorf("a Follow in an Accept or Reject does not satisfy the Activity interface")
+		}
+		followId, err := GetId(follow)
+		if err != nil {
+			return nil, err
+		}
+		if seen[followId.String()] {
+			continue
+		}
+		// Ensure that we are one of the actors on the Follow.
+		actors := follow.GetActivityStreamsActor()
+		if actors == nil {
+			continue
+		}
+		for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return nil, err
+			}
+			if id.String() == actorIRI.String() {
+				seen[followId.String()] = true
+				myFollowIRIs = append(myFollowIRIs, followId)
+				break
+			}
+		}
+	}
+	return myFollowIRIs, nil
+}
+
+// verifyMyFollow checks a Follow in an Accept or Reject against the one we
+// sent from our outbox, ensuring the peer didn't fabricate it. The Follow must
+// be owned by this server, and the stored Follow must have us as an actor and
+// all the peer actors as its objects.
+func (w FederatingWrappedCallbacks) verifyMyFollow(c context.Context, followIRI, actorIRI *url.URL, peerActors vocab.ActivityStreamsActorProperty) error {
+	// This may be a duplicate check if we dereferenced the Follow in
+	// myFollows, but the peer may have served a fabricated one.
+	if err := w.db.Lock(c, followIRI); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, followIRI)
+	// A Follow not created by this server, such as one stored from a
+	// federated activity, was not sent from our outbox.
+	if owns, err := w.db.Owns(c, followIRI); err != nil {
+		return err
+	} else if !owns {
+		return fmt.Errorf("peer responded to a Follow that was not sent by this server")
+	}
+	t, err := w.db.Get(c, followIRI)
+	if err != nil {
+		return err
+	}
+	if !streams.IsOrExtendsActivityStreamsFollow(t) {
+		return fmt.Errorf("peer responded to a Follow but provided a non-Follow id")
+	}
+	follow, ok := t.(Activity)
+	if !ok {
+		return fmt.Errorf("a Follow does not satisfy the Activity interface")
+	}
+	// Ensure that we are one of the actors on the Follow.
+	ok = false
+	actors := follow.GetActivityStreamsActor()
+	if actors != nil {
+		for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return err
+			}
+			if id.String() == actorIRI.String() {
+				ok = true
+				break
+			}
+		}
+	}
+	if !ok {
+		return fmt.Errorf("peer responded to a Follow but we are not the actor on that Follow")
+	}
+	// Build map of the peer actors
+	found := make(map[string]bool)
+	for iter := peerActors.Begin(); iter != peerActors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		found[id.String()] = false
+	}
+	// Verify all actor(s) were on the original Follow.
+	followObj := follow.GetActivityStreamsObject()
+	if followObj != nil {
+		for iter := followObj.Begin(); iter != followObj.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return err
+			}
+			if _, ok := found[id.String()]; ok {
+				found[id.String()] = true
+			}
+		}
+	}
+	for _, ok := range found {
+		if !ok {
+			return fmt.Errorf("peer responded to a Follow but was not an object in the original Follow")
+		}
+	}
+	return nil
+}
+
 // add implements the federating Add activity side effects.
 func (w FederatingWrappedCallbacks) add(c context.Context, a vocab.ActivityStreamsAdd) error {
 	op := a.GetActivityStreamsObject()
@@ -683,8 +1298,10 @@ func (w FederatingWrappedCallbacks) add(c context.Context, a vocab.ActivityStrea
 	if target == nil || target.Len() == 0 {
 		return ErrTargetRequired
 	}
-	if err := add(c, op, target, w.db); err != nil {
-		return err
+	if !w.recipientOnly {
+		if err := add(c, op, target, w.db); err != nil {
+			return err
+		}
 	}
 	if w.Add != nil {
 		return w.Add(c, a)
@@ -702,8 +1319,10 @@ func (w FederatingWrappedCallbacks) remove(c context.Context, a vocab.ActivitySt
 	if target == nil || target.Len() == 0 {
 		return ErrTargetRequired
 	}
-	if err := remove(c, op, target, w.db); err != nil {
-		return err
+	if !w.recipientOnly {
+		if err := remove(c, op, target, w.db); err != nil {
+			return err
+		}
 	}
 	if w.Remove != nil {
 		return w.Remove(c, a)
@@ -784,7 +1403,7 @@ func (w FederatingWrappedCallbacks) like(c context.Context, a vocab.ActivityStre
 		}
 		return nil
 	}
-	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+	for iter := op.Begin(); iter != op.End() && !w.recipientOnly; iter = iter.Next() {
 		if err := loopFn(iter); err != nil {
 			return err
 		}
@@ -865,7 +1484,7 @@ func (w FederatingWrappedCallbacks) announce(c context.Context, a vocab.Activity
 		}
 		return nil
 	}
-	if op != nil {
+	if op != nil && !w.recipientOnly {
 		for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
 			if err := loopFn(iter); err != nil {
 				return err
@@ -885,23 +1504,747 @@ func (w FederatingWrappedCallbacks) undo(c context.Context, a vocab.ActivityStre
 		return ErrObjectRequired
 	}
 	actors := a.GetActivityStreamsActor()
-	if err := mustHaveActivityActorsMatchObjectActors(c, actors, op, w.newTransport, w.inboxIRI); err != nil {
+	objects, err := mustHaveActivityActorsMatchObjectActors(c, actors, op, w.newTransport, w.inboxIRI)
+	if err != nil {
 		return err
 	}
+	for _, t := range objects {
+		if streams.IsOrExtendsActivityStreamsFollow(t) {
+			err = w.undoFollow(c, t)
+		} else if w.recipientOnly {
+			// The remaining side effects do not depend on the
+			// recipient, and were already applied.
+			continue
+		} else if streams.IsOrExtendsActivityStreamsLike(t) {
+			err = w.removeFromObjectCollections(c, t, getLikes)
+		} else if streams.IsOrExtendsActivityStreamsAnnounce(t) {
+			err = w.removeFromObjectCollections(c, t, getShares)
+		} else if streams.IsOrExtendsActivityStreamsCreate(t) && w.MaintainReplies {
+			err = w.undoCreate(c, t)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	if w.Undo != nil {
 		return w.Undo(c, a)
 	}
 	return nil
 }
 
-// block implements the federating Block activity side effects.
-func (w FederatingWrappedCallbacks) block(c context.Context, a vocab.ActivityStreamsBlock) error {
-	op := a.GetActivityStreamsObject()
-	if op == nil || op.Len() == 0 {
-		return ErrObjectRequired
+// undoFollow removes the actors of a Follow from the 'followers' collection of
+// the actor owning this inbox, if they were following it. When follow requests
+// are manually approved, the Follow is also removed from the pending follow
+// requests.
+func (w FederatingWrappedCallbacks) undoFollow(c context.Context, follow vocab.Type) error {
+	o, ok := follow.(objecter)
+	if !ok {
+		return fmt.Errorf("cannot undo Follow: no 'object' property on %T", follow)
 	}
-	if w.Block != nil {
-		return w.Block(c, a)
+	ac, ok := follow.(actorer)
+	if !ok {
+		return fmt.Errorf("cannot undo Follow: no 'actor' property on %T", follow)
 	}
-	return nil
+	if err := w.db.Lock(c, w.inboxIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	actorIRI, err := w.db.ActorForInbox(c, w.inboxIRI)
+	if err != nil {
+		w.db.Unlock(c, w.inboxIRI)
+		return err
+	}
+	w.db.Unlock(c, w.inboxIRI)
+	// Unlock must be called by now and every branch above.
+	isMe := false
+	op := o.GetActivityStreamsObject()
+	if op != nil {
+		for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return err
+			}
+			if id.String() == actorIRI.String() {
+				isMe = true
+				break
+			}
+		}
+	}
+	if !isMe {
+		return nil
+	}
+	followActors := ac.GetActivityStreamsActor()
+	if followActors == nil {
+		return nil
+	}
+	unfollowed := make(map[string]bool, followActors.Len())
+	for iter := followActors.Begin(); iter != followActors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		unfollowed[id.String()] = true
+	}
+	if err := w.db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, actorIRI)
+	followers, err := w.db.Followers(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	if err = removeFromCollection(followers, unfollowed); err != nil {
+		return err
+	}
+	if err = w.db.Update(c, followers); err != nil {
+		return err
+	}
+	if w.OnFollow != OnFollowManuallyApprove {
+		return nil
+	}
+	followIRI, err := GetId(follow)
+	if err != nil {
+		return err
+	}
+	requests, err := w.followRequests(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	if err = removeFromCollection(requests, map[string]bool{followIRI.String(): true}); err != nil {
+		return err
+	}
+	return w.db.Update(c, requests)
+}
+
+// removeFromObjectCollections removes the activity from a collection, such as
+// 'likes' or 'shares', on all 'object' targets owned by this server.
+//
+// The getCollection function obtains the collection of an object, returning
+// nil if the object does not have one.
+func (w FederatingWrappedCallbacks) removeFromObjectCollections(c context.Context, activity vocab.Type, getCollection func(t vocab.Type) vocab.Type) error {
+	o, ok := activity.(objecter)
+	if !ok {
+		return fmt.Errorf("cannot undo %T: no 'object' property", activity)
+	}
+	op := o.GetActivityStreamsObject()
+	if op == nil {
+		return nil
+	}
+	id, err := GetId(activity)
+	if err != nil {
+		return err
+	}
+	ids := map[string]bool{id.String(): true}
+	// Create anonymous loop function to be able to properly scope the defer
+	// for the database lock at each iteration.
+	loopFn := func(iter vocab.ActivityStreamsObjectPropertyIterator) error {
+		objId, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		if err := w.db.Lock(c, objId); err != nil {
+			return err
+		}
+		defer w.db.Unlock(c, objId)
+		if owns, err := w.db.Owns(c, objId); err != nil {
+			return err
+		} else if !owns {
+			return nil
+		}
+		t, err := w.db.Get(c, objId)
+		if err != nil {
+			return err
+		}
+		col := getCollection(t)
+		if col == nil {
+			return nil
+		}
+		if err = removeFromCollection(col, ids); err != nil {
+			return err
+		}
+		return w.db.Update(c, t)
+	}
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		if err := loopFn(iter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getLikes returns the 'likes' collection embedded in the value, or nil if
+// there is none.
+func getLikes(t vocab.Type) vocab.Type {
+	if l, ok := t.(likeser); ok {
+		if likes := l.GetActivityStreamsLikes(); likes != nil {
+			return likes.GetType()
+		}
+	}
+	return nil
+}
+
+// getShares returns the 'shares' collection embedded in the value, or nil if
+// there is none.
+func getShares(t vocab.Type) vocab.Type {
+	if s, ok := t.(shareser); ok {
+		if shares := s.GetActivityStreamsShares(); shares != nil {
+			return shares.GetType()
+		}
+	}
+	return nil
+}
+
+// addReply adds the reply to the 'replies' collection of each object it is
+// 'inReplyTo' that is owned by this server.
+func (w FederatingWrappedCallbacks) addReply(c context.Context, reply vocab.Type) error {
+	id, err := GetId(reply)
+	if err != nil {
+		return err
+	}
+	parents, err := inReplyToIds(reply)
+	if err != nil {
+		return err
+	}
+	_, _, _, isVote := voteFor(reply)
+	ids := map[string]bool{id.String(): true}
+	for _, parent := range parents {
+		err = w.updateOwned(c, parent, func(t vocab.Type) (bool, error) {
+			if isVote && streams.IsOrExtendsActivityStreamsQuestion(t) {
+				// Votes are counted in the options instead.
+				return false, nil
+			}
+			replies, err := getOrCreateReplies(t)
+			if err != nil {
+				return false, err
+			}
+			// Remove the reply first, so a redelivered Create does
+			// not add it twice.
+			if err = removeFromCollection(replies, ids); err != nil {
+				return false, err
+			}
+			if err = prependToCollection(replies, id); err != nil {
+				return false, err
+			}
+			return true, setTotalItems(replies)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeReply removes the stored object with the given id from the 'replies'
+// collection of each object it is 'inReplyTo' that is owned by this server.
+func (w FederatingWrappedCallbacks) removeReply(c context.Context, id *url.URL) error {
+	if err := w.db.Lock(c, id); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	exists, err := w.db.Exists(c, id)
+	if err != nil || !exists {
+		w.db.Unlock(c, id)
+		return err
+	}
+	reply, err := w.db.Get(c, id)
+	w.db.Unlock(c, id)
+	// Unlock must be called by now and every branch above.
+	if err != nil {
+		return err
+	}
+	parents, err := inReplyToIds(reply)
+	if err != nil {
+		return err
+	}
+	ids := map[string]bool{id.String(): true}
+	for _, parent := range parents {
+		err = w.updateOwned(c, parent, func(t vocab.Type) (bool, error) {
+			replies := getReplies(t)
+			if replies == nil {
+				return false, nil
+			}
+			if err := removeFromCollection(replies, ids); err != nil {
+				return false, err
+			}
+			return true, setTotalItems(replies)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// undoCreate removes the objects of the undone Create from the 'replies'
+// collection of the objects they are 'inReplyTo'.
+func (w FederatingWrappedCallbacks) undoCreate(c context.Context, create vocab.Type) error {
+	a, ok := create.(Activity)
+	if !ok {
+		return fmt.Errorf("cannot undo Create: %T is not an Activity", create)
+	}
+	if err := mustHaveActivityOriginMatchObjects(a); err != nil {
+		return err
+	}
+	op := a.GetActivityStreamsObject()
+	if op == nil {
+		return nil
+	}
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		if err = w.removeReply(c, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateOwned calls modify with the object with the given id if it is owned by
+// this server, and updates it in the database if modify reports a change.
+func (w FederatingWrappedCallbacks) updateOwned(c context.Context, id *url.URL, modify func(t vocab.Type) (changed bool, err error)) error {
+	if err := w.db.Lock(c, id); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, id)
+	if owns, err := w.db.Owns(c, id); err != nil {
+		return err
+	} else if !owns {
+		return nil
+	}
+	t, err := w.db.Get(c, id)
+	if err != nil {
+		return err
+	}
+	if changed, err := modify(t); err != nil || !changed {
+		return err
+	}
+	return w.db.Update(c, t)
+}
+
+// inReplyToIds returns the ids of the objects the value is 'inReplyTo'.
+func inReplyToIds(t vocab.Type) ([]*url.URL, error) {
+	irt, ok := t.(inReplyToer)
+	if !ok {
+		return nil, nil
+	}
+	irtProp := irt.GetActivityStreamsInReplyTo()
+	if irtProp == nil {
+		return nil, nil
+	}
+	ids := make([]*url.URL, 0, irtProp.Len())
+	for iter := irtProp.Begin(); iter != irtProp.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// getReplies returns the 'replies' collection embedded in the value, or nil if
+// there is none.
+func getReplies(t vocab.Type) vocab.Type {
+	if r, ok := t.(replieser); ok {
+		if replies := r.GetActivityStreamsReplies(); replies != nil {
+			return replies.GetType()
+		}
+	}
+	return nil
+}
+
+// getOrCreateReplies returns the 'replies' collection embedded in the value,
+// defaulting to a new Collection if there is none.
+func getOrCreateReplies(t vocab.Type) (vocab.Type, error) {
+	r, ok := t.(replieser)
+	if !ok {
+		return nil, fmt.Errorf("cannot add reply to replies collection for type %T", t)
+	}
+	replies := r.GetActivityStreamsReplies()
+	if replies == nil {
+		replies = streams.NewActivityStreamsRepliesProperty()
+		r.SetActivityStreamsReplies(replies)
+	}
+	repliesT := replies.GetType()
+	if repliesT == nil {
+		col := streams.NewActivityStreamsCollection()
+		repliesT = col
+		replies.SetActivityStreamsCollection(col)
+	}
+	return repliesT, nil
+}
+
+// block implements the federating Block activity side effects.
+func (w FederatingWrappedCallbacks) block(c context.Context, a vocab.ActivityStreamsBlock) error {
+	op := a.GetActivityStreamsObject()
+	if op == nil || op.Len() == 0 {
+		return ErrObjectRequired
+	}
+	if w.Block != nil {
+		return w.Block(c, a)
+	}
+	return nil
+}
+
+// move implements the federating Move activity side effects.
+func (w FederatingWrappedCallbacks) move(c context.Context, a vocab.ActivityStreamsMove) error {
+	op := a.GetActivityStreamsObject()
+	if op == nil || op.Len() == 0 {
+		return ErrObjectRequired
+	}
+	tp := a.GetActivityStreamsTarget()
+	if tp == nil || tp.Len() == 0 {
+		return ErrTargetRequired
+	} else if tp.Len() > 1 {
+		return fmt.Errorf("cannot move to %d targets", tp.Len())
+	}
+	target, err := ToId(tp.At(0))
+	if err != nil {
+		return err
+	}
+	// Ensure the actors being moved are the ones moving.
+	origins, err := objectIds(a)
+	if err != nil {
+		return err
+	}
+	actors := make(map[string]bool)
+	if ap := a.GetActivityStreamsActor(); ap != nil {
+		for iter := ap.Begin(); iter != ap.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return err
+			}
+			actors[id.String()] = true
+		}
+	}
+	moved := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		if !actors[origin.String()] {
+			return fmt.Errorf("cannot move %s: not an actor of the Move", origin)
+		}
+		moved[origin.String()] = true
+	}
+	// Only migrate if this actor is following one of the moved actors.
+	actorIRI, err := w.actorForInbox(c)
+	if err != nil {
+		return err
+	}
+	following, err := w.isFollowing(c, actorIRI, moved)
+	if err != nil {
+		return err
+	}
+	if following {
+		if err = w.verifyMoveTarget(c, target, origins); err != nil {
+			return err
+		}
+		if err = w.unfollow(c, actorIRI, moved); err != nil {
+			return err
+		}
+		if err = w.followMovedActor(c, actorIRI, target); err != nil {
+			return err
+		}
+	}
+	if w.Move != nil {
+		return w.Move(c, a)
+	}
+	return nil
+}
+
+// isFollowing determines whether any of the ids are in the 'following'
+// collection of the actor.
+func (w FederatingWrappedCallbacks) isFollowing(c context.Context, actorIRI *url.URL, ids map[string]bool) (bool, error) {
+	if err := w.db.Lock(c, actorIRI); err != nil {
+		return false, err
+	}
+	defer w.db.Unlock(c, actorIRI)
+	following, err := w.db.Following(c, actorIRI)
+	if err != nil {
+		return false, err
+	}
+	items := following.GetActivityStreamsItems()
+	if items == nil {
+		return false, nil
+	}
+	for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return false, err
+		}
+		if ids[id.String()] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// verifyMoveTarget dereferences the target of a Move, and ensures it lists all
+// of the origins in its 'alsoKnownAs' property. Otherwise, anyone could move
+// the followers of an actor to an account they control.
+func (w FederatingWrappedCallbacks) verifyMoveTarget(c context.Context, target *url.URL, origins []*url.URL) error {
+	tport, err := w.newTransport(c, w.inboxIRI, goFedUserAgent())
+	if err != nil {
+		return err
+	}
+	b, err := tport.Dereference(c, target)
+	if err != nil {
+		return err
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	t, err := streams.ToType(c, m)
+	if err != nil {
+		return err
+	}
+	id, err := GetId(t)
+	if err != nil {
+		return err
+	} else if id.String() != target.String() {
+		return fmt.Errorf("move target %s dereferenced to %s", target, id)
+	}
+	aka := make(map[string]bool)
+	if akaer, ok := t.(alsoKnownAser); ok {
+		if akap := akaer.GetActivityStreamsAlsoKnownAs(); akap != nil {
+			for iter := akap.Begin(); iter != akap.End(); iter = iter.Next() {
+				id, err := ToId(iter)
+				if err != nil {
+					return err
+				}
+				aka[id.String()] = true
+			}
+		}
+	}
+	for _, origin := range origins {
+		if !aka[origin.String()] {
+			return fmt.Errorf("move target %s is not also known as %s", target, origin)
+		}
+	}
+	return nil
+}
+
+// unfollow removes the ids from the 'following' collection of the actor.
+func (w FederatingWrappedCallbacks) unfollow(c context.Context, actorIRI *url.URL, ids map[string]bool) error {
+	if err := w.db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, actorIRI)
+	following, err := w.db.Following(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	if err = removeFromCollection(following, ids); err != nil {
+		return err
+	}
+	return w.db.Update(c, following)
+}
+
+// followMovedActor sends a Follow of the target of a Move from the actor
+// owning this inbox. The Follow is saved so that the Accept in response can be
+// verified.
+func (w FederatingWrappedCallbacks) followMovedActor(c context.Context, actorIRI, target *url.URL) error {
+	follow := streams.NewActivityStreamsFollow()
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendIRI(actorIRI)
+	follow.SetActivityStreamsActor(actor)
+	op := streams.NewActivityStreamsObjectProperty()
+	op.AppendIRI(target)
+	follow.SetActivityStreamsObject(op)
+	to := streams.NewActivityStreamsToProperty()
+	to.AppendIRI(target)
+	follow.SetActivityStreamsTo(to)
+	if err := w.addNewIds(c, follow); err != nil {
+		return err
+	}
+	id, err := GetId(follow)
+	if err != nil {
+		return err
+	}
+	if err = w.createIfNotExists(c, id, follow); err != nil {
+		return err
+	}
+	if err = w.db.Lock(c, w.inboxIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	outboxIRI, err := w.db.OutboxForInbox(c, w.inboxIRI)
+	if err != nil {
+		w.db.Unlock(c, w.inboxIRI)
+		return err
+	}
+	w.db.Unlock(c, w.inboxIRI)
+	// Unlock must be called by now and every branch above.
+	return w.deliver(c, outboxIRI, follow)
+}
+
+// flag implements the federating Flag activity side effects.
+func (w FederatingWrappedCallbacks) flag(c context.Context, a vocab.ActivityStreamsFlag) error {
+	op := a.GetActivityStreamsObject()
+	if op == nil || op.Len() == 0 {
+		return ErrObjectRequired
+	}
+	id, err := GetId(a)
+	if err != nil {
+		return err
+	}
+	objIds, err := objectIds(a)
+	if err != nil {
+		return err
+	}
+	report := Report{
+		Id:       id,
+		InboxIRI: w.inboxIRI,
+		Flag:     a,
+	}
+	// Create anonymous loop function to be able to properly scope the defer
+	// for the database lock at each iteration.
+	loopFn := func(objId *url.URL) error {
+		if err := w.db.Lock(c, objId); err != nil {
+			return err
+		}
+		defer w.db.Unlock(c, objId)
+		owns, err := w.db.Owns(c, objId)
+		if err != nil {
+			return err
+		}
+		if owns {
+			report.Local = append(report.Local, objId)
+		} else {
+			report.Remote = append(report.Remote, objId)
+		}
+		return nil
+	}
+	for _, objId := range objIds {
+		if err := loopFn(objId); err != nil {
+			return err
+		}
+	}
+	if q := w.getModerationQueue(c); q != nil && len(report.Local) > 0 && !w.recipientOnly {
+		if err := q.AddReport(c, report); err != nil {
+			return err
+		}
+	}
+	if w.Flag != nil {
+		return w.Flag(c, a)
+	}
+	return nil
+}
+
+// getVoteStore returns the VoteStore, or nil if votes are not counted.
+func (w FederatingWrappedCallbacks) getVoteStore(c context.Context) VoteStore {
+	if w.voteStore == nil {
+		return nil
+	}
+	return w.voteStore(c)
+}
+
+// getModerationQueue returns the ModerationQueue, or nil if Flags are not
+// queued.
+func (w FederatingWrappedCallbacks) getModerationQueue(c context.Context) ModerationQueue {
+	if w.moderationQueue == nil {
+		return nil
+	}
+	return w.moderationQueue(c)
+}
+
+// forwardReport sends a Flag of the remote objects of a queued Report to the
+// actors owning them, on behalf of the actor owning this inbox. The original
+// reporter is not revealed.
+func (w FederatingWrappedCallbacks) forwardReport(c context.Context, reportIRI *url.URL) error {
+	q := w.getModerationQueue(c)
+	if q == nil {
+		return fmt.Errorf("cannot forward report %s: no ModerationQueue", reportIRI)
+	}
+	report, err := q.Report(c, reportIRI)
+	if err != nil {
+		return err
+	} else if report == nil {
+		return fmt.Errorf("no report %s in the ModerationQueue", reportIRI)
+	} else if len(report.Remote) == 0 {
+		return fmt.Errorf("report %s has no objects on other servers", reportIRI)
+	}
+	owners, err := w.reportedOwners(c, report.Remote)
+	if err != nil {
+		return err
+	}
+	actorIRI, err := w.actorForInbox(c)
+	if err != nil {
+		return err
+	}
+	flag := streams.NewActivityStreamsFlag()
+	actor := streams.NewActivityStreamsActorProperty()
+	actor.AppendIRI(actorIRI)
+	flag.SetActivityStreamsActor(actor)
+	op := streams.NewActivityStreamsObjectProperty()
+	to := streams.NewActivityStreamsToProperty()
+	for _, objId := range report.Remote {
+		op.AppendIRI(objId)
+	}
+	for _, owner := range owners {
+		to.AppendIRI(owner)
+	}
+	flag.SetActivityStreamsObject(op)
+	flag.SetActivityStreamsTo(to)
+	if report.Flag != nil {
+		flag.SetActivityStreamsContent(report.Flag.GetActivityStreamsContent())
+	}
+	if err = w.addNewIds(c, flag); err != nil {
+		return err
+	}
+	if err = w.db.Lock(c, w.inboxIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	outboxIRI, err := w.db.OutboxForInbox(c, w.inboxIRI)
+	if err != nil {
+		w.db.Unlock(c, w.inboxIRI)
+		return err
+	}
+	w.db.Unlock(c, w.inboxIRI)
+	// Unlock must be called by now and every branch above.
+	return w.deliver(c, outboxIRI, flag)
+}
+
+// reportedOwners determines the actors to address a forwarded report to by
+// dereferencing the reported objects. A reported actor is addressed itself,
+// and any other object is addressed to its 'attributedTo' actors.
+func (w FederatingWrappedCallbacks) reportedOwners(c context.Context, objIds []*url.URL) ([]*url.URL, error) {
+	tport, err := w.newTransport(c, w.inboxIRI, goFedUserAgent())
+	if err != nil {
+		return nil, err
+	}
+	var owners []*url.URL
+	for _, objId := range objIds {
+		b, err := tport.Dereference(c, objId)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]interface{}
+		if err = json.Unmarshal(b, &m); err != nil {
+			return nil, err
+		}
+		t, err := streams.ToType(c, m)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := t.(inboxer); ok {
+			owners = append(owners, objId)
+			continue
+		}
+		at, ok := t.(attributedToer)
+		if !ok || at.GetActivityStreamsAttributedTo() == nil || at.GetActivityStreamsAttributedTo().Len() == 0 {
+			return nil, fmt.Errorf("cannot determine the owner of reported object %s", objId)
+		}
+		atProp := at.GetActivityStreamsAttributedTo()
+		for iter := atProp.Begin(); iter != atProp.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return nil, err
+			}
+			owners = append(owners, id)
+		}
+	}
+	return dedupeIRIs(owners, nil), nil
 }
@@ -4,12 +4,20 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/golang/mock/gomock"
 )
 
+// followRequestsDatabase is a Database that also implements the optional
+// FollowRequestsDatabase.
+type followRequestsDatabase struct {
+	*MockDatabase
+	*MockFollowRequestsDatabase
+}
+
 // TestFederatedCallbacks tests the overriding functionality.
 func TestFederatedCallbacks(t *testing.T) {
 	t.Run("ReturnsOtherCallback", func(t *testing.T) {
@@ -337,6 +345,363 @@ func TestFederatedCreate(t *testing.T) {
 	})
 }
 
+func TestFederatedCreateVote(t *testing.T) {
+	const questionIRI = "https://example.com/question/1"
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	newOptionFn := func(name string, votes int) vocab.ActivityStreamsNote {
+		n := streams.NewActivityStreamsNote()
+		np := streams.NewActivityStreamsNameProperty()
+		np.AppendXMLSchemaString(name)
+		n.SetActivityStreamsName(np)
+		col := streams.NewActivityStreamsCollection()
+		totalItems := streams.NewActivityStreamsTotalItemsProperty()
+		totalItems.Set(votes)
+		col.SetActivityStreamsTotalItems(totalItems)
+		replies := streams.NewActivityStreamsRepliesProperty()
+		replies.SetActivityStreamsCollection(col)
+		n.SetActivityStreamsReplies(replies)
+		return n
+	}
+	newQuestionFn := func(yesVotes int) vocab.ActivityStreamsQuestion {
+		q := streams.NewActivityStreamsQuestion()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(questionIRI))
+		q.SetJSONLDId(id)
+		at := streams.NewActivityStreamsAttributedToProperty()
+		at.AppendIRI(mustParse(testFederatedActorIRI2))
+		q.SetActivityStreamsAttributedTo(at)
+		oneOf := streams.NewActivityStreamsOneOfProperty()
+		oneOf.AppendActivityStreamsNote(newOptionFn("yes", yesVotes))
+		oneOf.AppendActivityStreamsNote(newOptionFn("no", 0))
+		q.SetActivityStreamsOneOf(oneOf)
+		endTime := streams.NewActivityStreamsEndTimeProperty()
+		endTime.Set(now.Add(time.Hour))
+		q.SetActivityStreamsEndTime(endTime)
+		return q
+	}
+	newVoteFn := func(option string) vocab.ActivityStreamsCreate {
+		n := streams.NewActivityStreamsNote()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNoteId2))
+		n.SetJSONLDId(id)
+		np := streams.NewActivityStreamsNameProperty()
+		np.AppendXMLSchemaString(option)
+		n.SetActivityStreamsName(np)
+		irt := streams.NewActivityStreamsInReplyToProperty()
+		irt.AppendIRI(mustParse(questionIRI))
+		n.SetActivityStreamsInReplyTo(irt)
+		at := streams.NewActivityStreamsAttributedToProperty()
+		at.AppendIRI(mustParse(testFederatedActorIRI))
+		n.SetActivityStreamsAttributedTo(at)
+		c := streams.NewActivityStreamsCreate()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		c.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsNote(n)
+		c.SetActivityStreamsObject(op)
+		return c
+	}
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase, store *MemoryVoteStore) {
+		mockDB = NewMockDatabase(ctl)
+		mockClock := NewMockClock(ctl)
+		mockClock.EXPECT().Now().Return(now).AnyTimes()
+		store = NewMemoryVoteStore()
+		w.db = mockDB
+		w.clock = mockClock
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.voteStore = func(c context.Context) VoteStore {
+			return store
+		}
+		return
+	}
+	expectQuestionFn := func(mockDB *MockDatabase, q vocab.ActivityStreamsQuestion) {
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(questionIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(questionIRI)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(questionIRI)).Return(q, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(questionIRI))
+	}
+	expectCreateFn := func(mockDB *MockDatabase) {
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Create(ctx, gomock.Any())
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+	}
+	t.Run("CountsVoteAndSendsUpdateToVoters", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, store := setupFn(ctl)
+		var delivered Activity
+		w.addNewIds = func(c context.Context, activity Activity) error {
+			return nil
+		}
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			delivered = activity
+			return nil
+		}
+		err := store.AddVote(ctx, mustParse(questionIRI), Vote{
+			Voter:  mustParse(testFederatedActorIRI3),
+			Option: "yes",
+		})
+		assertEqual(t, err, nil)
+		expectQuestion := newQuestionFn(2)
+		votersCount := streams.NewTootVotersCountProperty()
+		votersCount.Set(2)
+		expectQuestion.SetTootVotersCount(votersCount)
+		expectQuestionFn(mockDB, newQuestionFn(1))
+		mockDB.EXPECT().Update(ctx, expectQuestion)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().OutboxForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testMyOutboxIRI), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		expectCreateFn(mockDB)
+		err = w.create(ctx, newVoteFn("yes"))
+		assertEqual(t, err, nil)
+		votes, err := store.Votes(ctx, mustParse(questionIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(votes), 2)
+		assertEqual(t, votes[1].Voter.String(), testFederatedActorIRI)
+		assertEqual(t, votes[1].Option, "yes")
+		update, ok := delivered.(vocab.ActivityStreamsUpdate)
+		if !ok {
+			t.Fatalf("expected Update, got %T", delivered)
+		}
+		assertEqual(t, update.GetActivityStreamsTo().Len(), 2)
+		shared := update.GetActivityStreamsObject().At(0).GetActivityStreamsQuestion()
+		yes := shared.GetActivityStreamsOneOf().At(0).GetActivityStreamsNote()
+		col := yes.GetActivityStreamsReplies().GetActivityStreamsCollection()
+		assertEqual(t, col.GetActivityStreamsTotalItems().Get(), 2)
+		assertEqual(t, col.GetActivityStreamsItems() == nil, true)
+	})
+	t.Run("IgnoresSecondVoteInOneOfPoll", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, store := setupFn(ctl)
+		err := store.AddVote(ctx, mustParse(questionIRI), Vote{
+			Voter:  mustParse(testFederatedActorIRI),
+			Option: "no",
+		})
+		assertEqual(t, err, nil)
+		expectQuestionFn(mockDB, newQuestionFn(0))
+		expectCreateFn(mockDB)
+		err = w.create(ctx, newVoteFn("yes"))
+		assertEqual(t, err, nil)
+		votes, err := store.Votes(ctx, mustParse(questionIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(votes), 1)
+	})
+	t.Run("RejectsVoteAfterEndTime", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		q := newQuestionFn(0)
+		q.GetActivityStreamsEndTime().Set(now.Add(-time.Hour))
+		expectQuestionFn(mockDB, q)
+		err := w.create(ctx, newVoteFn("yes"))
+		assertEqual(t, err, ErrPollClosed)
+	})
+	t.Run("RejectsVoteInClosedPoll", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		q := newQuestionFn(0)
+		closed := streams.NewActivityStreamsClosedProperty()
+		closed.AppendXMLSchemaBoolean(true)
+		q.SetActivityStreamsClosed(closed)
+		expectQuestionFn(mockDB, q)
+		err := w.create(ctx, newVoteFn("yes"))
+		assertEqual(t, err, ErrPollClosed)
+	})
+	t.Run("RejectsVoteForUnknownOption", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		expectQuestionFn(mockDB, newQuestionFn(0))
+		err := w.create(ctx, newVoteFn("maybe"))
+		assertEqual(t, err, ErrInvalidVote)
+	})
+	t.Run("RejectsVoteNotSentByVoter", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, store := setupFn(ctl)
+		expectQuestionFn(mockDB, newQuestionFn(0))
+		c := newVoteFn("yes")
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI3))
+		c.SetActivityStreamsActor(actor)
+		err := w.create(ctx, c)
+		assertEqual(t, err, ErrInvalidVote)
+		votes, err := store.Votes(ctx, mustParse(questionIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(votes), 0)
+	})
+	t.Run("DoesNotCountVoteWithoutVoteStore", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		w.voteStore = nil
+		expectCreateFn(mockDB)
+		err := w.create(ctx, newVoteFn("yes"))
+		assertEqual(t, err, nil)
+	})
+}
+
+func TestFederatedReplies(t *testing.T) {
+	ctx := context.Background()
+	newNoteFn := func(id string, inReplyTo string) vocab.ActivityStreamsNote {
+		n := streams.NewActivityStreamsNote()
+		idProp := streams.NewJSONLDIdProperty()
+		idProp.Set(mustParse(id))
+		n.SetJSONLDId(idProp)
+		if len(inReplyTo) > 0 {
+			irt := streams.NewActivityStreamsInReplyToProperty()
+			irt.AppendIRI(mustParse(inReplyTo))
+			n.SetActivityStreamsInReplyTo(irt)
+		}
+		return n
+	}
+	withRepliesFn := func(n vocab.ActivityStreamsNote, ids ...string) vocab.ActivityStreamsNote {
+		col := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		for _, id := range ids {
+			items.AppendIRI(mustParse(id))
+		}
+		col.SetActivityStreamsItems(items)
+		totalItems := streams.NewActivityStreamsTotalItemsProperty()
+		totalItems.Set(len(ids))
+		col.SetActivityStreamsTotalItems(totalItems)
+		replies := streams.NewActivityStreamsRepliesProperty()
+		replies.SetActivityStreamsCollection(col)
+		n.SetActivityStreamsReplies(replies)
+		return n
+	}
+	newCreateFn := func(obj vocab.ActivityStreamsNote) vocab.ActivityStreamsCreate {
+		c := streams.NewActivityStreamsCreate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		c.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		c.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsNote(obj)
+		c.SetActivityStreamsObject(op)
+		return c
+	}
+	newDeleteFn := func() vocab.ActivityStreamsDelete {
+		d := streams.NewActivityStreamsDelete()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		d.SetJSONLDId(id)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId2))
+		d.SetActivityStreamsObject(op)
+		return d
+	}
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase) {
+		mockDB = NewMockDatabase(ctl)
+		w.db = mockDB
+		w.MaintainReplies = true
+		return
+	}
+	t.Run("AddsReplyToOwnedParent", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		reply := newNoteFn(testNoteId2, testNoteId1)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Create(ctx, reply)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(
+			withRepliesFn(newNoteFn(testNoteId1, ""), testFederatedActivityIRI2), nil)
+		mockDB.EXPECT().Update(ctx, withRepliesFn(newNoteFn(testNoteId1, ""), testNoteId2, testFederatedActivityIRI2))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		err := w.create(ctx, newCreateFn(reply))
+		assertEqual(t, err, nil)
+	})
+	t.Run("CreatesRepliesCollection", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		reply := newNoteFn(testNoteId2, testNoteId1)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Create(ctx, reply)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(newNoteFn(testNoteId1, ""), nil)
+		mockDB.EXPECT().Update(ctx, withRepliesFn(newNoteFn(testNoteId1, ""), testNoteId2))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		err := w.create(ctx, newCreateFn(reply))
+		assertEqual(t, err, nil)
+	})
+	t.Run("IgnoresParentNotOwned", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		reply := newNoteFn(testNoteId2, testNoteId1)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Create(ctx, reply)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		err := w.create(ctx, newCreateFn(reply))
+		assertEqual(t, err, nil)
+	})
+	t.Run("DoesNothingIfDisabled", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		w.MaintainReplies = false
+		reply := newNoteFn(testNoteId2, testNoteId1)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Create(ctx, reply)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		err := w.create(ctx, newCreateFn(reply))
+		assertEqual(t, err, nil)
+	})
+	t.Run("RemovesReplyOnDelete", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Exists(ctx, mustParse(testNoteId2)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNoteId2)).Return(newNoteFn(testNoteId2, testNoteId1), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(
+			withRepliesFn(newNoteFn(testNoteId1, ""), testNoteId2, testFederatedActivityIRI2), nil)
+		mockDB.EXPECT().Update(ctx, withRepliesFn(newNoteFn(testNoteId1, ""), testFederatedActivityIRI2))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Delete(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		err := w.deleteFn(ctx, newDeleteFn())
+		assertEqual(t, err, nil)
+	})
+	t.Run("DeletesUnknownReply", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Exists(ctx, mustParse(testNoteId2)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Delete(ctx, mustParse(testNoteId2))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId2))
+		err := w.deleteFn(ctx, newDeleteFn())
+		assertEqual(t, err, nil)
+	})
+}
+
 func TestFederatedUpdate(t *testing.T) {
 	newUpdateFn := func() vocab.ActivityStreamsUpdate {
 		u := streams.NewActivityStreamsUpdate()
@@ -705,6 +1070,41 @@ func TestFederatedFollow(t *testing.T) {
 			t.Fatalf("got error %s", err)
 		}
 	})
+	t.Run("OnFollowManuallyApproveStoresRequest", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockRequests := NewMockFollowRequestsDatabase(ctl)
+		w.db = followRequestsDatabase{mockDB, mockRequests}
+		w.OnFollow = OnFollowManuallyApprove
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			t.Fatalf("expected no delivery, got %T", activity)
+			return nil
+		}
+		f := newFollowFn()
+		requests := streams.NewActivityStreamsCollection()
+		expectRequests := streams.NewActivityStreamsCollection()
+		expectItems := streams.NewActivityStreamsItemsProperty()
+		expectItems.AppendIRI(mustParse(testNewActivityIRI))
+		expectRequests.SetActivityStreamsItems(expectItems)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNewActivityIRI))
+		mockDB.EXPECT().Exists(ctx, mustParse(testNewActivityIRI)).Return(false, nil)
+		mockDB.EXPECT().Create(ctx, f)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockRequests.EXPECT().FollowRequests(ctx, mustParse(testFederatedActorIRI2)).Return(
+			requests, nil)
+		mockDB.EXPECT().Update(ctx, expectRequests)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		err := w.follow(ctx, f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
 	t.Run("CallsCustomCallback", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -731,86 +1131,255 @@ func TestFederatedFollow(t *testing.T) {
 	})
 }
 
-func TestFederatedAccept(t *testing.T) {
-	newAcceptFn := func() vocab.ActivityStreamsAccept {
-		c := streams.NewActivityStreamsAccept()
+func TestFederatedResolveFollowRequest(t *testing.T) {
+	newFollowFn := func() vocab.ActivityStreamsFollow {
+		f := streams.NewActivityStreamsFollow()
 		id := streams.NewJSONLDIdProperty()
-		id.Set(mustParse(testFederatedActivityIRI2))
-		c.SetJSONLDId(id)
+		id.Set(mustParse(testNewActivityIRI))
+		f.SetJSONLDId(id)
 		actor := streams.NewActivityStreamsActorProperty()
 		actor.AppendIRI(mustParse(testFederatedActorIRI))
-		c.SetActivityStreamsActor(actor)
+		f.SetActivityStreamsActor(actor)
 		op := streams.NewActivityStreamsObjectProperty()
-		op.AppendActivityStreamsFollow(testFollow)
-		c.SetActivityStreamsObject(op)
-		return c
+		op.AppendIRI(mustParse(testFederatedActorIRI2))
+		f.SetActivityStreamsObject(op)
+		return f
+	}
+	newRequestsFn := func() vocab.ActivityStreamsCollection {
+		requests := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testNewActivityIRI))
+		requests.SetActivityStreamsItems(items)
+		return requests
 	}
 	ctx := context.Background()
-	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase, mockTp *MockTransport) {
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase, mockRequests *MockFollowRequestsDatabase) {
 		mockDB = NewMockDatabase(ctl)
-		mockTp = NewMockTransport(ctl)
+		mockRequests = NewMockFollowRequestsDatabase(ctl)
+		w.db = followRequestsDatabase{mockDB, mockRequests}
 		w.inboxIRI = mustParse(testMyInboxIRI)
-		w.db = mockDB
-		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
-			return mockTp, nil
+		w.OnFollow = OnFollowManuallyApprove
+		w.addNewIds = func(c context.Context, activity Activity) error {
+			return nil
 		}
 		return
 	}
-	t.Run("DoesNothingIfNoObjects", func(t *testing.T) {
-		a := newAcceptFn()
-		a.SetActivityStreamsObject(nil)
-		var w FederatingWrappedCallbacks
-		err := w.accept(ctx, a)
-		if err != nil {
-			t.Fatalf("got error %s", err)
-		}
-	})
-	t.Run("DereferencesObjectIRI", func(t *testing.T) {
+	expectRemovedFn := func(mockDB *MockDatabase, mockRequests *MockFollowRequestsDatabase) {
+		expectRequests := newRequestsFn()
+		expectRequests.GetActivityStreamsItems().Remove(0)
+		gomock.InOrder(
+			mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI)),
+			mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+				mustParse(testFederatedActorIRI2), nil),
+			mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI)),
+			mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2)),
+			mockRequests.EXPECT().FollowRequests(ctx, mustParse(testFederatedActorIRI2)).Return(
+				newRequestsFn(), nil),
+			mockDB.EXPECT().Update(ctx, expectRequests),
+			mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2)),
+			mockDB.EXPECT().Lock(ctx, mustParse(testNewActivityIRI)),
+			mockDB.EXPECT().Get(ctx, mustParse(testNewActivityIRI)).Return(
+				newFollowFn(), nil),
+			mockDB.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI)),
+		)
+	}
+	expectOutboxFn := func(mockDB *MockDatabase) {
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().OutboxForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testMyOutboxIRI), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+	}
+	t.Run("ApproveUpdatesFollowersAndDeliversAccept", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		w, mockDB, mockTp := setupFn(ctl)
-		followers := streams.NewActivityStreamsCollection()
+		w, mockDB, mockRequests := setupFn(ctl)
+		var delivered Activity
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			delivered = activity
+			return nil
+		}
 		expectFollowers := streams.NewActivityStreamsCollection()
 		expectItems := streams.NewActivityStreamsItemsProperty()
 		expectItems.AppendIRI(mustParse(testFederatedActorIRI))
 		expectFollowers.SetActivityStreamsItems(expectItems)
-		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
-		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
-			mustParse(testFederatedActorIRI2), nil)
-		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
-		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
-			mustSerializeToBytes(testFollow), nil)
-		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
-		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
-			testFollow, nil)
-		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		expectRemovedFn(mockDB, mockRequests)
 		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
-		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
-			followers, nil)
+		mockDB.EXPECT().Followers(ctx, mustParse(testFederatedActorIRI2)).Return(
+			streams.NewActivityStreamsCollection(), nil)
 		mockDB.EXPECT().Update(ctx, expectFollowers)
 		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
-		a := newAcceptFn()
-		op := streams.NewActivityStreamsObjectProperty()
-		op.AppendIRI(mustParse(testFederatedActivityIRI))
-		a.SetActivityStreamsObject(op)
-		err := w.accept(ctx, a)
+		expectOutboxFn(mockDB)
+		err := w.resolveFollowRequest(ctx, mustParse(testNewActivityIRI), true)
 		if err != nil {
 			t.Fatalf("got error %s", err)
 		}
+		if !streams.IsOrExtendsActivityStreamsAccept(delivered) {
+			t.Fatalf("expected Accept, got %T", delivered)
+		}
 	})
-	t.Run("IgnoresNonFollowObjects", func(t *testing.T) {
+	t.Run("DenyDeliversReject", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		w, mockDB, _ := setupFn(ctl)
-		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
-		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
-			mustParse(testFederatedActorIRI2), nil)
-		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
-		a := newAcceptFn()
-		op := streams.NewActivityStreamsObjectProperty()
-		op.AppendActivityStreamsListen(testListen)
-		a.SetActivityStreamsObject(op)
-		err := w.accept(ctx, a)
+		w, mockDB, mockRequests := setupFn(ctl)
+		var delivered Activity
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			delivered = activity
+			return nil
+		}
+		expectRemovedFn(mockDB, mockRequests)
+		expectOutboxFn(mockDB)
+		err := w.resolveFollowRequest(ctx, mustParse(testNewActivityIRI), false)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if !streams.IsOrExtendsActivityStreamsReject(delivered) {
+			t.Fatalf("expected Reject, got %T", delivered)
+		}
+	})
+	t.Run("ErrorIfNotPending", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, mockRequests := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockRequests.EXPECT().FollowRequests(ctx, mustParse(testFederatedActorIRI2)).Return(
+			streams.NewActivityStreamsCollection(), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		err := w.resolveFollowRequest(ctx, mustParse(testNewActivityIRI), true)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("RespondsOnceToConcurrentCalls", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, mockRequests := setupFn(ctl)
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			return nil
+		}
+		// The second call finds the request already removed by the
+		// first call.
+		expectRemovedFn(mockDB, mockRequests)
+		expectOutboxFn(mockDB)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockRequests.EXPECT().FollowRequests(ctx, mustParse(testFederatedActorIRI2)).Return(
+			streams.NewActivityStreamsCollection(), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		err := w.resolveFollowRequest(ctx, mustParse(testNewActivityIRI), false)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		err = w.resolveFollowRequest(ctx, mustParse(testNewActivityIRI), true)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("ErrorWithoutFollowRequestsDatabase", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		w.db = mockDB
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		err := w.resolveFollowRequest(ctx, mustParse(testNewActivityIRI), true)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+}
+
+func TestFederatedAccept(t *testing.T) {
+	newAcceptFn := func() vocab.ActivityStreamsAccept {
+		c := streams.NewActivityStreamsAccept()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI2))
+		c.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		c.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsFollow(testFollow)
+		c.SetActivityStreamsObject(op)
+		return c
+	}
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase, mockTp *MockTransport) {
+		mockDB = NewMockDatabase(ctl)
+		mockTp = NewMockTransport(ctl)
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.db = mockDB
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		return
+	}
+	t.Run("DoesNothingIfNoObjects", func(t *testing.T) {
+		a := newAcceptFn()
+		a.SetActivityStreamsObject(nil)
+		var w FederatingWrappedCallbacks
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("DereferencesObjectIRI", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, mockTp := setupFn(ctl)
+		followers := streams.NewActivityStreamsCollection()
+		expectFollowers := streams.NewActivityStreamsCollection()
+		expectItems := streams.NewActivityStreamsItemsProperty()
+		expectItems.AppendIRI(mustParse(testFederatedActorIRI))
+		expectFollowers.SetActivityStreamsItems(expectItems)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testFollow), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+			testFollow, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
+			followers, nil)
+		mockDB.EXPECT().Update(ctx, expectFollowers)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		a := newAcceptFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI))
+		a.SetActivityStreamsObject(op)
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("IgnoresNonFollowObjects", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		a := newAcceptFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsListen(testListen)
+		a.SetActivityStreamsObject(op)
+		err := w.accept(ctx, a)
 		if err != nil {
 			t.Fatalf("got error %s", err)
 		}
@@ -838,6 +1407,7 @@ func TestFederatedAccept(t *testing.T) {
 			mustParse(testFederatedActorIRI2), nil)
 		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
 		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(true, nil)
 		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
 			testListen, nil)
 		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
@@ -847,6 +1417,23 @@ func TestFederatedAccept(t *testing.T) {
 			t.Fatalf("expected error, got none")
 		}
 	})
+	t.Run("ErrorIfFollowNotOwned", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		a := newAcceptFn()
+		err := w.accept(ctx, a)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
 	t.Run("UpdatesFollowingCollection", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -861,6 +1448,7 @@ func TestFederatedAccept(t *testing.T) {
 			mustParse(testFederatedActorIRI2), nil)
 		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
 		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(true, nil)
 		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
 			testFollow, nil)
 		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
@@ -875,6 +1463,49 @@ func TestFederatedAccept(t *testing.T) {
 			t.Fatalf("got error %s", err)
 		}
 	})
+	t.Run("UpdatesFollowingCollectionOnceForMultipleFollows", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, mockTp := setupFn(ctl)
+		follow2 := streams.NewActivityStreamsFollow()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		follow2.SetJSONLDId(id)
+		follow2.SetActivityStreamsActor(testFollow.GetActivityStreamsActor())
+		follow2.SetActivityStreamsObject(testFollow.GetActivityStreamsObject())
+		following := streams.NewActivityStreamsCollection()
+		expectFollowing := streams.NewActivityStreamsCollection()
+		expectItems := streams.NewActivityStreamsItemsProperty()
+		expectItems.AppendIRI(mustParse(testFederatedActorIRI))
+		expectFollowing.SetActivityStreamsItems(expectItems)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockTp.EXPECT().Dereference(ctx, mustParse(testNewActivityIRI)).Return(
+			mustSerializeToBytes(follow2), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+			testFollow, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNewActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNewActivityIRI)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNewActivityIRI)).Return(
+			follow2, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
+			following, nil)
+		mockDB.EXPECT().Update(ctx, expectFollowing)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		a := newAcceptFn()
+		a.GetActivityStreamsObject().AppendIRI(mustParse(testNewActivityIRI))
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
 	t.Run("CallsCustomCallback", func(t *testing.T) {
 		a := newAcceptFn()
 		a.SetActivityStreamsObject(nil)
@@ -896,7 +1527,111 @@ func TestFederatedAccept(t *testing.T) {
 }
 
 func TestFederatedReject(t *testing.T) {
+	newRejectFn := func() vocab.ActivityStreamsReject {
+		r := streams.NewActivityStreamsReject()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI2))
+		r.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		r.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsFollow(testFollow)
+		r.SetActivityStreamsObject(op)
+		return r
+	}
 	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase) {
+		mockDB = NewMockDatabase(ctl)
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.db = mockDB
+		return
+	}
+	t.Run("RemovesFromFollowingCollection", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		following := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActorIRI))
+		items.AppendIRI(mustParse(testFederatedActorIRI3))
+		following.SetActivityStreamsItems(items)
+		expectFollowing := streams.NewActivityStreamsCollection()
+		expectItems := streams.NewActivityStreamsItemsProperty()
+		expectItems.AppendIRI(mustParse(testFederatedActorIRI))
+		expectItems.AppendIRI(mustParse(testFederatedActorIRI3))
+		expectItems.Remove(0)
+		expectFollowing.SetActivityStreamsItems(expectItems)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+			testFollow, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
+			following, nil)
+		mockDB.EXPECT().Update(ctx, expectFollowing)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		r := newRejectFn()
+		err := w.reject(ctx, r)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("IgnoresFollowObjectsNotContainingMe", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI3), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		r := newRejectFn()
+		err := w.reject(ctx, r)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("ErrorIfPeerLiedAboutOurFollowId", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+			testListen, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		r := newRejectFn()
+		err := w.reject(ctx, r)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("ErrorIfFollowNotOwned", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActivityIRI)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		r := newRejectFn()
+		err := w.reject(ctx, r)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
 	t.Run("CallsCustomCallback", func(t *testing.T) {
 		r := streams.NewActivityStreamsReject()
 		var w FederatingWrappedCallbacks
@@ -1694,90 +2429,346 @@ func TestFederatedUndo(t *testing.T) {
 	})
 	t.Run("ErrorIfObjectLengthZero", func(t *testing.T) {
 		u := newUndoFn()
-		u.GetActivityStreamsObject().Remove(0)
-		var w FederatingWrappedCallbacks
+		u.GetActivityStreamsObject().Remove(0)
+		var w FederatingWrappedCallbacks
+		err := w.undo(ctx, u)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("ErrorIfActorMismatch", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp := setupFn(ctl)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testListen), nil)
+		u := newUndoFn()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI2))
+		u.SetActivityStreamsActor(actor)
+		err := w.undo(ctx, u)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("ErrorIfActorMismatchWhenDereferencingIRI", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp := setupFn(ctl)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testFollow), nil)
+		u := newUndoFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI))
+		u.SetActivityStreamsObject(op)
+		err := w.undo(ctx, u)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("DereferencesWhenUndoValue", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp := setupFn(ctl)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testListen), nil)
+		u := newUndoFn()
+		err := w.undo(ctx, u)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("DereferencesWhenUndoIRI", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp := setupFn(ctl)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testListen), nil)
+		u := newUndoFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI))
+		u.SetActivityStreamsObject(op)
+		err := w.undo(ctx, u)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("CallsCustomCallback", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp := setupFn(ctl)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testListen), nil)
+		var gotc context.Context
+		var got vocab.ActivityStreamsUndo
+		w.Undo = func(ctx context.Context, v vocab.ActivityStreamsUndo) error {
+			gotc = ctx
+			got = v
+			return nil
+		}
+		u := newUndoFn()
+		err := w.undo(ctx, u)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, ctx, gotc)
+		assertEqual(t, u, got)
+	})
+	t.Run("RemovesFollowerWhenUndoFollow", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp := setupFn(ctl)
+		mockDB := NewMockDatabase(ctl)
+		w.db = mockDB
+		followers := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActorIRI2))
+		items.AppendIRI(mustParse(testFederatedActorIRI3))
+		followers.SetActivityStreamsItems(items)
+		expectFollowers := streams.NewActivityStreamsCollection()
+		expectItems := streams.NewActivityStreamsItemsProperty()
+		expectItems.AppendIRI(mustParse(testFederatedActorIRI3))
+		expectFollowers.SetActivityStreamsItems(expectItems)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testFollow), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDB.EXPECT().Followers(ctx, mustParse(testFederatedActorIRI)).Return(
+			followers, nil)
+		mockDB.EXPECT().Update(ctx, expectFollowers).Return(nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		u := newUndoFn()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI2))
+		u.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI))
+		u.SetActivityStreamsObject(op)
 		err := w.undo(ctx, u)
-		if err == nil {
-			t.Fatalf("expected error, got none")
+		if err != nil {
+			t.Fatalf("got error %s", err)
 		}
 	})
-	t.Run("ErrorIfActorMismatch", func(t *testing.T) {
+	t.Run("RemovesFollowRequestWhenUndoFollow", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		w, mockTp := setupFn(ctl)
+		mockDB := NewMockDatabase(ctl)
+		mockRequests := NewMockFollowRequestsDatabase(ctl)
+		w.db = followRequestsDatabase{mockDB, mockRequests}
+		w.OnFollow = OnFollowManuallyApprove
+		newCollectionFn := func(ids ...string) vocab.ActivityStreamsCollection {
+			col := streams.NewActivityStreamsCollection()
+			items := streams.NewActivityStreamsItemsProperty()
+			for _, id := range ids {
+				items.AppendIRI(mustParse(id))
+			}
+			col.SetActivityStreamsItems(items)
+			return col
+		}
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
-			mustSerializeToBytes(testListen), nil)
+			mustSerializeToBytes(testFollow), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDB.EXPECT().Followers(ctx, mustParse(testFederatedActorIRI)).Return(
+			newCollectionFn(testFederatedActorIRI3), nil)
+		mockDB.EXPECT().Update(ctx, newCollectionFn(testFederatedActorIRI3)).Return(nil)
+		mockRequests.EXPECT().FollowRequests(ctx, mustParse(testFederatedActorIRI)).Return(
+			newCollectionFn(testFederatedActivityIRI, testFederatedActivityIRI2), nil)
+		mockDB.EXPECT().Update(ctx, newCollectionFn(testFederatedActivityIRI2)).Return(nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
 		u := newUndoFn()
 		actor := streams.NewActivityStreamsActorProperty()
 		actor.AppendIRI(mustParse(testFederatedActorIRI2))
 		u.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI))
+		u.SetActivityStreamsObject(op)
 		err := w.undo(ctx, u)
-		if err == nil {
-			t.Fatalf("expected error, got none")
+		if err != nil {
+			t.Fatalf("got error %s", err)
 		}
 	})
-	t.Run("ErrorIfActorMismatchWhenDereferencingIRI", func(t *testing.T) {
+	t.Run("IgnoresUndoFollowOfOtherActor", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		w, mockTp := setupFn(ctl)
+		mockDB := NewMockDatabase(ctl)
+		w.db = mockDB
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
 			mustSerializeToBytes(testFollow), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI3), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
 		u := newUndoFn()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI2))
+		u.SetActivityStreamsActor(actor)
 		op := streams.NewActivityStreamsObjectProperty()
 		op.AppendIRI(mustParse(testFederatedActivityIRI))
 		u.SetActivityStreamsObject(op)
 		err := w.undo(ctx, u)
-		if err == nil {
-			t.Fatalf("expected error, got none")
+		if err != nil {
+			t.Fatalf("got error %s", err)
 		}
 	})
-	t.Run("DereferencesWhenUndoValue", func(t *testing.T) {
+	newLikedNoteFn := func() vocab.ActivityStreamsNote {
+		note := streams.NewActivityStreamsNote()
+		likes := streams.NewActivityStreamsLikesProperty()
+		col := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActivityIRI))
+		items.AppendIRI(mustParse(testFederatedActivityIRI2))
+		col.SetActivityStreamsItems(items)
+		likes.SetActivityStreamsCollection(col)
+		note.SetActivityStreamsLikes(likes)
+		return note
+	}
+	t.Run("RemovesFromLikesWhenUndoLike", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		w, mockTp := setupFn(ctl)
+		mockDB := NewMockDatabase(ctl)
+		w.db = mockDB
+		like := streams.NewActivityStreamsLike()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		like.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		like.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		like.SetActivityStreamsObject(op)
+		expectNote := newLikedNoteFn()
+		expectNote.GetActivityStreamsLikes().GetActivityStreamsCollection().GetActivityStreamsItems().Remove(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
-			mustSerializeToBytes(testListen), nil)
+			mustSerializeToBytes(like), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(
+			newLikedNoteFn(), nil)
+		mockDB.EXPECT().Update(ctx, expectNote).Return(nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
 		u := newUndoFn()
+		uop := streams.NewActivityStreamsObjectProperty()
+		uop.AppendIRI(mustParse(testFederatedActivityIRI))
+		u.SetActivityStreamsObject(uop)
 		err := w.undo(ctx, u)
 		if err != nil {
 			t.Fatalf("got error %s", err)
 		}
 	})
-	t.Run("DereferencesWhenUndoIRI", func(t *testing.T) {
+	t.Run("RemovesFromSharesWhenUndoAnnounce", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		w, mockTp := setupFn(ctl)
+		mockDB := NewMockDatabase(ctl)
+		w.db = mockDB
+		announce := streams.NewActivityStreamsAnnounce()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		announce.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		announce.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		announce.SetActivityStreamsObject(op)
+		newSharedNoteFn := func(ids ...string) vocab.ActivityStreamsNote {
+			note := streams.NewActivityStreamsNote()
+			shares := streams.NewActivityStreamsSharesProperty()
+			col := streams.NewActivityStreamsOrderedCollection()
+			oi := streams.NewActivityStreamsOrderedItemsProperty()
+			for _, id := range ids {
+				oi.AppendIRI(mustParse(id))
+			}
+			col.SetActivityStreamsOrderedItems(oi)
+			shares.SetActivityStreamsOrderedCollection(col)
+			note.SetActivityStreamsShares(shares)
+			return note
+		}
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
-			mustSerializeToBytes(testListen), nil)
+			mustSerializeToBytes(announce), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(
+			newSharedNoteFn(testFederatedActivityIRI2, testFederatedActivityIRI), nil)
+		mockDB.EXPECT().Update(ctx, newSharedNoteFn(testFederatedActivityIRI2)).Return(nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
 		u := newUndoFn()
-		op := streams.NewActivityStreamsObjectProperty()
-		op.AppendIRI(mustParse(testFederatedActivityIRI))
-		u.SetActivityStreamsObject(op)
+		uop := streams.NewActivityStreamsObjectProperty()
+		uop.AppendIRI(mustParse(testFederatedActivityIRI))
+		u.SetActivityStreamsObject(uop)
 		err := w.undo(ctx, u)
 		if err != nil {
 			t.Fatalf("got error %s", err)
 		}
 	})
-	t.Run("CallsCustomCallback", func(t *testing.T) {
+	t.Run("RemovesFromRepliesWhenUndoCreate", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		w, mockTp := setupFn(ctl)
-		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
-			mustSerializeToBytes(testListen), nil)
-		var gotc context.Context
-		var got vocab.ActivityStreamsUndo
-		w.Undo = func(ctx context.Context, v vocab.ActivityStreamsUndo) error {
-			gotc = ctx
-			got = v
-			return nil
+		mockDB := NewMockDatabase(ctl)
+		w.db = mockDB
+		w.MaintainReplies = true
+		create := streams.NewActivityStreamsCreate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		create.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		create.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI2))
+		create.SetActivityStreamsObject(op)
+		reply := streams.NewActivityStreamsNote()
+		irt := streams.NewActivityStreamsInReplyToProperty()
+		irt.AppendIRI(mustParse(testNoteId1))
+		reply.SetActivityStreamsInReplyTo(irt)
+		newRepliedNoteFn := func(ids ...string) vocab.ActivityStreamsNote {
+			note := streams.NewActivityStreamsNote()
+			replies := streams.NewActivityStreamsRepliesProperty()
+			col := streams.NewActivityStreamsCollection()
+			items := streams.NewActivityStreamsItemsProperty()
+			for _, id := range ids {
+				items.AppendIRI(mustParse(id))
+			}
+			col.SetActivityStreamsItems(items)
+			totalItems := streams.NewActivityStreamsTotalItemsProperty()
+			totalItems.Set(len(ids))
+			col.SetActivityStreamsTotalItems(totalItems)
+			replies.SetActivityStreamsCollection(col)
+			note.SetActivityStreamsReplies(replies)
+			return note
 		}
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(create), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI2))
+		mockDB.EXPECT().Exists(ctx, mustParse(testFederatedActivityIRI2)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI2)).Return(reply, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(true, nil)
+		mockDB.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(
+			newRepliedNoteFn(testFederatedActivityIRI2, testNoteId2), nil)
+		mockDB.EXPECT().Update(ctx, newRepliedNoteFn(testNoteId2)).Return(nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
 		u := newUndoFn()
+		uop := streams.NewActivityStreamsObjectProperty()
+		uop.AppendIRI(mustParse(testFederatedActivityIRI))
+		u.SetActivityStreamsObject(uop)
 		err := w.undo(ctx, u)
 		if err != nil {
 			t.Fatalf("got error %s", err)
 		}
-		assertEqual(t, ctx, gotc)
-		assertEqual(t, u, got)
 	})
 }
 
@@ -1832,3 +2823,329 @@ func TestFederatedBlock(t *testing.T) {
 		assertEqual(t, b, got)
 	})
 }
+
+func TestFederatedMove(t *testing.T) {
+	newMoveFn := func() vocab.ActivityStreamsMove {
+		m := streams.NewActivityStreamsMove()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		m.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		m.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActorIRI))
+		m.SetActivityStreamsObject(op)
+		target := streams.NewActivityStreamsTargetProperty()
+		target.AppendIRI(mustParse(testFederatedActorIRI3))
+		m.SetActivityStreamsTarget(target)
+		return m
+	}
+	newTargetFn := func(aka ...string) vocab.ActivityStreamsPerson {
+		p := streams.NewActivityStreamsPerson()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActorIRI3))
+		p.SetJSONLDId(id)
+		akap := streams.NewActivityStreamsAlsoKnownAsProperty()
+		for _, iri := range aka {
+			akap.AppendIRI(mustParse(iri))
+		}
+		p.SetActivityStreamsAlsoKnownAs(akap)
+		return p
+	}
+	newFollowingFn := func(ids ...string) vocab.ActivityStreamsCollection {
+		col := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		for _, id := range ids {
+			items.AppendIRI(mustParse(id))
+		}
+		col.SetActivityStreamsItems(items)
+		return col
+	}
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase, mockTp *MockTransport) {
+		mockDB = NewMockDatabase(ctl)
+		mockTp = NewMockTransport(ctl)
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.db = mockDB
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		return
+	}
+	expectFollowingFn := func(mockDB *MockDatabase, following vocab.ActivityStreamsCollection) {
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
+			following, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+	}
+	t.Run("ErrorIfNoTarget", func(t *testing.T) {
+		m := newMoveFn()
+		m.SetActivityStreamsTarget(nil)
+		var w FederatingWrappedCallbacks
+		err := w.move(ctx, m)
+		assertEqual(t, err, ErrTargetRequired)
+	})
+	t.Run("ErrorIfObjectIsNotAnActor", func(t *testing.T) {
+		m := newMoveFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActorIRI4))
+		m.SetActivityStreamsObject(op)
+		var w FederatingWrappedCallbacks
+		err := w.move(ctx, m)
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("IgnoresIfNotFollowing", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		expectFollowingFn(mockDB, newFollowingFn(testFederatedActorIRI4))
+		err := w.move(ctx, newMoveFn())
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("ErrorIfTargetNotAlsoKnownAsOrigin", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, mockTp := setupFn(ctl)
+		expectFollowingFn(mockDB, newFollowingFn(testFederatedActorIRI))
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI3)).Return(
+			mustSerializeToBytes(newTargetFn(testFederatedActorIRI4)), nil)
+		err := w.move(ctx, newMoveFn())
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("FollowsTargetInsteadOfOrigin", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, mockTp := setupFn(ctl)
+		var delivered Activity
+		w.addNewIds = func(c context.Context, activity Activity) error {
+			id := streams.NewJSONLDIdProperty()
+			id.Set(mustParse(testNewActivityIRI))
+			activity.SetJSONLDId(id)
+			return nil
+		}
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			delivered = activity
+			return nil
+		}
+		expectFollowing := newFollowingFn(testFederatedActorIRI, testFederatedActorIRI4)
+		expectFollowing.GetActivityStreamsItems().Remove(0)
+		expectFollowingFn(mockDB, newFollowingFn(testFederatedActorIRI, testFederatedActorIRI4))
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI3)).Return(
+			mustSerializeToBytes(newTargetFn(testFederatedActorIRI)), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
+			newFollowingFn(testFederatedActorIRI, testFederatedActorIRI4), nil)
+		mockDB.EXPECT().Update(ctx, expectFollowing)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNewActivityIRI))
+		mockDB.EXPECT().Exists(ctx, mustParse(testNewActivityIRI)).Return(false, nil)
+		mockDB.EXPECT().Create(ctx, gomock.Any())
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().OutboxForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testMyOutboxIRI), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		err := w.move(ctx, newMoveFn())
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if !streams.IsOrExtendsActivityStreamsFollow(delivered) {
+			t.Fatalf("expected Follow, got %T", delivered)
+		}
+		object, err := ToId(delivered.(vocab.ActivityStreamsFollow).GetActivityStreamsObject().At(0))
+		assertEqual(t, err, nil)
+		assertEqual(t, object.String(), testFederatedActorIRI3)
+	})
+}
+
+func TestFederatedFlag(t *testing.T) {
+	newFlagFn := func() vocab.ActivityStreamsFlag {
+		f := streams.NewActivityStreamsFlag()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		f.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		f.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		op.AppendIRI(mustParse(testFederatedActorIRI4))
+		f.SetActivityStreamsObject(op)
+		return f
+	}
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase, q *MemoryModerationQueue) {
+		mockDB = NewMockDatabase(ctl)
+		q = NewMemoryModerationQueue()
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.db = mockDB
+		w.moderationQueue = func(c context.Context) ModerationQueue {
+			return q
+		}
+		return
+	}
+	expectOwnsFn := func(mockDB *MockDatabase, localOwned bool) {
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(localOwned, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI4))
+		mockDB.EXPECT().Owns(ctx, mustParse(testFederatedActorIRI4)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI4))
+	}
+	t.Run("ErrorIfNoObject", func(t *testing.T) {
+		f := newFlagFn()
+		f.SetActivityStreamsObject(nil)
+		var w FederatingWrappedCallbacks
+		err := w.flag(ctx, f)
+		assertEqual(t, err, ErrObjectRequired)
+	})
+	t.Run("QueuesReportOfOwnedObjects", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, q := setupFn(ctl)
+		f := newFlagFn()
+		expectOwnsFn(mockDB, true)
+		err := w.flag(ctx, f)
+		assertEqual(t, err, nil)
+		reports, err := q.Reports(ctx)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(reports), 1)
+		assertEqual(t, reports[0].Id.String(), testFederatedActivityIRI)
+		assertEqual(t, reports[0].InboxIRI.String(), testMyInboxIRI)
+		assertEqual(t, reports[0].Flag, f)
+		assertEqual(t, len(reports[0].Local), 1)
+		assertEqual(t, reports[0].Local[0].String(), testNoteId1)
+		assertEqual(t, len(reports[0].Remote), 1)
+		assertEqual(t, reports[0].Remote[0].String(), testFederatedActorIRI4)
+	})
+	t.Run("IgnoresReportOfOnlyRemoteObjects", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, q := setupFn(ctl)
+		expectOwnsFn(mockDB, false)
+		err := w.flag(ctx, newFlagFn())
+		assertEqual(t, err, nil)
+		reports, err := q.Reports(ctx)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(reports), 0)
+	})
+	t.Run("ForwardsRemoteObjectsToOwners", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, q := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		var delivered Activity
+		w.addNewIds = func(c context.Context, activity Activity) error {
+			return nil
+		}
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			assertEqual(t, outboxIRI.String(), testMyOutboxIRI)
+			delivered = activity
+			return nil
+		}
+		note := streams.NewActivityStreamsNote()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNoteId2))
+		note.SetJSONLDId(id)
+		attrTo := streams.NewActivityStreamsAttributedToProperty()
+		attrTo.AppendIRI(mustParse(testFederatedActorIRI3))
+		note.SetActivityStreamsAttributedTo(attrTo)
+		err := q.AddReport(ctx, Report{
+			Id:     mustParse(testFederatedActivityIRI),
+			Flag:   newFlagFn(),
+			Local:  []*url.URL{mustParse(testNoteId1)},
+			Remote: []*url.URL{mustParse(testNoteId2), mustParse(testFederatedActorIRI)},
+		})
+		assertEqual(t, err, nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testNoteId2)).Return(
+			mustSerializeToBytes(note), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().OutboxForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testMyOutboxIRI), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		err = w.forwardReport(ctx, mustParse(testFederatedActivityIRI))
+		assertEqual(t, err, nil)
+		forwarded, ok := delivered.(vocab.ActivityStreamsFlag)
+		if !ok {
+			t.Fatalf("expected Flag, got %T", delivered)
+		}
+		actor, err := ToId(forwarded.GetActivityStreamsActor().At(0))
+		assertEqual(t, err, nil)
+		assertEqual(t, actor.String(), testFederatedActorIRI2)
+		objIds, err := objectIds(forwarded)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(objIds), 2)
+		assertEqual(t, objIds[0].String(), testNoteId2)
+		assertEqual(t, objIds[1].String(), testFederatedActorIRI)
+		toProp := forwarded.GetActivityStreamsTo()
+		assertEqual(t, toProp.Len(), 2)
+		to, err := ToId(toProp.At(0))
+		assertEqual(t, err, nil)
+		assertEqual(t, to.String(), testFederatedActorIRI3)
+		to, err = ToId(toProp.At(1))
+		assertEqual(t, err, nil)
+		assertEqual(t, to.String(), testFederatedActorIRI)
+	})
+	t.Run("ErrorForwardingObjectWithoutOwner", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _, q := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		err := q.AddReport(ctx, Report{
+			Id:     mustParse(testFederatedActivityIRI),
+			Flag:   newFlagFn(),
+			Remote: []*url.URL{mustParse(testNoteId2)},
+		})
+		assertEqual(t, err, nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testNoteId2)).Return(
+			mustSerializeToBytes(testFederatedNote2), nil)
+		err = w.forwardReport(ctx, mustParse(testFederatedActivityIRI))
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("ErrorForwardingUnknownReport", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _, _ := setupFn(ctl)
+		err := w.forwardReport(ctx, mustParse(testFederatedActivityIRI))
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("ResolvedReportIsRemoved", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, q := setupFn(ctl)
+		err := q.AddReport(ctx, Report{Id: mustParse(testFederatedActivityIRI)})
+		assertEqual(t, err, nil)
+		err = q.ResolveReport(ctx, mustParse(testFederatedActivityIRI))
+		assertEqual(t, err, nil)
+		r, err := q.Report(ctx, mustParse(testFederatedActivityIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, r == nil, true)
+	})
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
 )
 
 var ErrNotFound = errors.New("go-fed/activity: ActivityStreams data not found")
@@ -57,6 +59,91 @@ func NewActivityStreamsHandler(db Database, clock Clock) HandlerFunc {
 // Returns ErrNotFound when the database does not retrieve any data and no
 // errors occurred during retrieval.
 func NewActivityStreamsHandlerScheme(db Database, clock Clock, scheme string) HandlerFunc {
+	return newActivityStreamsHandler(db, clock, scheme, nil, nil)
+}
+
+// NewCachingActivityStreamsHandler creates a HandlerFunc like
+// NewActivityStreamsHandler that also serves the Cache-Control header given
+// by the CacheControlPolicy for the type of each value.
+//
+// Defaults to supporting content to be retrieved by HTTPS only.
+func NewCachingActivityStreamsHandler(db Database, clock Clock, policy CacheControlPolicy) HandlerFunc {
+	return NewCachingActivityStreamsHandlerScheme(db, clock, policy, "https")
+}
+
+// NewCachingActivityStreamsHandlerScheme creates a HandlerFunc like
+// NewCachingActivityStreamsHandler, serving data provided by the specified
+// protocol scheme.
+//
+// Specifying the "scheme" allows for retrieving ActivityStreams content with
+// identifiers such as HTTP, HTTPS, or other protocol schemes.
+func NewCachingActivityStreamsHandlerScheme(db Database, clock Clock, policy CacheControlPolicy, scheme string) HandlerFunc {
+	return newActivityStreamsHandler(db, clock, scheme, nil, policy)
+}
+
+// RequestVerifier verifies the signature of an incoming request. It is
+// implemented by HttpSigVerifier.
+type RequestVerifier interface {
+	// VerifyRequest returns the IRI of the actor who signed the request,
+	// using the Transport to obtain their public key.
+	//
+	// A request without a valid signature must result in verified being
+	// false and a nil error.
+	VerifyRequest(c context.Context, r *http.Request, t Transport) (signer *url.URL, verified bool, err error)
+}
+
+// NewAuthorizedFetchHandler creates a HandlerFunc like
+// NewActivityStreamsHandler that only serves requests signed by a peer, a mode
+// known as "authorized fetch" or "secure mode".
+//
+// The signature is verified by the RequestVerifier, which uses the Transport
+// to obtain the signer's public key. Unsigned requests are answered with
+// http.StatusUnauthorized, and signers Blocked by the FederatingProtocol with
+// http.StatusForbidden.
+//
+// Values not addressed to the Public collection are only served to signers in
+// their 'to', 'cc', 'bto', 'bcc' or 'audience', or to followers of their
+// author if they are addressed to the author's 'followers'. Other signers are
+// answered with http.StatusNotFound. Values without any recipients, such as
+// collections, are served to all signers.
+//
+// Values with a 'publicKey' or 'assertionMethod', such as actors, and public
+// keys and Multikeys themselves are served without requiring a signature, so
+// that peers can obtain the keys needed to verify this server's own
+// signatures.
+//
+// Since responses depend on the signer, they are served with a private
+// Cache-Control header so that shared caches do not store them.
+//
+// Defaults to supporting content to be retrieved by HTTPS only.
+func NewAuthorizedFetchHandler(db Database, clock Clock, s2s FederatingProtocol, v RequestVerifier, t Transport) HandlerFunc {
+	return NewAuthorizedFetchHandlerScheme(db, clock, s2s, v, t, "https")
+}
+
+// NewAuthorizedFetchHandlerScheme creates a HandlerFunc like
+// NewAuthorizedFetchHandler, serving data provided by the specified protocol
+// scheme.
+//
+// Specifying the "scheme" allows for retrieving ActivityStreams content with
+// identifiers such as HTTP, HTTPS, or other protocol schemes.
+func NewAuthorizedFetchHandlerScheme(db Database, clock Clock, s2s FederatingProtocol, v RequestVerifier, t Transport, scheme string) HandlerFunc {
+	return newActivityStreamsHandler(db, clock, scheme, func(c context.Context, w http.ResponseWriter, r *http.Request, value vocab.Type) (bool, error) {
+		return authorizeFetch(c, w, r, value, db, s2s, v, t)
+	}, CacheControlPolicy{"": "private"})
+}
+
+// authorizeFetchFunc determines whether the value may be served in response to
+// the request. If not, it has written the response.
+type authorizeFetchFunc func(c context.Context, w http.ResponseWriter, r *http.Request, t vocab.Type) (authorized bool, err error)
+
+// newActivityStreamsHandler creates a HandlerFunc serving ActivityStreams
+// values from the Database, once they are authorized. A nil authorize function
+// serves every value.
+//
+// The ETag and Last-Modified headers are served with each value, and requests
+// whose conditional headers show that the client already has it are answered
+// with http.StatusNotModified.
+func newActivityStreamsHandler(db Database, clock Clock, scheme string, authorize authorizeFetchFunc, policy CacheControlPolicy) HandlerFunc {
 	return func(c context.Context, w http.ResponseWriter, r *http.Request) (isASRequest bool, err error) {
 		// Do nothing if it is not an ActivityPub GET request
 		if !isActivityPubGet(r) {
@@ -82,6 +169,12 @@ func NewActivityStreamsHandlerScheme(db Database, clock Clock, scheme string) Ha
 			err = ErrNotFound
 			return
 		}
+		if authorize != nil {
+			var authorized bool
+			if authorized, err = authorize(c, w, r, t); err != nil || !authorized {
+				return
+			}
+		}
 		// Remove sensitive fields.
 		clearSensitiveFields(t)
 		// Serialize the fetched value.
@@ -95,9 +188,16 @@ func NewActivityStreamsHandlerScheme(db Database, clock Clock, scheme string) Ha
 		}
 		// Construct the response.
 		addResponseHeaders(w.Header(), clock, raw)
+		addCachingHeaders(w.Header(), t, raw)
+		if cc := policy.cacheControl(t); len(cc) > 0 {
+			w.Header().Set(cacheControlHeader, cc)
+		}
 		// Write the response.
 		if streams.IsOrExtendsActivityStreamsTombstone(t) {
 			w.WriteHeader(http.StatusGone)
+		} else if isNotModified(r, w.Header()) {
+			writeNotModified(w)
+			return
 		} else {
 			w.WriteHeader(http.StatusOK)
 		}
@@ -111,3 +211,175 @@ func NewActivityStreamsHandlerScheme(db Database, clock Clock, scheme string) Ha
 		return
 	}
 }
+
+// authorizeFetch determines whether the value may be served to the signer of
+// the request, as described for NewAuthorizedFetchHandler.
+func authorizeFetch(c context.Context, w http.ResponseWriter, r *http.Request, t vocab.Type, db Database, s2s FederatingProtocol, v RequestVerifier, tp Transport) (authorized bool, err error) {
+	if _, ok := t.(publicKeyer); ok || streams.IsOrExtendsW3IDSecurityV1PublicKey(t) {
+		return true, nil
+	} else if _, ok := t.(assertionMethoder); ok || streams.IsOrExtendsW3IDSecurityDataIntegrityV1Multikey(t) {
+		return true, nil
+	}
+	signer, verified, err := v.VerifyRequest(c, r, tp)
+	if err != nil {
+		return
+	} else if !verified {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if blocked, err := s2s.Blocked(c, []*url.URL{signer}); err != nil {
+		return false, err
+	} else if blocked {
+		w.WriteHeader(http.StatusForbidden)
+		return false, nil
+	}
+	recipients, err := fetchRecipients(t)
+	if err != nil {
+		return
+	}
+	if len(recipients) == 0 {
+		return true, nil
+	}
+	for _, iri := range recipients {
+		if IsPublic(iri.String()) || iri.String() == signer.String() {
+			return true, nil
+		}
+	}
+	if authorized, err = followsAddressedAuthor(c, db, t, recipients, signer); err != nil || authorized {
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+	return
+}
+
+// followsAddressedAuthor determines whether the signer follows one of the
+// local authors of the value, and the value is addressed to that author's
+// 'followers'.
+func followsAddressedAuthor(c context.Context, db Database, t vocab.Type, recipients []*url.URL, signer *url.URL) (bool, error) {
+	var authors []*url.URL
+	if at, ok := t.(attributedToer); ok {
+		if atProp := at.GetActivityStreamsAttributedTo(); atProp != nil {
+			for iter := atProp.Begin(); iter != atProp.End(); iter = iter.Next() {
+				id, err := ToId(iter)
+				if err != nil {
+					return false, err
+				}
+				authors = append(authors, id)
+			}
+		}
+	}
+	if ac, ok := t.(actorer); ok {
+		if acProp := ac.GetActivityStreamsActor(); acProp != nil {
+			for iter := acProp.Begin(); iter != acProp.End(); iter = iter.Next() {
+				id, err := ToId(iter)
+				if err != nil {
+					return false, err
+				}
+				authors = append(authors, id)
+			}
+		}
+	}
+	// Create anonymous loop function to be able to properly scope the defer
+	// for the database lock at each iteration.
+	loopFn := func(author *url.URL) (bool, error) {
+		if err := db.Lock(c, author); err != nil {
+			return false, err
+		}
+		defer db.Unlock(c, author)
+		if owns, err := db.Owns(c, author); err != nil || !owns {
+			return false, err
+		}
+		followers, err := db.Followers(c, author)
+		if err != nil {
+			return false, err
+		}
+		followersId, err := GetId(followers)
+		if err != nil {
+			return false, err
+		}
+		if !containsIRI(recipients, followersId) {
+			return false, nil
+		}
+		items := followers.GetActivityStreamsItems()
+		if items == nil {
+			return false, nil
+		}
+		for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return false, err
+			}
+			if id.String() == signer.String() {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	for _, author := range authors {
+		if follows, err := loopFn(author); err != nil || follows {
+			return follows, err
+		}
+	}
+	return false, nil
+}
+
+// fetchRecipients returns the IRIs in the 'to', 'bto', 'cc', 'bcc' and
+// 'audience' of the value.
+func fetchRecipients(t vocab.Type) (iris []*url.URL, err error) {
+	if v, ok := t.(toer); ok {
+		if to := v.GetActivityStreamsTo(); to != nil {
+			for iter := to.Begin(); iter != to.End(); iter = iter.Next() {
+				var val *url.URL
+				if val, err = ToId(iter); err != nil {
+					return
+				}
+				iris = append(iris, val)
+			}
+		}
+	}
+	if v, ok := t.(btoer); ok {
+		if bto := v.GetActivityStreamsBto(); bto != nil {
+			for iter := bto.Begin(); iter != bto.End(); iter = iter.Next() {
+				var val *url.URL
+				if val, err = ToId(iter); err != nil {
+					return
+				}
+				iris = append(iris, val)
+			}
+		}
+	}
+	if v, ok := t.(ccer); ok {
+		if cc := v.GetActivityStreamsCc(); cc != nil {
+			for iter := cc.Begin(); iter != cc.End(); iter = iter.Next() {
+				var val *url.URL
+				if val, err = ToId(iter); err != nil {
+					return
+				}
+				iris = append(iris, val)
+			}
+		}
+	}
+	if v, ok := t.(bccer); ok {
+		if bcc := v.GetActivityStreamsBcc(); bcc != nil {
+			for iter := bcc.Begin(); iter != bcc.End(); iter = iter.Next() {
+				var val *url.URL
+				if val, err = ToId(iter); err != nil {
+					return
+				}
+				iris = append(iris, val)
+			}
+		}
+	}
+	if v, ok := t.(audiencer); ok {
+		if audience := v.GetActivityStreamsAudience(); audience != nil {
+			for iter := audience.Begin(); iter != audience.End(); iter = iter.Next() {
+				var val *url.URL
+				if val, err = ToId(iter); err != nil {
+					return
+				}
+				iris = append(iris, val)
+			}
+		}
+	}
+	return
+}
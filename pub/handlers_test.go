@@ -2,12 +2,18 @@ package pub
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
 	"github.com/golang/mock/gomock"
 )
 
@@ -102,4 +108,303 @@ func TestActivityStreamsHandler(t *testing.T) {
 		assertEqual(t, err, nil)
 		assertByteEqual(t, b, mustSerializeToBytes(testMyNote))
 	})
+	t.Run("ServesCachingHeaders", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockDb, mockClock, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		note := streams.NewActivityStreamsNote()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNoteId1))
+		note.SetJSONLDId(id)
+		updated := streams.NewActivityStreamsUpdatedProperty()
+		updated.Set(now())
+		note.SetActivityStreamsUpdated(updated)
+		// Mock
+		mockDb.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDb.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(note, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		mockClock.EXPECT().Now().Return(now())
+		// Run & Verify
+		_, err := hf(ctx, resp, req)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+		respV := resp.Result()
+		assertEqual(t, respV.Header.Get(etagHeader), testETag(note))
+		assertEqual(t, respV.Header.Get(lastModifiedHeader), nowDateHeader())
+		assertEqual(t, respV.Header.Get(cacheControlHeader), "")
+	})
+	t.Run("RespondsNotModifiedForMatchingETag", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockDb, mockClock, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		req.Header.Set(ifNoneMatchHeader, "\"other\", W/"+testETag(testMyNote))
+		// Mock
+		mockDb.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDb.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(testMyNote, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		mockClock.EXPECT().Now().Return(now())
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusNotModified)
+		respV := resp.Result()
+		assertEqual(t, respV.Header.Get(etagHeader), testETag(testMyNote))
+		assertEqual(t, respV.Header.Get(contentTypeHeader), "")
+		b, err := ioutil.ReadAll(respV.Body)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(b), 0)
+	})
+	t.Run("RespondsNotModifiedSinceLastModified", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockDb, mockClock, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		req.Header.Set(ifModifiedSinceHeader, nowDateHeader())
+		note := streams.NewActivityStreamsNote()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNoteId1))
+		note.SetJSONLDId(id)
+		published := streams.NewActivityStreamsPublishedProperty()
+		published.Set(now().Add(-time.Hour))
+		note.SetActivityStreamsPublished(published)
+		// Mock
+		mockDb.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDb.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(note, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		mockClock.EXPECT().Now().Return(now())
+		// Run & Verify
+		_, err := hf(ctx, resp, req)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusNotModified)
+	})
+	t.Run("ServesCacheControlByType", func(t *testing.T) {
+		for _, test := range []struct {
+			value vocab.Type
+			code  int
+			want  string
+		}{
+			{testMyPerson, http.StatusOK, "max-age=3600"},
+			{testMyNote, http.StatusOK, "no-cache"},
+			{toTombstone(testMyNote, mustParse(testNoteId1), now()), http.StatusGone, "max-age=86400"},
+		} {
+			// Setup
+			ctl := gomock.NewController(t)
+			mockDb := NewMockDatabase(ctl)
+			mockClock := NewMockClock(ctl)
+			hf := NewCachingActivityStreamsHandler(mockDb, mockClock, CacheControlPolicy{
+				"Person":    "max-age=3600",
+				"Tombstone": "max-age=86400",
+				"":          "no-cache",
+			})
+			resp := httptest.NewRecorder()
+			req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+			// Mock
+			mockDb.EXPECT().Lock(ctx, mustParse(testNoteId1))
+			mockDb.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(test.value, nil)
+			mockDb.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+			mockClock.EXPECT().Now().Return(now())
+			// Run & Verify
+			_, err := hf(ctx, resp, req)
+			assertEqual(t, err, nil)
+			assertEqual(t, resp.Code, test.code)
+			assertEqual(t, resp.Result().Header.Get(cacheControlHeader), test.want)
+			ctl.Finish()
+		}
+	})
+}
+
+// TestAuthorizedFetchHandler tests the handler for serving ActivityPub
+// requests only to signed peers.
+func TestAuthorizedFetchHandler(t *testing.T) {
+	setupData()
+	ctx := context.Background()
+	const testFollowersIRI = "https://example.com/addison/followers"
+	setupFn := func(ctl *gomock.Controller) (db *MockDatabase, clock *MockClock, fp *MockFederatingProtocol, v *MockRequestVerifier, tp *MockTransport, hf HandlerFunc) {
+		db = NewMockDatabase(ctl)
+		clock = NewMockClock(ctl)
+		fp = NewMockFederatingProtocol(ctl)
+		v = NewMockRequestVerifier(ctl)
+		tp = NewMockTransport(ctl)
+		hf = NewAuthorizedFetchHandler(db, clock, fp, v, tp)
+		return
+	}
+	newNoteFn := func(to ...string) vocab.ActivityStreamsNote {
+		n := streams.NewActivityStreamsNote()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNoteId1))
+		n.SetJSONLDId(id)
+		at := streams.NewActivityStreamsAttributedToProperty()
+		at.AppendIRI(mustParse(testMyActorIRI))
+		n.SetActivityStreamsAttributedTo(at)
+		toProp := streams.NewActivityStreamsToProperty()
+		for _, iri := range to {
+			toProp.AppendIRI(mustParse(iri))
+		}
+		n.SetActivityStreamsTo(toProp)
+		return n
+	}
+	newFollowersFn := func(ids ...string) vocab.ActivityStreamsCollection {
+		col := streams.NewActivityStreamsCollection()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFollowersIRI))
+		col.SetJSONLDId(id)
+		items := streams.NewActivityStreamsItemsProperty()
+		for _, id := range ids {
+			items.AppendIRI(mustParse(id))
+		}
+		col.SetActivityStreamsItems(items)
+		return col
+	}
+	expectGetFn := func(db *MockDatabase, t vocab.Type) {
+		db.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		db.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(t, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+	}
+	expectSignerFn := func(fp *MockFederatingProtocol, v *MockRequestVerifier, tp *MockTransport, req *http.Request) {
+		v.EXPECT().VerifyRequest(ctx, req, tp).Return(mustParse(testFederatedActorIRI), true, nil)
+		fp.EXPECT().Blocked(ctx, []*url.URL{mustParse(testFederatedActorIRI)}).Return(false, nil)
+	}
+	t.Run("ServesActorWithoutSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db, clock, _, _, _, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		// Mock
+		expectGetFn(db, testMyPerson)
+		clock.EXPECT().Now().Return(now())
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("UnauthorizedWithoutSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db, _, _, v, tp, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		// Mock
+		expectGetFn(db, newNoteFn(PublicActivityPubIRI))
+		v.EXPECT().VerifyRequest(ctx, req, tp).Return(nil, false, nil)
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("ForbiddenIfSignerBlocked", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db, _, fp, v, tp, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		// Mock
+		expectGetFn(db, newNoteFn(PublicActivityPubIRI))
+		v.EXPECT().VerifyRequest(ctx, req, tp).Return(mustParse(testFederatedActorIRI), true, nil)
+		fp.EXPECT().Blocked(ctx, []*url.URL{mustParse(testFederatedActorIRI)}).Return(true, nil)
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusForbidden)
+	})
+	t.Run("ServesPublicValue", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db, clock, fp, v, tp, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		note := newNoteFn(PublicActivityPubIRI)
+		// Mock
+		expectGetFn(db, note)
+		expectSignerFn(fp, v, tp, req)
+		clock.EXPECT().Now().Return(now())
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, mustSerializeToBytes(note))
+	})
+	t.Run("ServesAddressedSigner", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db, clock, fp, v, tp, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		// Mock
+		expectGetFn(db, newNoteFn(testFederatedActorIRI))
+		expectSignerFn(fp, v, tp, req)
+		clock.EXPECT().Now().Return(now())
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("ServesFollowerOfAuthor", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db, clock, fp, v, tp, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		// Mock
+		expectGetFn(db, newNoteFn(testFollowersIRI))
+		expectSignerFn(fp, v, tp, req)
+		db.EXPECT().Lock(ctx, mustParse(testMyActorIRI))
+		db.EXPECT().Owns(ctx, mustParse(testMyActorIRI)).Return(true, nil)
+		db.EXPECT().Followers(ctx, mustParse(testMyActorIRI)).Return(newFollowersFn(testFederatedActorIRI), nil)
+		db.EXPECT().Unlock(ctx, mustParse(testMyActorIRI))
+		clock.EXPECT().Now().Return(now())
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("NotFoundForUnaddressedSigner", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db, _, fp, v, tp, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		// Mock
+		expectGetFn(db, newNoteFn(testFederatedActorIRI2))
+		expectSignerFn(fp, v, tp, req)
+		db.EXPECT().Lock(ctx, mustParse(testMyActorIRI))
+		db.EXPECT().Owns(ctx, mustParse(testMyActorIRI)).Return(true, nil)
+		db.EXPECT().Followers(ctx, mustParse(testMyActorIRI)).Return(newFollowersFn(testFederatedActorIRI), nil)
+		db.EXPECT().Unlock(ctx, mustParse(testMyActorIRI))
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusNotFound)
+	})
+}
+
+// testETag returns the ETag served with the value.
+func testETag(t vocab.Type) string {
+	sum := sha256.Sum256(mustSerializeToBytes(t))
+	return "\"" + hex.EncodeToString(sum[:]) + "\""
 }
@@ -0,0 +1,173 @@
+package pub
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-fed/httpsig"
+)
+
+const (
+	// The "hs2019" draft-cavage HTTP Signature algorithm, whose actual
+	// algorithm is determined from the key.
+	hs2019Algorithm = "hs2019"
+	// The Authorization header.
+	authorizationHeader = "Authorization"
+)
+
+// httpsig.Signer must be implemented by Hs2019Signer.
+var _ httpsig.Signer = &Hs2019Signer{}
+
+// Hs2019Signer signs requests with draft-cavage HTTP Signatures using the
+// "hs2019" algorithm, where the signature algorithm is determined from the
+// private key: RSASSA-PKCS1-v1_5 using SHA-256 for *rsa.PrivateKey, and
+// Ed25519 for ed25519.PrivateKey.
+//
+// It satisfies the httpsig.Signer interface so that it may be given to an
+// HttpSigTransport, allowing actors with Ed25519 keys to sign requests, which
+// the httpsig package's signers do not support. It is safe to use
+// concurrently.
+type Hs2019Signer struct {
+	headers []string
+}
+
+// NewHs2019Signer returns a new Hs2019Signer that signs the headers, which may
+// include the "(request-target)" pseudo-header. Header names must be
+// lowercase, and every header must be present in the requests being signed.
+func NewHs2019Signer(headers []string) *Hs2019Signer {
+	return &Hs2019Signer{
+		headers: headers,
+	}
+}
+
+// SignRequest signs the request with the private key, identifying it to the
+// peer with the public key id, and sets the Signature header.
+//
+// Unlike the httpsig package's signers, no Digest of the body is added. The
+// Digest header must already be set on the request if it is signed.
+func (s *Hs2019Signer) SignRequest(pKey crypto.PrivateKey, pubKeyId string, r *http.Request, body []byte) error {
+	signingString, err := cavageSigningString(r, s.headers)
+	if err != nil {
+		return err
+	}
+	var sig []byte
+	switch k := pKey.(type) {
+	case *rsa.PrivateKey:
+		sig, err = MessageSigRsaV15Sha256.sign(k, []byte(signingString))
+	case ed25519.PrivateKey:
+		sig, err = MessageSigEd25519.sign(k, []byte(signingString))
+	default:
+		err = fmt.Errorf("unsupported private key of type %T for hs2019", pKey)
+	}
+	if err != nil {
+		return err
+	}
+	r.Header.Set(signatureHeader, `keyId="`+pubKeyId+
+		`",algorithm="`+hs2019Algorithm+
+		`",headers="`+strings.Join(s.headers, " ")+
+		`",signature="`+base64.StdEncoding.EncodeToString(sig)+`"`)
+	return nil
+}
+
+// SignResponse is not supported, as only requests are signed by this library.
+func (s *Hs2019Signer) SignResponse(pKey crypto.PrivateKey, pubKeyId string, r http.ResponseWriter, body []byte) error {
+	return fmt.Errorf("signing responses with hs2019 HTTP Signatures is not supported")
+}
+
+// cavageSignature is a parsed draft-cavage HTTP Signature.
+type cavageSignature struct {
+	keyId     string
+	headers   []string
+	signature []byte
+}
+
+// parseCavageSignature parses the draft-cavage HTTP Signature in the Signature
+// header, or else in the Authorization header.
+func parseCavageSignature(h http.Header) (*cavageSignature, error) {
+	v := h.Get(signatureHeader)
+	if len(v) == 0 {
+		v = strings.TrimPrefix(h.Get(authorizationHeader), "Signature ")
+	}
+	s := &cavageSignature{
+		// The default when no headers are given.
+		headers: []string{"date"},
+	}
+	for len(v) > 0 {
+		eq := strings.IndexByte(v, '=')
+		if eq < 0 || eq+1 >= len(v) || v[eq+1] != '"' {
+			return nil, fmt.Errorf("malformed http signature parameter at %q", v)
+		}
+		name := strings.TrimSpace(v[:eq])
+		end := strings.IndexByte(v[eq+2:], '"')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated http signature parameter %q", name)
+		}
+		value := v[eq+2 : eq+2+end]
+		v = strings.TrimPrefix(strings.TrimSpace(v[eq+2+end+1:]), ",")
+		switch name {
+		case "keyId":
+			s.keyId = value
+		case "headers":
+			s.headers = strings.Fields(strings.ToLower(value))
+		case "signature":
+			b, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, err
+			}
+			s.signature = b
+		}
+	}
+	if len(s.keyId) == 0 || len(s.signature) == 0 {
+		return nil, fmt.Errorf("http signature has no keyId or signature")
+	}
+	return s, nil
+}
+
+// verify determines whether the signature of the request verifies with an
+// Ed25519 public key. Other keys are verified by the httpsig package.
+func (s *cavageSignature) verify(r *http.Request, pubKey crypto.PublicKey) bool {
+	k, ok := pubKey.(ed25519.PublicKey)
+	if !ok {
+		return false
+	}
+	signingString, err := cavageSigningString(r, s.headers)
+	if err != nil {
+		return false
+	}
+	return MessageSigEd25519.verify(k, []byte(signingString), s.signature)
+}
+
+// cavageSigningString builds the draft-cavage signing string of the request
+// for the headers.
+func cavageSigningString(r *http.Request, headers []string) (string, error) {
+	lines := make([]string, 0, len(headers))
+	for _, h := range headers {
+		var v string
+		switch h {
+		case httpsig.RequestTarget:
+			v = strings.ToLower(r.Method) + " " + r.URL.RequestURI()
+		case "(created)", "(expires)":
+			return "", fmt.Errorf("unsupported pseudo-header %q", h)
+		default:
+			values := r.Header[http.CanonicalHeaderKey(h)]
+			if len(values) == 0 && h == "host" && len(r.Host) > 0 {
+				values = []string{r.Host}
+			}
+			if len(values) == 0 {
+				return "", fmt.Errorf("header %q is not present", h)
+			}
+			trimmed := make([]string, len(values))
+			for i, value := range values {
+				trimmed[i] = strings.TrimSpace(value)
+			}
+			v = strings.Join(trimmed, ", ")
+		}
+		lines = append(lines, h+": "+v)
+	}
+	return strings.Join(lines, "\n"), nil
+}
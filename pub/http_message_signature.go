@@ -0,0 +1,582 @@
+package pub
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-fed/httpsig"
+)
+
+const (
+	// The Signature-Input header of RFC 9421.
+	signatureInputHeader = "Signature-Input"
+	// The Signature header, which RFC 9421 shares with draft-cavage HTTP
+	// Signatures.
+	signatureHeader = "Signature"
+	// The Accept-Signature header, with which a peer requests RFC 9421
+	// signatures.
+	acceptSignatureHeader = "Accept-Signature"
+	// The label of the signature created by an HttpMessageSigner.
+	messageSignatureLabel = "sig1"
+	// The name of the signature parameters component.
+	signatureParamsComponent = "@signature-params"
+)
+
+// MessageSignatureAlgorithm is an algorithm from the HTTP Signature Algorithms
+// registry of RFC 9421.
+type MessageSignatureAlgorithm string
+
+const (
+	// MessageSigRsaV15Sha256 is RSASSA-PKCS1-v1_5 using SHA-256, the
+	// algorithm of most fediverse RSA keys.
+	MessageSigRsaV15Sha256 MessageSignatureAlgorithm = "rsa-v1_5-sha256"
+	// MessageSigRsaPssSha512 is RSASSA-PSS using SHA-512.
+	MessageSigRsaPssSha512 MessageSignatureAlgorithm = "rsa-pss-sha512"
+	// MessageSigEcdsaP256Sha256 is ECDSA using curve P-256 and SHA-256.
+	MessageSigEcdsaP256Sha256 MessageSignatureAlgorithm = "ecdsa-p256-sha256"
+	// MessageSigEd25519 is EdDSA using curve Ed25519.
+	MessageSigEd25519 MessageSignatureAlgorithm = "ed25519"
+)
+
+// messageSignatureAlgorithmsForKey returns the algorithms that may be used
+// with the key, in order of preference. This is how an unspecified algorithm,
+// including the draft-cavage "hs2019" algorithm, is determined from the key.
+func messageSignatureAlgorithmsForKey(key interface{}) []MessageSignatureAlgorithm {
+	switch key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return []MessageSignatureAlgorithm{MessageSigRsaV15Sha256, MessageSigRsaPssSha512}
+	case *ecdsa.PrivateKey, *ecdsa.PublicKey:
+		return []MessageSignatureAlgorithm{MessageSigEcdsaP256Sha256}
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return []MessageSignatureAlgorithm{MessageSigEd25519}
+	default:
+		return nil
+	}
+}
+
+// sign signs the signature base with the private key.
+func (a MessageSignatureAlgorithm) sign(key crypto.PrivateKey, base []byte) ([]byte, error) {
+	switch a {
+	case MessageSigRsaV15Sha256:
+		if k, ok := key.(*rsa.PrivateKey); ok {
+			return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, hashOf(crypto.SHA256, base))
+		}
+	case MessageSigRsaPssSha512:
+		if k, ok := key.(*rsa.PrivateKey); ok {
+			return rsa.SignPSS(rand.Reader, k, crypto.SHA512, hashOf(crypto.SHA512, base), &rsa.PSSOptions{SaltLength: 64})
+		}
+	case MessageSigEcdsaP256Sha256:
+		if k, ok := key.(*ecdsa.PrivateKey); ok {
+			r, s, err := ecdsa.Sign(rand.Reader, k, hashOf(crypto.SHA256, base))
+			if err != nil {
+				return nil, err
+			}
+			// The signature is the fixed size concatenation of r and s.
+			sig := make([]byte, 64)
+			r.FillBytes(sig[:32])
+			s.FillBytes(sig[32:])
+			return sig, nil
+		}
+	case MessageSigEd25519:
+		if k, ok := key.(ed25519.PrivateKey); ok {
+			return ed25519.Sign(k, base), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported message signature algorithm %q", a)
+	}
+	return nil, fmt.Errorf("private key of type %T cannot be used with algorithm %q", key, a)
+}
+
+// verify determines whether the signature of the signature base verifies with
+// the public key.
+func (a MessageSignatureAlgorithm) verify(key crypto.PublicKey, base, sig []byte) bool {
+	switch a {
+	case MessageSigRsaV15Sha256:
+		k, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(k, crypto.SHA256, hashOf(crypto.SHA256, base), sig) == nil
+	case MessageSigRsaPssSha512:
+		k, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPSS(k, crypto.SHA512, hashOf(crypto.SHA512, base), sig, &rsa.PSSOptions{SaltLength: 64}) == nil
+	case MessageSigEcdsaP256Sha256:
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(sig) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(k, hashOf(crypto.SHA256, base), r, s)
+	case MessageSigEd25519:
+		k, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(k, base, sig)
+	default:
+		return false
+	}
+}
+
+// hashOf returns the hash of the bytes.
+func hashOf(h crypto.Hash, b []byte) []byte {
+	hasher := h.New()
+	hasher.Write(b)
+	return hasher.Sum(nil)
+}
+
+// httpsig.Signer must be implemented by HttpMessageSigner.
+var _ httpsig.Signer = &HttpMessageSigner{}
+
+// HttpMessageSigner signs requests with RFC 9421 HTTP Message Signatures,
+// setting the Signature-Input and Signature headers.
+//
+// It satisfies the httpsig.Signer interface so that it may be given to an
+// HttpSigTransport in place of a draft-cavage HTTP Signature signer. Unlike
+// those signers, it is safe to use concurrently.
+type HttpMessageSigner struct {
+	clock      Clock
+	algo       MessageSignatureAlgorithm
+	components []string
+}
+
+// NewHttpMessageSigner returns a new HttpMessageSigner.
+//
+// The components are the RFC 9421 component identifiers covered by the
+// signature, such as "@method", "@target-uri" or "content-digest". Field
+// names must be lowercase. Every component must be present in the requests
+// being signed.
+//
+// If the algorithm is empty, it is determined from the type of the private
+// key, preferring MessageSigRsaV15Sha256 for RSA keys.
+func NewHttpMessageSigner(clock Clock, algo MessageSignatureAlgorithm, components []string) *HttpMessageSigner {
+	return &HttpMessageSigner{
+		clock:      clock,
+		algo:       algo,
+		components: components,
+	}
+}
+
+// SignRequest signs the request with the private key, identifying it to the
+// peer with the public key id.
+//
+// Unlike the draft-cavage signers, no digest of the body is added. The
+// Content-Digest header must already be set on the request if it is one of
+// the covered components.
+func (s *HttpMessageSigner) SignRequest(pKey crypto.PrivateKey, pubKeyId string, r *http.Request, body []byte) error {
+	algo := s.algo
+	if len(algo) == 0 {
+		algos := messageSignatureAlgorithmsForKey(pKey)
+		if len(algos) == 0 {
+			return fmt.Errorf("unsupported private key of type %T", pKey)
+		}
+		algo = algos[0]
+	}
+	input := &messageSignatureInput{
+		components: s.components,
+		params: []sfParam{
+			{name: "created", value: s.clock.Now().Unix()},
+			{name: "keyid", value: pubKeyId},
+			{name: "alg", value: string(algo)},
+		},
+	}
+	base, err := messageSignatureBase(r, input)
+	if err != nil {
+		return err
+	}
+	sig, err := algo.sign(pKey, base)
+	if err != nil {
+		return err
+	}
+	r.Header.Set(signatureInputHeader, messageSignatureLabel+"="+input.serialize())
+	r.Header.Set(signatureHeader, messageSignatureLabel+"=:"+base64.StdEncoding.EncodeToString(sig)+":")
+	return nil
+}
+
+// SignResponse is not supported, as only requests are signed with HTTP
+// Message Signatures by this library.
+func (s *HttpMessageSigner) SignResponse(pKey crypto.PrivateKey, pubKeyId string, r http.ResponseWriter, body []byte) error {
+	return fmt.Errorf("signing responses with HTTP Message Signatures is not supported")
+}
+
+// messageSignatureInput is a member of the Signature-Input dictionary: the
+// covered components and the signature parameters.
+type messageSignatureInput struct {
+	components []string
+	params     []sfParam
+}
+
+// sfParam is a structured field parameter. Its value is an int64, string or
+// bool.
+type sfParam struct {
+	name  string
+	value interface{}
+}
+
+// param returns the value of the named parameter, if present.
+func (m *messageSignatureInput) param(name string) (interface{}, bool) {
+	for _, p := range m.params {
+		if p.name == name {
+			return p.value, true
+		}
+	}
+	return nil, false
+}
+
+// stringParam returns the value of the named string parameter, if present.
+func (m *messageSignatureInput) stringParam(name string) (string, bool) {
+	v, ok := m.param(name)
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// intParam returns the value of the named integer parameter, if present.
+func (m *messageSignatureInput) intParam(name string) (int64, bool) {
+	v, ok := m.param(name)
+	if !ok {
+		return 0, false
+	}
+	i, ok := v.(int64)
+	return i, ok
+}
+
+// covers determines whether the component is covered by the signature.
+func (m *messageSignatureInput) covers(component string) bool {
+	for _, c := range m.components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// serialize returns the inner list serialization of the input, which is both
+// its Signature-Input value and its '@signature-params' component value.
+func (m *messageSignatureInput) serialize() string {
+	var b strings.Builder
+	b.WriteString("(")
+	for i, c := range m.components {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(sfString(c))
+	}
+	b.WriteString(")")
+	for _, p := range m.params {
+		b.WriteString(";")
+		b.WriteString(p.name)
+		switch v := p.value.(type) {
+		case int64:
+			b.WriteString("=")
+			b.WriteString(strconv.FormatInt(v, 10))
+		case string:
+			b.WriteString("=")
+			b.WriteString(sfString(v))
+		case bool:
+			if !v {
+				b.WriteString("=?0")
+			}
+		}
+	}
+	return b.String()
+}
+
+// sfString serializes a structured field string.
+func sfString(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
+
+// messageSignatureBase builds the RFC 9421 signature base of the request for
+// the signature input.
+func messageSignatureBase(r *http.Request, input *messageSignatureInput) ([]byte, error) {
+	var b strings.Builder
+	seen := make(map[string]bool, len(input.components))
+	for _, c := range input.components {
+		if seen[c] {
+			return nil, fmt.Errorf("component %q is covered more than once", c)
+		}
+		seen[c] = true
+		v, err := messageComponentValue(r, c)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(sfString(c))
+		b.WriteString(": ")
+		b.WriteString(v)
+		b.WriteString("\n")
+	}
+	b.WriteString(sfString(signatureParamsComponent))
+	b.WriteString(": ")
+	b.WriteString(input.serialize())
+	return []byte(b.String()), nil
+}
+
+// messageComponentValue returns the value of a derived component or field of
+// the request.
+//
+// The target URI of a request received by a server is interpreted as having
+// an HTTPS protocol scheme.
+func messageComponentValue(r *http.Request, component string) (string, error) {
+	u := *r.URL
+	if len(u.Host) == 0 {
+		u.Host = r.Host
+	}
+	if len(u.Scheme) == 0 {
+		u.Scheme = "https"
+	}
+	switch component {
+	case "@method":
+		return r.Method, nil
+	case "@target-uri":
+		return u.String(), nil
+	case "@authority":
+		return strings.ToLower(u.Host), nil
+	case "@scheme":
+		return strings.ToLower(u.Scheme), nil
+	case "@request-target":
+		return u.RequestURI(), nil
+	case "@path":
+		if p := u.EscapedPath(); len(p) > 0 {
+			return p, nil
+		}
+		return "/", nil
+	case "@query":
+		return "?" + u.RawQuery, nil
+	}
+	if strings.HasPrefix(component, "@") || component != strings.ToLower(component) {
+		return "", fmt.Errorf("unsupported component %q", component)
+	}
+	values := r.Header[http.CanonicalHeaderKey(component)]
+	if len(values) == 0 && component == "host" && len(r.Host) > 0 {
+		values = []string{r.Host}
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("component %q is not present", component)
+	}
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(trimmed, ", "), nil
+}
+
+// parseSignatureInputs parses the Signature-Input headers into the labelled
+// inputs, in the order they appear.
+func parseSignatureInputs(h http.Header) (labels []string, inputs map[string]*messageSignatureInput, err error) {
+	p := &sfParser{s: strings.Join(h[http.CanonicalHeaderKey(signatureInputHeader)], ", ")}
+	inputs = make(map[string]*messageSignatureInput)
+	err = p.dictionary(func(label string) error {
+		in := &messageSignatureInput{}
+		if err := p.consume('('); err != nil {
+			return err
+		}
+		for {
+			p.skipSpaces()
+			if p.peek() == ')' {
+				p.i++
+				break
+			}
+			c, err := p.str()
+			if err != nil {
+				return err
+			}
+			// Component parameters, such as 'sf' or 'req', are
+			// not supported.
+			if p.peek() == ';' {
+				return fmt.Errorf("unsupported parameters on component %q", c)
+			}
+			in.components = append(in.components, c)
+		}
+		if in.params, err = p.params(); err != nil {
+			return err
+		}
+		if _, dup := inputs[label]; !dup {
+			labels = append(labels, label)
+		}
+		inputs[label] = in
+		return nil
+	})
+	return
+}
+
+// parseSignatures parses the Signature headers of RFC 9421 into the labelled
+// signatures.
+func parseSignatures(h http.Header) (map[string][]byte, error) {
+	p := &sfParser{s: strings.Join(h[http.CanonicalHeaderKey(signatureHeader)], ", ")}
+	sigs := make(map[string][]byte)
+	err := p.dictionary(func(label string) error {
+		if err := p.consume(':'); err != nil {
+			return err
+		}
+		end := strings.IndexByte(p.s[p.i:], ':')
+		if end < 0 {
+			return fmt.Errorf("unterminated byte sequence")
+		}
+		b, err := base64.StdEncoding.DecodeString(p.s[p.i : p.i+end])
+		if err != nil {
+			return err
+		}
+		p.i += end + 1
+		if _, err = p.params(); err != nil {
+			return err
+		}
+		sigs[label] = b
+		return nil
+	})
+	return sigs, err
+}
+
+// sfParser parses the subset of RFC 8941 structured fields used by the
+// Signature-Input and Signature headers.
+type sfParser struct {
+	s string
+	i int
+}
+
+// peek returns the next byte, or zero at the end of the input.
+func (p *sfParser) peek() byte {
+	if p.i >= len(p.s) {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+// skipSpaces skips spaces and tabs.
+func (p *sfParser) skipSpaces() {
+	for p.peek() == ' ' || p.peek() == '\t' {
+		p.i++
+	}
+}
+
+// consume skips the expected byte, or returns an error if it is not next.
+func (p *sfParser) consume(b byte) error {
+	if p.peek() != b {
+		return fmt.Errorf("expected %q at offset %d", b, p.i)
+	}
+	p.i++
+	return nil
+}
+
+// dictionary parses a dictionary, calling the member function to parse the
+// value of each member after its key and '='.
+func (p *sfParser) dictionary(member func(key string) error) error {
+	p.skipSpaces()
+	for p.i < len(p.s) {
+		key, err := p.key()
+		if err != nil {
+			return err
+		}
+		if err = p.consume('='); err != nil {
+			return err
+		}
+		if err = member(key); err != nil {
+			return err
+		}
+		p.skipSpaces()
+		if p.i >= len(p.s) {
+			break
+		}
+		if err = p.consume(','); err != nil {
+			return err
+		}
+		p.skipSpaces()
+	}
+	return nil
+}
+
+// key parses a dictionary or parameter key.
+func (p *sfParser) key() (string, error) {
+	start := p.i
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if (c >= 'a' && c <= 'z') || c == '*' ||
+			(p.i > start && ((c >= '0' && c <= '9') || c == '_' || c == '-' || c == '.')) {
+			p.i++
+			continue
+		}
+		break
+	}
+	if p.i == start {
+		return "", fmt.Errorf("expected key at offset %d", start)
+	}
+	return p.s[start:p.i], nil
+}
+
+// str parses a string.
+func (p *sfParser) str() (string, error) {
+	if err := p.consume('"'); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		p.i++
+		switch c {
+		case '\\':
+			if p.i >= len(p.s) {
+				return "", fmt.Errorf("unterminated string")
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		case '"':
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// params parses parameters with integer, string, token or boolean values.
+func (p *sfParser) params() ([]sfParam, error) {
+	var params []sfParam
+	for p.peek() == ';' {
+		p.i++
+		p.skipSpaces()
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		param := sfParam{name: name, value: true}
+		if p.peek() == '=' {
+			p.i++
+			switch c := p.peek(); {
+			case c == '"':
+				if param.value, err = p.str(); err != nil {
+					return nil, err
+				}
+			case c == '?':
+				p.i++
+				param.value = p.peek() == '1'
+				p.i++
+			case c == '-' || (c >= '0' && c <= '9'):
+				start := p.i
+				p.i++
+				for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+					p.i++
+				}
+				if param.value, err = strconv.ParseInt(p.s[start:p.i], 10, 64); err != nil {
+					return nil, err
+				}
+			default:
+				// A token, which is kept as a string.
+				start := p.i
+				for c := p.peek(); c != 0 && c != ';' && c != ',' && c != ' ' && c != ')'; c = p.peek() {
+					p.i++
+				}
+				param.value = p.s[start:p.i]
+			}
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
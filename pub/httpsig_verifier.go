@@ -0,0 +1,643 @@
+package pub
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/go-fed/httpsig"
+)
+
+// contextKey is the type of keys this library stores in a context.Context.
+type contextKey string
+
+const (
+	// httpSigSignerContextKey is the context key under which the IRI of a
+	// verified HTTP Signature's key owner is stored.
+	httpSigSignerContextKey contextKey = "httpSigSigner"
+	// ldSignatureSignerContextKey is the context key under which the IRI
+	// of a verified Linked Data Signature's key owner is stored.
+	ldSignatureSignerContextKey contextKey = "ldSignatureSigner"
+	// receivedActivityContextKey is the context key under which the raw
+	// body an activity was received in is stored for inbox forwarding.
+	receivedActivityContextKey contextKey = "receivedActivity"
+	// sharedInboxDeliveryContextKey is the context key under which the
+	// delivery of an activity posted to a shared inbox is tracked.
+	sharedInboxDeliveryContextKey contextKey = "sharedInboxDelivery"
+)
+
+const (
+	// The greatest difference allowed between the time a request was signed
+	// and the current time, in either direction.
+	httpSigMaxClockSkew = time.Hour
+)
+
+// HttpSigSigner returns the IRI of the actor whose HTTP Signature was verified
+// by an HttpSigVerifier for this request, if any.
+func HttpSigSigner(c context.Context) (signer *url.URL, ok bool) {
+	signer, ok = c.Value(httpSigSignerContextKey).(*url.URL)
+	return
+}
+
+// LDSignatureSigner returns the IRI of the actor whose Linked Data Signature
+// authenticated a forwarded activity in an HttpSigVerifier for this request,
+// if any.
+func LDSignatureSigner(c context.Context) (signer *url.URL, ok bool) {
+	signer, ok = c.Value(ldSignatureSignerContextKey).(*url.URL)
+	return
+}
+
+// HttpSigVerifier must satisfy the RequestVerifier interface.
+var _ RequestVerifier = &HttpSigVerifier{}
+
+// HttpSigVerifier verifies the HTTP Signatures of incoming requests on behalf
+// of a FederatingProtocol implementation.
+//
+// The public key is obtained by dereferencing the 'keyId' of the signature,
+// which may either be the PublicKey or Multikey itself, or an actor with the
+// key in its 'publicKey' or 'assertionMethod' property. The key's 'owner' or
+// 'controller' must be the actor that sent the request. Ed25519 Multikeys are
+// verified regardless of the configured algorithms.
+//
+// Optionally, an activity forwarded by another server may be authenticated by
+// its author's Linked Data Signature instead.
+//
+// It is safe to use concurrently.
+type HttpSigVerifier struct {
+	clock Clock
+	algos []httpsig.Algorithm
+	ld    *RsaSignature2017Verifier
+}
+
+// NewHttpSigVerifier returns a new HttpSigVerifier.
+//
+// The Clock is used to reject signed requests whose Date, or whose signature's
+// 'created' time, is not within httpSigMaxClockSkew of the current time, and
+// signatures that have expired, limiting how long a captured request can be
+// replayed.
+//
+// The algorithms are tried in order when verifying a signature. If none are
+// provided, then httpsig.RSA_SHA256 is used, which is the algorithm used by
+// the majority of the fediverse.
+func NewHttpSigVerifier(clock Clock, algos []httpsig.Algorithm) *HttpSigVerifier {
+	if len(algos) == 0 {
+		algos = []httpsig.Algorithm{httpsig.RSA_SHA256}
+	}
+	return &HttpSigVerifier{
+		clock: clock,
+		algos: algos,
+	}
+}
+
+// NewHttpSigVerifierWithLDSignatures returns a new HttpSigVerifier that also
+// accepts activities forwarded by other servers, when the activity has a
+// Linked Data Signature by one of its actors that the RsaSignature2017Verifier
+// verifies.
+func NewHttpSigVerifierWithLDSignatures(clock Clock, algos []httpsig.Algorithm, ld *RsaSignature2017Verifier) *HttpSigVerifier {
+	v := NewHttpSigVerifier(clock, algos)
+	v.ld = ld
+	return v
+}
+
+// AuthenticatePostInbox verifies the HTTP Signature of a POST to an inbox, and
+// is suitable for use in FederatingProtocol's AuthenticatePostInbox.
+//
+// The Transport is used to dereference the public key, and should be obtained
+// from CommonBehavior's NewTransport for the inbox being posted to.
+//
+// In addition to verifying the signature, the owner of the public key must be
+// one of the actors of the Activity in the request body. The request body is
+// restored after reading so it can be processed later on.
+//
+// If the key owner is not an actor of the Activity, such as when it has been
+// forwarded, and the HttpSigVerifier accepts Linked Data Signatures, then the
+// Activity must instead have a Linked Data Signature by one of its actors.
+//
+// If successful, the returned context contains the key owner's IRI, which can
+// be obtained with HttpSigSigner, and the IRI of the Linked Data Signature's
+// key owner if one was used, which can be obtained with LDSignatureSigner. If
+// the request is not authentic, then the
+// http.StatusUnauthorized status is written to the response.
+func (v *HttpSigVerifier) AuthenticatePostInbox(c context.Context, w http.ResponseWriter, r *http.Request, t Transport) (out context.Context, authenticated bool, err error) {
+	out = c
+	var raw []byte
+	if r.Body != nil {
+		raw, err = ioutil.ReadAll(r.Body)
+		if err != nil {
+			return
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(raw))
+	}
+	signer, verified, err := v.VerifyRequest(c, r, t)
+	if err != nil {
+		return
+	} else if !verified || !hasBodyDigest(r.Header, raw) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	// Ensure the signer is the actor of the activity.
+	var m map[string]interface{}
+	if err = json.Unmarshal(raw, &m); err != nil {
+		// Respond with bad request -- the body is not JSON.
+		err = nil
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	asValue, err := streams.ToType(c, m)
+	if err != nil && !streams.IsUnmatchedErr(err) {
+		return
+	} else if streams.IsUnmatchedErr(err) {
+		// Respond with bad request -- we do not understand the type.
+		err = nil
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var actorIds []*url.URL
+	if ac, ok := asValue.(actorer); ok {
+		if actors := ac.GetActivityStreamsActor(); actors != nil {
+			for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+				var id *url.URL
+				id, err = ToId(iter)
+				if err != nil {
+					return
+				}
+				actorIds = append(actorIds, id)
+			}
+		}
+	}
+	var ldSigner *url.URL
+	if !containsIRI(actorIds, signer) {
+		// Forwarded activities are authenticated by their author's
+		// Linked Data Signature instead.
+		var verified bool
+		if v.ld != nil {
+			ldSigner, verified, err = v.ld.VerifyLD(c, m, t)
+			if err != nil {
+				return
+			}
+		}
+		if !verified || !containsIRI(actorIds, ldSigner) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+	out = context.WithValue(c, httpSigSignerContextKey, signer)
+	if ldSigner != nil {
+		out = context.WithValue(out, ldSignatureSignerContextKey, ldSigner)
+	}
+	authenticated = true
+	return
+}
+
+// VerifyRequest verifies the HTTP Signature on the request and returns the
+// IRI of the owner of the key that signed it.
+//
+// Requests with a Signature-Input header are verified as RFC 9421 HTTP
+// Message Signatures, and otherwise as draft-cavage HTTP Signatures. The
+// deprecated draft-cavage 'algorithm' parameter, including "hs2019", is
+// ignored in favor of trying the configured algorithms.
+//
+// A draft-cavage signature must cover the "(request-target)", "host" and
+// "date" headers, as well as the "digest" of POST requests, and the Date must
+// be within httpSigMaxClockSkew of the current time. Likewise, an HTTP Message
+// Signature must have a 'created' time within httpSigMaxClockSkew of the
+// current time, and must not have expired.
+//
+// A missing or malformed signature, a signature that does not verify, or a
+// key without an owner result in verified being false and a nil error. An
+// error is only returned when the public key cannot be obtained.
+func (v *HttpSigVerifier) VerifyRequest(c context.Context, r *http.Request, t Transport) (signer *url.URL, verified bool, err error) {
+	if len(r.Header.Get(signatureInputHeader)) > 0 {
+		return v.verifyMessageSignature(c, r, t)
+	}
+	r = withHostHeader(r)
+	verifier, err := httpsig.NewVerifier(r)
+	if err != nil {
+		// No or malformed signature.
+		err = nil
+		return
+	}
+	sig, perr := parseCavageSignature(r.Header)
+	if perr != nil || !cavageSignatureCoversRequest(r, sig) || !v.isFresh(r.Header) {
+		return
+	}
+	keyId, err := url.Parse(verifier.KeyId())
+	if err != nil {
+		err = nil
+		return
+	}
+	pubKey, owner, err := fetchPublicKey(c, t, keyId)
+	if err != nil {
+		return
+	} else if pubKey == nil || owner == nil {
+		return
+	}
+	// The httpsig package cannot verify Ed25519 signatures.
+	if _, ok := pubKey.(ed25519.PublicKey); ok {
+		if sig.verify(r, pubKey) {
+			signer = owner
+			verified = true
+		}
+		return
+	}
+	for _, algo := range v.algos {
+		if verifier.Verify(pubKey, algo) == nil {
+			signer = owner
+			verified = true
+			return
+		}
+	}
+	return
+}
+
+// isFresh determines whether the Date header is within httpSigMaxClockSkew of
+// the current time.
+func (v *HttpSigVerifier) isFresh(h http.Header) bool {
+	date, err := http.ParseTime(h.Get(dateHeader))
+	if err != nil {
+		return false
+	}
+	now := v.clock.Now()
+	return !date.Before(now.Add(-httpSigMaxClockSkew)) && !date.After(now.Add(httpSigMaxClockSkew))
+}
+
+// withHostHeader returns the request with its Host header, which servers move
+// to the request's Host where the httpsig package does not look for it.
+func withHostHeader(r *http.Request) *http.Request {
+	if len(r.Header.Get("Host")) > 0 || len(r.Host) == 0 {
+		return r
+	}
+	h := make(http.Header, len(r.Header)+1)
+	for k, v := range r.Header {
+		h[k] = v
+	}
+	h.Set("Host", r.Host)
+	r = r.WithContext(r.Context())
+	r.Header = h
+	return r
+}
+
+// cavageSignatureCoversRequest determines whether the draft-cavage signature
+// covers enough of the request to authenticate it, so that it cannot be
+// replayed against another target or with another body.
+func cavageSignatureCoversRequest(r *http.Request, sig *cavageSignature) bool {
+	required := []string{httpsig.RequestTarget, "host", "date"}
+	if r.Method == http.MethodPost {
+		required = append(required, "digest")
+	}
+	for _, h := range required {
+		covered := false
+		for _, signed := range sig.headers {
+			if signed == h {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// hasBodyDigest determines whether the Digest or Content-Digest headers have
+// at least one digest with a supported algorithm, and all of them match the
+// body. Together with a signature covering the header, this binds the
+// signature to the body.
+func hasBodyDigest(h http.Header, body []byte) bool {
+	return len(getDigestValues(h)) > 0 && verifyDigest(h, body, nil)
+}
+
+// verifyMessageSignature verifies the RFC 9421 HTTP Message Signatures on the
+// request, returning the owner of the key of the first one that verifies.
+//
+// A signature must cover the method and target of the request, as well as the
+// Content-Digest of POST requests, and must identify its key with the 'keyid'
+// parameter. Its 'created' parameter must be within httpSigMaxClockSkew of the
+// current time, and its 'expires' parameter, if any, must not have passed by
+// more than httpSigMaxClockSkew. If the signature has no 'alg' parameter, the
+// algorithm is determined from the type of the key.
+func (v *HttpSigVerifier) verifyMessageSignature(c context.Context, r *http.Request, t Transport) (signer *url.URL, verified bool, err error) {
+	labels, inputs, perr := parseSignatureInputs(r.Header)
+	if perr != nil {
+		return
+	}
+	sigs, perr := parseSignatures(r.Header)
+	if perr != nil {
+		return
+	}
+	for _, label := range labels {
+		input := inputs[label]
+		sig, ok := sigs[label]
+		if !ok || !messageSignatureCoversRequest(r, input) || !v.isFreshMessageSignature(input) {
+			continue
+		}
+		keyIdStr, ok := input.stringParam("keyid")
+		if !ok {
+			continue
+		}
+		keyId, perr := url.Parse(keyIdStr)
+		if perr != nil {
+			continue
+		}
+		base, perr := messageSignatureBase(r, input)
+		if perr != nil {
+			continue
+		}
+		var pubKey crypto.PublicKey
+		var owner *url.URL
+		pubKey, owner, err = fetchPublicKey(c, t, keyId)
+		if err != nil {
+			return
+		} else if pubKey == nil || owner == nil {
+			continue
+		}
+		algos := messageSignatureAlgorithmsForKey(pubKey)
+		if alg, ok := input.stringParam("alg"); ok {
+			algos = []MessageSignatureAlgorithm{MessageSignatureAlgorithm(alg)}
+		}
+		for _, algo := range algos {
+			if algo.verify(pubKey, base, sig) {
+				signer = owner
+				verified = true
+				return
+			}
+		}
+	}
+	return
+}
+
+// isFreshMessageSignature determines whether the signature input has a
+// 'created' time within httpSigMaxClockSkew of the current time, and has not
+// expired.
+func (v *HttpSigVerifier) isFreshMessageSignature(input *messageSignatureInput) bool {
+	created, ok := input.intParam("created")
+	if !ok {
+		return false
+	}
+	now := v.clock.Now()
+	createdTime := time.Unix(created, 0)
+	if createdTime.Before(now.Add(-httpSigMaxClockSkew)) || createdTime.After(now.Add(httpSigMaxClockSkew)) {
+		return false
+	}
+	if _, ok := input.param("expires"); ok {
+		expires, ok := input.intParam("expires")
+		if !ok || time.Unix(expires, 0).Before(now.Add(-httpSigMaxClockSkew)) {
+			return false
+		}
+	}
+	return true
+}
+
+// messageSignatureCoversRequest determines whether the signature input covers
+// enough of the request to authenticate it. The target of the request must be
+// covered either by '@target-uri', or by '@authority' along with '@path' or
+// '@request-target', so that the signature cannot be replayed to another host.
+func messageSignatureCoversRequest(r *http.Request, input *messageSignatureInput) bool {
+	coversPath := input.covers("@path") || input.covers("@request-target")
+	if !input.covers("@method") {
+		return false
+	} else if !input.covers("@target-uri") && !(input.covers("@authority") && coversPath) {
+		return false
+	} else if r.Method == http.MethodPost && !input.covers("content-digest") {
+		return false
+	}
+	return true
+}
+
+// fetchPublicKey dereferences the keyId and finds the public key and its owner.
+//
+// The keyId may resolve to the key itself, either a PublicKey or a Multikey,
+// or to an actor with the key in its 'publicKey' or 'assertionMethod'
+// property. In the latter case, the actor must be the owner of the key. An
+// actor may have several keys, in which case the one identified by the keyId
+// is used.
+//
+// The owner must be on the same host as the keyId. When the keyId resolves to
+// the key itself, the owner is dereferenced as well and must list the keyId
+// among its keys, since a key may otherwise claim any owner.
+//
+// Returns a nil key if the dereferenced value does not contain a usable key.
+func fetchPublicKey(c context.Context, t Transport, keyId *url.URL) (pubKey crypto.PublicKey, owner *url.URL, err error) {
+	asValue, err := dereferenceKeyDocument(c, t, keyId)
+	if err != nil {
+		return
+	}
+	standalone := false
+	switch v := asValue.(type) {
+	case vocab.W3IDSecurityV1PublicKey:
+		standalone = true
+		pubKey, owner, err = publicKeyAndOwner(v, nil)
+	case vocab.W3IDSecurityDataIntegrityV1Multikey:
+		standalone = true
+		pubKey, owner, err = multikeyAndController(v, nil)
+	default:
+		pubKey, owner, err = actorPublicKey(asValue, keyId)
+	}
+	if err != nil || pubKey == nil || owner == nil {
+		return
+	} else if owner.Host != keyId.Host {
+		return nil, nil, nil
+	}
+	if standalone {
+		var lists bool
+		lists, err = ownerListsKey(c, t, owner, keyId)
+		if err != nil || !lists {
+			return nil, nil, err
+		}
+	}
+	return
+}
+
+// dereferenceKeyDocument dereferences the keyId and deserializes the key or
+// actor document it resolves to.
+func dereferenceKeyDocument(c context.Context, t Transport, keyId *url.URL) (vocab.Type, error) {
+	b, err := t.Dereference(c, keyId)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	// Key documents served independently of the actor frequently omit the
+	// 'type', so supply the only one it can be.
+	if _, ok := m["type"]; !ok {
+		if _, ok := m["publicKeyPem"]; ok {
+			m["type"] = "PublicKey"
+		} else if _, ok := m["publicKeyMultibase"]; ok {
+			m["type"] = "Multikey"
+		}
+	}
+	return streams.ToType(c, m)
+}
+
+// actorPublicKey finds the key with the keyId in the actor's 'publicKey' or
+// 'assertionMethod' property, returning it if the actor is its owner.
+func actorPublicKey(asValue vocab.Type, keyId *url.URL) (pubKey crypto.PublicKey, owner *url.URL, err error) {
+	actorId, err := GetId(asValue)
+	if err != nil {
+		return
+	}
+	if pker, ok := asValue.(publicKeyer); ok {
+		if key := findPublicKey(pker.GetW3IDSecurityV1PublicKey(), keyId); key != nil {
+			return publicKeyAndOwner(key, actorId)
+		}
+	}
+	if amer, ok := asValue.(assertionMethoder); ok {
+		if key := findMultikey(amer.GetW3IDSecurityDataIntegrityV1AssertionMethod(), keyId); key != nil {
+			return multikeyAndController(key, actorId)
+		}
+	}
+	return
+}
+
+// ownerListsKey dereferences the owner of a key and determines whether the
+// keyId is in its 'publicKey' or 'assertionMethod' property, either as an IRI
+// or as an embedded key.
+func ownerListsKey(c context.Context, t Transport, owner, keyId *url.URL) (bool, error) {
+	asValue, err := dereferenceKeyDocument(c, t, owner)
+	if err != nil {
+		return false, err
+	}
+	if id, err := GetId(asValue); err != nil || id.String() != owner.String() {
+		return false, nil
+	}
+	var ids []*url.URL
+	if pker, ok := asValue.(publicKeyer); ok {
+		if p := pker.GetW3IDSecurityV1PublicKey(); p != nil {
+			for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+				if id, err := ToId(iter); err == nil {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	if amer, ok := asValue.(assertionMethoder); ok {
+		if p := amer.GetW3IDSecurityDataIntegrityV1AssertionMethod(); p != nil {
+			for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+				if id, err := ToId(iter); err == nil {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return containsIRI(ids, keyId), nil
+}
+
+// publicKeyAndOwner parses the PEM encoded key of a PublicKey and returns it
+// with its 'owner', which must be the actor if one is given.
+func publicKeyAndOwner(key vocab.W3IDSecurityV1PublicKey, actorId *url.URL) (pubKey crypto.PublicKey, owner *url.URL, err error) {
+	ownerProp := key.GetW3IDSecurityV1Owner()
+	if ownerProp == nil || ownerProp.Get() == nil {
+		return
+	}
+	if actorId != nil && actorId.String() != ownerProp.Get().String() {
+		return
+	}
+	pemProp := key.GetW3IDSecurityV1PublicKeyPem()
+	if pemProp == nil {
+		return
+	}
+	pubKey, err = parsePublicKeyPem(pemProp.Get())
+	if err != nil {
+		return
+	}
+	owner = ownerProp.Get()
+	return
+}
+
+// multikeyAndController parses the Multibase encoded key of a Multikey and
+// returns it with its 'controller', which must be the actor if one is given.
+func multikeyAndController(key vocab.W3IDSecurityDataIntegrityV1Multikey, actorId *url.URL) (pubKey crypto.PublicKey, controller *url.URL, err error) {
+	controllerProp := key.GetW3IDSecurityDataIntegrityV1Controller()
+	if controllerProp == nil || controllerProp.Get() == nil {
+		return
+	}
+	if actorId != nil && actorId.String() != controllerProp.Get().String() {
+		return
+	}
+	mbProp := key.GetW3IDSecurityDataIntegrityV1PublicKeyMultibase()
+	if mbProp == nil {
+		return
+	}
+	pubKey, err = parsePublicKeyMultibase(mbProp.Get())
+	if err != nil {
+		return
+	}
+	controller = controllerProp.Get()
+	return
+}
+
+// findPublicKey returns the embedded PublicKey with the given id.
+//
+// If the property has exactly one PublicKey and it has no id, it is returned
+// since there is no ambiguity about which key was meant.
+func findPublicKey(p vocab.W3IDSecurityV1PublicKeyProperty, keyId *url.URL) vocab.W3IDSecurityV1PublicKey {
+	if p == nil {
+		return nil
+	}
+	var only vocab.W3IDSecurityV1PublicKey
+	n := 0
+	for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+		if !iter.IsW3IDSecurityV1PublicKey() {
+			continue
+		}
+		pk := iter.Get()
+		n++
+		only = pk
+		id := pk.GetJSONLDId()
+		if id != nil && id.Get().String() == keyId.String() {
+			return pk
+		}
+	}
+	if n == 1 && only.GetJSONLDId() == nil {
+		return only
+	}
+	return nil
+}
+
+// findMultikey returns the embedded Multikey with the given id.
+//
+// Unlike the 'publicKey' property, the keys of an 'assertionMethod' must
+// always be identified by their id.
+func findMultikey(p vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty, keyId *url.URL) vocab.W3IDSecurityDataIntegrityV1Multikey {
+	if p == nil {
+		return nil
+	}
+	for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+		if !iter.IsW3IDSecurityDataIntegrityV1Multikey() {
+			continue
+		}
+		mk := iter.Get()
+		id := mk.GetJSONLDId()
+		if id != nil && id.Get().String() == keyId.String() {
+			return mk
+		}
+	}
+	return nil
+}
+
+// parsePublicKeyPem decodes a PEM encoded PKIX or PKCS1 public key.
+func parsePublicKeyPem(s string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, fmt.Errorf("publicKeyPem is not PEM encoded")
+	}
+	if pk, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return pk, nil
+	}
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
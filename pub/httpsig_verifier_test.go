@@ -0,0 +1,679 @@
+package pub
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/go-fed/httpsig"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testFederatedKeyId        = "https://other.example.com/dakota#main-key"
+	testFederatedEd25519KeyId = "https://other.example.com/dakota#ed25519-key"
+)
+
+// mustGenerateRSAKey generates an RSA key pair or panics.
+func mustGenerateRSAKey() *rsa.PrivateKey {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// toPublicKeyPem encodes an RSA public key in the PEM PKIX form.
+func toPublicKeyPem(k *rsa.PublicKey) string {
+	b, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}))
+}
+
+// newPersonWithKey creates a Person with the given id and a PublicKey with the
+// given key id and owner.
+func newPersonWithKey(id, keyId, owner string, k *rsa.PublicKey) vocab.ActivityStreamsPerson {
+	p := streams.NewActivityStreamsPerson()
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(mustParse(id))
+	p.SetJSONLDId(idProp)
+	inbox := streams.NewActivityStreamsInboxProperty()
+	inbox.SetIRI(mustParse(id + "/inbox"))
+	p.SetActivityStreamsInbox(inbox)
+	pk := streams.NewW3IDSecurityV1PublicKey()
+	pkId := streams.NewJSONLDIdProperty()
+	pkId.Set(mustParse(keyId))
+	pk.SetJSONLDId(pkId)
+	ownerProp := streams.NewW3IDSecurityV1OwnerProperty()
+	ownerProp.Set(mustParse(owner))
+	pk.SetW3IDSecurityV1Owner(ownerProp)
+	pemProp := streams.NewW3IDSecurityV1PublicKeyPemProperty()
+	pemProp.Set(toPublicKeyPem(k))
+	pk.SetW3IDSecurityV1PublicKeyPem(pemProp)
+	pkProp := streams.NewW3IDSecurityV1PublicKeyProperty()
+	pkProp.AppendW3IDSecurityV1PublicKey(pk)
+	p.SetW3IDSecurityV1PublicKey(pkProp)
+	return p
+}
+
+// mustGenerateEd25519Key generates an Ed25519 key pair or panics.
+func mustGenerateEd25519Key() ed25519.PrivateKey {
+	_, k, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// addMultikey adds a Multikey with the given key id and controller to the
+// 'assertionMethod' of the Person.
+func addMultikey(p vocab.ActivityStreamsPerson, keyId, controller string, k ed25519.PublicKey) {
+	mk := streams.NewW3IDSecurityDataIntegrityV1Multikey()
+	mkId := streams.NewJSONLDIdProperty()
+	mkId.Set(mustParse(keyId))
+	mk.SetJSONLDId(mkId)
+	controllerProp := streams.NewW3IDSecurityDataIntegrityV1ControllerProperty()
+	controllerProp.Set(mustParse(controller))
+	mk.SetW3IDSecurityDataIntegrityV1Controller(controllerProp)
+	mbProp := streams.NewW3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty()
+	mbProp.Set(Ed25519PublicKeyMultibase(k))
+	mk.SetW3IDSecurityDataIntegrityV1PublicKeyMultibase(mbProp)
+	am := p.GetW3IDSecurityDataIntegrityV1AssertionMethod()
+	if am == nil {
+		am = streams.NewW3IDSecurityDataIntegrityV1AssertionMethodProperty()
+		p.SetW3IDSecurityDataIntegrityV1AssertionMethod(am)
+	}
+	am.AppendW3IDSecurityDataIntegrityV1Multikey(mk)
+}
+
+// toSignedPostInboxRequest creates a POST request with the given type as the
+// payload, signed by the given key.
+func toSignedPostInboxRequest(t vocab.Type, keyId string, k *rsa.PrivateKey) *http.Request {
+	return toSignedPostInboxRequestWithHeaders(t, keyId, k, []string{httpsig.RequestTarget, "host", "date", "digest"})
+}
+
+// toSignedPostInboxRequestWithHeaders creates a POST request with the given
+// type as the payload, signed by the given key over the given headers.
+func toSignedPostInboxRequestWithHeaders(t vocab.Type, keyId string, k *rsa.PrivateKey, headers []string) *http.Request {
+	return toSignedPostInboxBodyRequest(mustSerializeToBytes(t), keyId, k, headers)
+}
+
+// toSignedPostInboxBodyRequest creates a POST request with the given payload,
+// signed by the given key over the given headers.
+func toSignedPostInboxBodyRequest(b []byte, keyId string, k *rsa.PrivateKey, headers []string) *http.Request {
+	r := toAPRequest(httptest.NewRequest("POST", testMyInboxIRI, bytes.NewReader(b)))
+	s, _, err := httpsig.NewSigner(
+		[]httpsig.Algorithm{httpsig.RSA_SHA256},
+		httpsig.DigestSha256,
+		headers,
+		httpsig.Signature)
+	if err != nil {
+		panic(err)
+	}
+	r.Header.Set("Host", r.Host)
+	setDigestHeaders(r.Header, DigestSha256, b)
+	if err = s.SignRequest(k, keyId, r, nil); err != nil {
+		panic(err)
+	}
+	// Servers move the Host header to the request's Host.
+	r.Header.Del("Host")
+	return r
+}
+
+// toLDSigned returns a copy of the given type with an RsaSignature2017 Linked
+// Data Signature by the given key.
+func toLDSigned(t vocab.Type, keyId string, k *rsa.PrivateKey) vocab.Type {
+	m := mustSerialize(t)
+	s := NewRsaSignature2017Signer(&fixedClock{now()}, nil, mustParse(keyId), k)
+	if err := s.SignLD(context.Background(), m); err != nil {
+		panic(err)
+	}
+	signed, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+// toHs2019SignedPostInboxRequest creates a POST request with the given type as
+// the payload, signed by the given key with the hs2019 algorithm.
+func toHs2019SignedPostInboxRequest(t vocab.Type, keyId string, k ed25519.PrivateKey) *http.Request {
+	b := mustSerializeToBytes(t)
+	r := toAPRequest(httptest.NewRequest("POST", testMyInboxIRI, bytes.NewReader(b)))
+	setDigestHeaders(r.Header, DigestSha256, b)
+	s := NewHs2019Signer([]string{httpsig.RequestTarget, "host", "date", "digest"})
+	if err := s.SignRequest(k, keyId, r, b); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// toMessageSignedPostInboxRequest creates a POST request with the given type as
+// the payload, signed by the given key with an RFC 9421 HTTP Message Signature
+// covering the components.
+func toMessageSignedPostInboxRequest(t vocab.Type, keyId string, k crypto.PrivateKey, components []string) *http.Request {
+	b := mustSerializeToBytes(t)
+	r := toAPRequest(httptest.NewRequest("POST", testMyInboxIRI, bytes.NewReader(b)))
+	setDigestHeaders(r.Header, DigestSha256, b)
+	s := NewHttpMessageSigner(&fixedClock{now()}, "", components)
+	if err := s.SignRequest(k, keyId, r, b); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// TestHttpSigVerifier tests verifying HTTP Signatures on inbox requests.
+func TestHttpSigVerifier(t *testing.T) {
+	ctx := context.Background()
+	setupData()
+	key := mustGenerateRSAKey()
+	otherKey := mustGenerateRSAKey()
+	edKey := mustGenerateEd25519Key()
+	otherEdKey := mustGenerateEd25519Key()
+	actor := newPersonWithKey(testFederatedActorIRI, testFederatedKeyId, testFederatedActorIRI, &key.PublicKey)
+	addMultikey(actor, testFederatedEd25519KeyId, testFederatedActorIRI, edKey.Public().(ed25519.PublicKey))
+	setupFn := func(ctl *gomock.Controller) (v *HttpSigVerifier, tp *MockTransport) {
+		v = NewHttpSigVerifier(&fixedClock{now()}, nil)
+		tp = NewMockTransport(ctl)
+		return
+	}
+	t.Run("VerifiesAndStoresSigner", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, key)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		out, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+		signer, ok := HttpSigSigner(out)
+		assertEqual(t, ok, true)
+		assertEqual(t, signer.String(), testFederatedActorIRI)
+	})
+	t.Run("RestoresRequestBody", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, key)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, _, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		var b bytes.Buffer
+		b.ReadFrom(req.Body)
+		assertByteEqual(t, b.Bytes(), mustSerializeToBytes(testCreate))
+	})
+	t.Run("VerifiesHs2019Algorithm", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, key)
+		req.Header.Set("Signature", strings.Replace(req.Header.Get("Signature"), "algorithm=\"rsa-sha256\"", "algorithm=\"hs2019\"", 1))
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+	})
+	t.Run("VerifiesMessageSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toMessageSignedPostInboxRequest(testCreate, testFederatedKeyId, key, []string{"@method", "@target-uri", "content-digest"})
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		out, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+		signer, ok := HttpSigSigner(out)
+		assertEqual(t, ok, true)
+		assertEqual(t, signer.String(), testFederatedActorIRI)
+	})
+	t.Run("UnauthorizedIfMessageSignatureInvalid", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toMessageSignedPostInboxRequest(testCreate, testFederatedKeyId, otherKey, []string{"@method", "@target-uri", "content-digest"})
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("VerifiesMessageSignatureCoveringAuthorityAndPath", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toMessageSignedPostInboxRequest(testCreate, testFederatedKeyId, key, []string{"@method", "@authority", "@path", "content-digest"})
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+	})
+	t.Run("UnauthorizedIfMessageSignatureReplayedToAnotherHost", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toMessageSignedPostInboxRequest(testCreate, testFederatedKeyId, key, []string{"@method", "@path", "content-digest"})
+		req.Host = "replayed.example.com"
+		req.URL.Host = req.Host
+		resp := httptest.NewRecorder()
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfMessageSignatureOmitsDigest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toMessageSignedPostInboxRequest(testCreate, testFederatedKeyId, key, []string{"@method", "@target-uri"})
+		resp := httptest.NewRecorder()
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfMessageSignatureIsStale", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp := setupFn(ctl)
+		v := NewHttpSigVerifier(&fixedClock{now().Add(httpSigMaxClockSkew + time.Second)}, nil)
+		req := toMessageSignedPostInboxRequest(testCreate, testFederatedKeyId, key, []string{"@method", "@target-uri", "content-digest"})
+		resp := httptest.NewRecorder()
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("RequiresFreshMessageSignatureParameters", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, _ := setupFn(ctl)
+		created := now().Unix()
+		skew := int64(httpSigMaxClockSkew / time.Second)
+		tests := []struct {
+			params string
+			fresh  bool
+		}{
+			{fmt.Sprintf("created=%d", created), true},
+			{fmt.Sprintf("created=%d;expires=%d", created, created+60), true},
+			{fmt.Sprintf("created=%d;expires=%d", created, created-skew+1), true},
+			{"keyid=\"key\"", false},
+			{fmt.Sprintf("created=%d", created-skew-1), false},
+			{fmt.Sprintf("created=%d", created+skew+1), false},
+			{fmt.Sprintf("created=%d;expires=%d", created, created-skew-1), false},
+			{fmt.Sprintf("created=%d;expires=\"never\"", created), false},
+		}
+		for _, test := range tests {
+			h := http.Header{}
+			h.Set(signatureInputHeader, "sig1=(\"@method\");"+test.params)
+			labels, inputs, err := parseSignatureInputs(h)
+			assertEqual(t, err, nil)
+			// Run & Verify
+			if fresh := v.isFreshMessageSignature(inputs[labels[0]]); fresh != test.fresh {
+				t.Errorf("%s: expected fresh to be %v", test.params, test.fresh)
+			}
+		}
+	})
+	t.Run("VerifiesEd25519Multikey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toHs2019SignedPostInboxRequest(testCreate, testFederatedEd25519KeyId, edKey)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedEd25519KeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		out, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+		signer, ok := HttpSigSigner(out)
+		assertEqual(t, ok, true)
+		assertEqual(t, signer.String(), testFederatedActorIRI)
+	})
+	t.Run("VerifiesMessageSignatureWithEd25519Multikey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toMessageSignedPostInboxRequest(testCreate, testFederatedEd25519KeyId, edKey, []string{"@method", "@target-uri", "content-digest"})
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedEd25519KeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+	})
+	t.Run("VerifiesStandaloneMultikey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toHs2019SignedPostInboxRequest(testCreate, testFederatedEd25519KeyId, edKey)
+		resp := httptest.NewRecorder()
+		mk := actor.GetW3IDSecurityDataIntegrityV1AssertionMethod().At(0).Get()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedEd25519KeyId)).Return(mustSerializeToBytes(mk), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+	})
+	t.Run("UnauthorizedIfOwnerDoesNotListStandaloneKey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		forgedKeyId := "https://other.example.com/keys/forged"
+		req := toSignedPostInboxRequest(testCreate, forgedKeyId, otherKey)
+		resp := httptest.NewRecorder()
+		pk := newPersonWithKey(testFederatedActorIRI, forgedKeyId, testFederatedActorIRI, &otherKey.PublicKey).GetW3IDSecurityV1PublicKey().At(0).Get()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(forgedKeyId)).Return(mustSerializeToBytes(pk), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfKeyOwnerOnOtherHost", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		forgedKeyId := "https://forger.example.com/key"
+		req := toSignedPostInboxRequest(testCreate, forgedKeyId, otherKey)
+		resp := httptest.NewRecorder()
+		pk := newPersonWithKey(testFederatedActorIRI, forgedKeyId, testFederatedActorIRI, &otherKey.PublicKey).GetW3IDSecurityV1PublicKey().At(0).Get()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(forgedKeyId)).Return(mustSerializeToBytes(pk), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfEd25519SignatureInvalid", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toHs2019SignedPostInboxRequest(testCreate, testFederatedEd25519KeyId, otherEdKey)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedEd25519KeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfActorDoesNotControlMultikey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toHs2019SignedPostInboxRequest(testCreate, testFederatedEd25519KeyId, edKey)
+		resp := httptest.NewRecorder()
+		other := newPersonWithKey(testFederatedActorIRI, testFederatedKeyId, testFederatedActorIRI, &key.PublicKey)
+		addMultikey(other, testFederatedEd25519KeyId, testFederatedActorIRI2, edKey.Public().(ed25519.PublicKey))
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedEd25519KeyId)).Return(mustSerializeToBytes(other), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfNoSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toPostInboxRequest(testCreate)
+		resp := httptest.NewRecorder()
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfSignatureInvalid", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, otherKey)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfSignatureOmitsDigest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequestWithHeaders(testCreate, testFederatedKeyId, key, []string{httpsig.RequestTarget, "host", "date"})
+		resp := httptest.NewRecorder()
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfDigestDoesNotMatchBody", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, key)
+		req.Body = ioutil.NopCloser(bytes.NewReader(mustSerializeToBytes(testCreate2)))
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfDateIsStale", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp := setupFn(ctl)
+		v := NewHttpSigVerifier(&fixedClock{now().Add(httpSigMaxClockSkew + time.Second)}, nil)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, key)
+		resp := httptest.NewRecorder()
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("BadRequestIfBodyIsNotJSON", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxBodyRequest([]byte("{"), testFederatedKeyId, key, []string{httpsig.RequestTarget, "host", "date", "digest"})
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
+	t.Run("UnauthorizedIfKeyOwnerNotActivityActor", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate2, testFederatedKeyId, key)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(
+			mustSerializeToBytes(newPersonWithKey(testFederatedActorIRI3, testFederatedKeyId, testFederatedActorIRI3, &key.PublicKey)), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("UnauthorizedIfActorDoesNotOwnKey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, key)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(
+			mustSerializeToBytes(newPersonWithKey(testFederatedActorIRI, testFederatedKeyId, testFederatedActorIRI2, &key.PublicKey)), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+	t.Run("ReturnsErrorIfKeyCannotBeDereferenced", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v, tp := setupFn(ctl)
+		req := toSignedPostInboxRequest(testCreate, testFederatedKeyId, key)
+		resp := httptest.NewRecorder()
+		testErr := fmt.Errorf("test error")
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(nil, testErr)
+		// Run
+		_, _, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("VerifiesForwardedActivityWithLDSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp := setupFn(ctl)
+		v := NewHttpSigVerifierWithLDSignatures(&fixedClock{now()}, nil, NewRsaSignature2017Verifier(nil))
+		forwarderKeyId := testFederatedActorIRI3 + "#main-key"
+		req := toSignedPostInboxRequest(toLDSigned(testCreate, testFederatedKeyId, key), forwarderKeyId, otherKey)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(forwarderKeyId)).Return(
+			mustSerializeToBytes(newPersonWithKey(testFederatedActorIRI3, forwarderKeyId, testFederatedActorIRI3, &otherKey.PublicKey)), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		out, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, true)
+		signer, ok := HttpSigSigner(out)
+		assertEqual(t, ok, true)
+		assertEqual(t, signer.String(), testFederatedActorIRI3)
+		ldSigner, ok := LDSignatureSigner(out)
+		assertEqual(t, ok, true)
+		assertEqual(t, ldSigner.String(), testFederatedActorIRI)
+	})
+	t.Run("UnauthorizedIfForwardedActivityLDSignatureInvalid", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp := setupFn(ctl)
+		v := NewHttpSigVerifierWithLDSignatures(&fixedClock{now()}, nil, NewRsaSignature2017Verifier(nil))
+		forwarderKeyId := testFederatedActorIRI3 + "#main-key"
+		req := toSignedPostInboxRequest(toLDSigned(testCreate, testFederatedKeyId, otherKey), forwarderKeyId, otherKey)
+		resp := httptest.NewRecorder()
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(forwarderKeyId)).Return(
+			mustSerializeToBytes(newPersonWithKey(testFederatedActorIRI3, forwarderKeyId, testFederatedActorIRI3, &otherKey.PublicKey)), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		_, authenticated, err := v.AuthenticatePostInbox(ctx, resp, req, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, authenticated, false)
+		assertEqual(t, resp.Code, http.StatusUnauthorized)
+	})
+}
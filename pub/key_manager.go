@@ -0,0 +1,272 @@
+package pub
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// The size of the RSA keys generated when rotating an actor's key.
+	rotatedKeyBits = 2048
+	// The number of random bytes in the id of a rotated key, which keeps ids
+	// unique when a key is rotated more than once in the same second.
+	rotatedKeyIdRandomBytes = 8
+)
+
+// KeyManager rotates the keys that this server's actors sign requests with.
+//
+// Rotating an actor's key generates a new key pair and stores it in the
+// KeyStore as the actor's current key. The actor's 'publicKey' is rewritten in
+// the Database, and an Update of the actor is sent to its followers so that
+// peers fetch the new key.
+//
+// The replaced key is retired rather than discarded. It remains in the actor's
+// 'publicKey' for the grace window, so that signatures made with it, such as
+// those of deliveries still being retried, continue to be accepted by peers
+// choosing among the actor's keys by keyId. ExpireKeys removes it once the
+// window has passed.
+//
+// It is safe to use concurrently.
+type KeyManager struct {
+	db    Database
+	actor FederatingActor
+	store KeyStore
+	clock Clock
+	grace time.Duration
+}
+
+// NewKeyManager returns a new KeyManager.
+//
+// The FederatingActor is used to send the Update of a rotated actor, and the
+// grace window is how long a retired key continues to be published.
+//
+// Applications with keys that predate the KeyManager should add them to the
+// KeyStore before the first rotation, otherwise they are removed from the
+// actor's 'publicKey' immediately instead of after the grace window.
+func NewKeyManager(db Database, actor FederatingActor, store KeyStore, clock Clock, grace time.Duration) *KeyManager {
+	return &KeyManager{
+		db:    db,
+		actor: actor,
+		store: store,
+		clock: clock,
+		grace: grace,
+	}
+}
+
+// CurrentKey returns the id and private key of the key the actor currently
+// signs requests with, such as when creating a Transport in CommonBehavior's
+// NewTransport.
+//
+// Returns a nil id and key if the actor has no current key.
+func (k *KeyManager) CurrentKey(c context.Context, actorIRI *url.URL) (keyId *url.URL, privKey crypto.PrivateKey, err error) {
+	keys, err := k.store.Keys(c, actorIRI)
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		if key.Retired.IsZero() {
+			keyId = key.Id
+			privKey = key.PrivateKey
+		}
+	}
+	return
+}
+
+// RotateKey generates a new RSA key pair for the actor, retiring its current
+// key, and sends an Update of the actor to its followers. The id of the new
+// key is returned.
+//
+// Retired keys whose grace window has passed are removed at the same time.
+func (k *KeyManager) RotateKey(c context.Context, actorIRI *url.URL) (keyId *url.URL, err error) {
+	privKey, err := rsa.GenerateKey(rand.Reader, rotatedKeyBits)
+	if err != nil {
+		return
+	}
+	suffix := make([]byte, rotatedKeyIdRandomBytes)
+	if _, err = rand.Read(suffix); err != nil {
+		return
+	}
+	now := k.clock.Now()
+	keyId = &url.URL{}
+	*keyId = *actorIRI
+	keyId.Fragment = fmt.Sprintf("key-%d-%s", now.Unix(), hex.EncodeToString(suffix))
+	if err = k.db.Lock(c, actorIRI); err != nil {
+		return
+	}
+	// WARNING: Unlock not deferred.
+	keys, err := k.store.Keys(c, actorIRI)
+	if err != nil {
+		k.db.Unlock(c, actorIRI)
+		return
+	}
+	for i := range keys {
+		if keys[i].Id.String() == keyId.String() {
+			k.db.Unlock(c, actorIRI)
+			err = fmt.Errorf("key %s of actor %s already exists", keyId, actorIRI)
+			return
+		} else if keys[i].Retired.IsZero() {
+			keys[i].Retired = now
+		}
+	}
+	keys = append(k.unexpired(keys, now), ActorKey{
+		Id:         keyId,
+		PrivateKey: privKey,
+	})
+	if err = k.store.SetKeys(c, actorIRI, keys); err != nil {
+		k.db.Unlock(c, actorIRI)
+		return
+	}
+	actor, err := k.setPublicKeys(c, actorIRI, keys)
+	if err != nil {
+		k.db.Unlock(c, actorIRI)
+		return
+	}
+	k.db.Unlock(c, actorIRI)
+	// Unlock must be called by now and every branch above.
+	err = k.sendUpdate(c, actorIRI, actor)
+	return
+}
+
+// ExpireKeys removes the actor's retired keys whose grace window has passed,
+// from both the KeyStore and the actor's 'publicKey'.
+//
+// No Update is sent, since peers only need to learn of the current key. It is
+// intended to be called periodically, or before the actor is next served.
+func (k *KeyManager) ExpireKeys(c context.Context, actorIRI *url.URL) error {
+	if err := k.db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	defer k.db.Unlock(c, actorIRI)
+	keys, err := k.store.Keys(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	unexpired := k.unexpired(keys, k.clock.Now())
+	if len(unexpired) == len(keys) {
+		return nil
+	}
+	if err = k.store.SetKeys(c, actorIRI, unexpired); err != nil {
+		return err
+	}
+	_, err = k.setPublicKeys(c, actorIRI, unexpired)
+	return err
+}
+
+// unexpired returns the keys that are current or still within their grace
+// window.
+func (k *KeyManager) unexpired(keys []ActorKey, now time.Time) []ActorKey {
+	out := make([]ActorKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Retired.IsZero() || now.Before(key.Retired.Add(k.grace)) {
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+// setPublicKeys replaces the 'publicKey' of the actor in the database with the
+// public halves of the keys, with the current key first. The database lock
+// for the actor must be held.
+func (k *KeyManager) setPublicKeys(c context.Context, actorIRI *url.URL, keys []ActorKey) (actor vocab.Type, err error) {
+	actor, err = k.db.Get(c, actorIRI)
+	if err != nil {
+		return
+	}
+	pker, ok := actor.(publicKeyer)
+	if !ok {
+		err = fmt.Errorf("actor %s of type %T has no publicKey property", actorIRI, actor)
+		return
+	}
+	prop := streams.NewW3IDSecurityV1PublicKeyProperty()
+	for _, current := range []bool{true, false} {
+		for _, key := range keys {
+			if key.Retired.IsZero() != current {
+				continue
+			}
+			var pk vocab.W3IDSecurityV1PublicKey
+			pk, err = toW3IDSecurityV1PublicKey(actorIRI, key)
+			if err != nil {
+				return
+			}
+			prop.AppendW3IDSecurityV1PublicKey(pk)
+		}
+	}
+	pker.SetW3IDSecurityV1PublicKey(prop)
+	err = k.db.Update(c, actor)
+	return
+}
+
+// sendUpdate sends an Update of the actor from its outbox, addressed publicly
+// and to its followers.
+func (k *KeyManager) sendUpdate(c context.Context, actorIRI *url.URL, actor vocab.Type) error {
+	ob, ok := actor.(outboxer)
+	if !ok || ob.GetActivityStreamsOutbox() == nil {
+		return fmt.Errorf("actor %s has no outbox to send an Update from", actorIRI)
+	}
+	outboxIRI, err := ToId(ob.GetActivityStreamsOutbox())
+	if err != nil {
+		return err
+	}
+	update := streams.NewActivityStreamsUpdate()
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorIRI)
+	update.SetActivityStreamsActor(actorProp)
+	op := streams.NewActivityStreamsObjectProperty()
+	if err = op.AppendType(actor); err != nil {
+		return err
+	}
+	update.SetActivityStreamsObject(op)
+	to := streams.NewActivityStreamsToProperty()
+	public, err := url.Parse(PublicActivityPubIRI)
+	if err != nil {
+		return err
+	}
+	to.AppendIRI(public)
+	update.SetActivityStreamsTo(to)
+	if fr, ok := actor.(followerser); ok && fr.GetActivityStreamsFollowers() != nil {
+		followersIRI, err := ToId(fr.GetActivityStreamsFollowers())
+		if err != nil {
+			return err
+		}
+		cc := streams.NewActivityStreamsCcProperty()
+		cc.AppendIRI(followersIRI)
+		update.SetActivityStreamsCc(cc)
+	}
+	_, err = k.actor.Send(c, outboxIRI, update)
+	return err
+}
+
+// toW3IDSecurityV1PublicKey creates the PublicKey of the key, owned by the
+// actor.
+func toW3IDSecurityV1PublicKey(actorIRI *url.URL, key ActorKey) (vocab.W3IDSecurityV1PublicKey, error) {
+	signer, ok := key.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("cannot obtain public key of private key of type %T", key.PrivateKey)
+	}
+	b, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	pk := streams.NewW3IDSecurityV1PublicKey()
+	id := streams.NewJSONLDIdProperty()
+	id.Set(key.Id)
+	pk.SetJSONLDId(id)
+	owner := streams.NewW3IDSecurityV1OwnerProperty()
+	owner.Set(actorIRI)
+	pk.SetW3IDSecurityV1Owner(owner)
+	pemProp := streams.NewW3IDSecurityV1PublicKeyPemProperty()
+	pemProp.Set(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b})))
+	pk.SetW3IDSecurityV1PublicKeyPem(pemProp)
+	return pk, nil
+}
@@ -0,0 +1,217 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testMyFollowersIRI = "https://example.com/addison/followers"
+	testMyOldKeyId     = "https://example.com/addison#main-key"
+)
+
+// newLocalPerson creates this server's Person with an outbox, followers and a
+// PublicKey with the given key id.
+func newLocalPerson(keyId string) vocab.ActivityStreamsPerson {
+	key := mustGenerateRSAKey()
+	p := newPersonWithKey(testMyActorIRI, keyId, testMyActorIRI, &key.PublicKey)
+	outbox := streams.NewActivityStreamsOutboxProperty()
+	outbox.SetIRI(mustParse(testMyOutboxIRI))
+	p.SetActivityStreamsOutbox(outbox)
+	followers := streams.NewActivityStreamsFollowersProperty()
+	followers.SetIRI(mustParse(testMyFollowersIRI))
+	p.SetActivityStreamsFollowers(followers)
+	return p
+}
+
+// publicKeyIds returns the ids of the actor's public keys, in order.
+func publicKeyIds(t vocab.Type) []string {
+	var ids []string
+	p := t.(publicKeyer).GetW3IDSecurityV1PublicKey()
+	for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+		ids = append(ids, iter.Get().GetJSONLDId().Get().String())
+	}
+	return ids
+}
+
+// TestKeyManager tests rotating and expiring actor keys.
+func TestKeyManager(t *testing.T) {
+	ctx := context.Background()
+	setupData()
+	grace := time.Hour
+	setupFn := func(ctl *gomock.Controller) (k *KeyManager, db *MockDatabase, fa *MockFederatingActor, c *MockClock, ks *MemoryKeyStore) {
+		db = NewMockDatabase(ctl)
+		fa = NewMockFederatingActor(ctl)
+		c = NewMockClock(ctl)
+		ks = NewMemoryKeyStore()
+		k = NewKeyManager(db, fa, ks, c, grace)
+		return
+	}
+	t.Run("RotatesKeyAndSendsUpdate", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		k, db, fa, c, ks := setupFn(ctl)
+		person := newLocalPerson(testMyOldKeyId)
+		var sent vocab.ActivityStreamsUpdate
+		// Mock
+		c.EXPECT().Now().Return(now())
+		db.EXPECT().Lock(ctx, mustParse(testMyActorIRI))
+		db.EXPECT().Get(ctx, mustParse(testMyActorIRI)).Return(person, nil)
+		db.EXPECT().Update(ctx, person)
+		db.EXPECT().Unlock(ctx, mustParse(testMyActorIRI))
+		fa.EXPECT().Send(ctx, mustParse(testMyOutboxIRI), gomock.Any()).DoAndReturn(func(c context.Context, outbox interface{}, t vocab.Type) (Activity, error) {
+			sent = t.(vocab.ActivityStreamsUpdate)
+			return sent, nil
+		})
+		// Run
+		keyId, err := k.RotateKey(ctx, mustParse(testMyActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, strings.HasPrefix(keyId.String(), fmt.Sprintf("%s#key-%d-", testMyActorIRI, now().Unix())), true)
+		assertEqual(t, len(publicKeyIds(person)), 1)
+		assertEqual(t, publicKeyIds(person)[0], keyId.String())
+		curId, curKey, err := k.CurrentKey(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, curId.String(), keyId.String())
+		assertNotEqual(t, curKey, nil)
+		keys, err := ks.Keys(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(keys), 1)
+		assertEqual(t, sent.GetActivityStreamsObject().At(0).GetType(), person)
+		assertEqual(t, sent.GetActivityStreamsCc().At(0).GetIRI().String(), testMyFollowersIRI)
+	})
+	t.Run("RotatesTwiceInTheSameSecond", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		k, db, fa, c, ks := setupFn(ctl)
+		person := newLocalPerson(testMyOldKeyId)
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		db.EXPECT().Lock(ctx, mustParse(testMyActorIRI)).Times(2)
+		db.EXPECT().Get(ctx, mustParse(testMyActorIRI)).Return(person, nil).Times(2)
+		db.EXPECT().Update(ctx, person).Times(2)
+		db.EXPECT().Unlock(ctx, mustParse(testMyActorIRI)).Times(2)
+		fa.EXPECT().Send(ctx, mustParse(testMyOutboxIRI), gomock.Any()).Times(2)
+		// Run
+		first, err := k.RotateKey(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		second, err := k.RotateKey(ctx, mustParse(testMyActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertNotEqual(t, first.String(), second.String())
+		curId, _, err := k.CurrentKey(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, curId.String(), second.String())
+		keys, err := ks.Keys(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(keys), 2)
+	})
+	t.Run("RetiredKeyVerifiesDuringGraceWindow", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		k, db, fa, c, ks := setupFn(ctl)
+		oldKey := mustGenerateRSAKey()
+		assertEqual(t, ks.SetKeys(ctx, mustParse(testMyActorIRI), []ActorKey{{Id: mustParse(testMyOldKeyId), PrivateKey: oldKey}}), nil)
+		person := newLocalPerson(testMyOldKeyId)
+		req := toSignedPostInboxRequest(testCreate, testMyOldKeyId, oldKey)
+		v := NewHttpSigVerifier(&fixedClock{now()}, nil)
+		tp := NewMockTransport(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		db.EXPECT().Lock(ctx, mustParse(testMyActorIRI))
+		db.EXPECT().Get(ctx, mustParse(testMyActorIRI)).Return(person, nil)
+		db.EXPECT().Update(ctx, person)
+		db.EXPECT().Unlock(ctx, mustParse(testMyActorIRI))
+		fa.EXPECT().Send(ctx, mustParse(testMyOutboxIRI), gomock.Any())
+		tp.EXPECT().Dereference(ctx, mustParse(testMyOldKeyId)).DoAndReturn(func(c context.Context, iri interface{}) ([]byte, error) {
+			return mustSerializeToBytes(person), nil
+		})
+		// Run
+		keyId, err := k.RotateKey(ctx, mustParse(testMyActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		ids := publicKeyIds(person)
+		assertEqual(t, len(ids), 2)
+		assertEqual(t, ids[0], keyId.String())
+		assertEqual(t, ids[1], testMyOldKeyId)
+		signer, verified, err := v.VerifyRequest(ctx, req, tp)
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, true)
+		assertEqual(t, signer.String(), testMyActorIRI)
+	})
+	t.Run("ExpiresRetiredKeysAfterGraceWindow", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		k, db, _, c, ks := setupFn(ctl)
+		assertEqual(t, ks.SetKeys(ctx, mustParse(testMyActorIRI), []ActorKey{
+			{Id: mustParse(testMyOldKeyId), PrivateKey: mustGenerateRSAKey(), Retired: now()},
+			{Id: mustParse(testMyActorIRI + "#key-1"), PrivateKey: mustGenerateRSAKey()},
+		}), nil)
+		person := newLocalPerson(testMyOldKeyId)
+		// Mock
+		c.EXPECT().Now().Return(now().Add(grace))
+		db.EXPECT().Lock(ctx, mustParse(testMyActorIRI))
+		db.EXPECT().Get(ctx, mustParse(testMyActorIRI)).Return(person, nil)
+		db.EXPECT().Update(ctx, person)
+		db.EXPECT().Unlock(ctx, mustParse(testMyActorIRI))
+		// Run
+		err := k.ExpireKeys(ctx, mustParse(testMyActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		ids := publicKeyIds(person)
+		assertEqual(t, len(ids), 1)
+		assertEqual(t, ids[0], testMyActorIRI+"#key-1")
+		keys, err := ks.Keys(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(keys), 1)
+	})
+	t.Run("KeepsRetiredKeysWithinGraceWindow", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		k, db, _, c, ks := setupFn(ctl)
+		assertEqual(t, ks.SetKeys(ctx, mustParse(testMyActorIRI), []ActorKey{
+			{Id: mustParse(testMyOldKeyId), PrivateKey: mustGenerateRSAKey(), Retired: now()},
+			{Id: mustParse(testMyActorIRI + "#key-1"), PrivateKey: mustGenerateRSAKey()},
+		}), nil)
+		// Mock
+		c.EXPECT().Now().Return(now().Add(grace - time.Second))
+		db.EXPECT().Lock(ctx, mustParse(testMyActorIRI))
+		db.EXPECT().Unlock(ctx, mustParse(testMyActorIRI))
+		// Run
+		err := k.ExpireKeys(ctx, mustParse(testMyActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		keys, err := ks.Keys(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(keys), 2)
+	})
+}
+
+// TestMemoryKeyStore tests storing keys in memory.
+func TestMemoryKeyStore(t *testing.T) {
+	ctx := context.Background()
+	t.Run("RemovesActorWithoutKeys", func(t *testing.T) {
+		// Setup
+		m := NewMemoryKeyStore()
+		assertEqual(t, m.SetKeys(ctx, mustParse(testMyActorIRI), []ActorKey{{Id: mustParse(testMyOldKeyId)}}), nil)
+		// Run
+		err := m.SetKeys(ctx, mustParse(testMyActorIRI), nil)
+		// Verify
+		assertEqual(t, err, nil)
+		keys, err := m.Keys(ctx, mustParse(testMyActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, keys == nil, true)
+	})
+}
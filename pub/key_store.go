@@ -0,0 +1,85 @@
+package pub
+
+import (
+	"context"
+	"crypto"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ActorKey is a key pair that one of this server's actors signs requests with.
+type ActorKey struct {
+	// Id is the id of the public key, which peers use as the keyId to
+	// verify signatures.
+	Id *url.URL
+	// PrivateKey is the private key signatures are made with.
+	PrivateKey crypto.PrivateKey
+	// Retired is when the key was replaced by a newer one, or the zero
+	// time if it is the actor's current key.
+	Retired time.Time
+}
+
+// KeyStore holds the private keys of this server's actors.
+//
+// It is used by the KeyManager, which stores an actor's current key alongside
+// the keys it has retired but which are still within their grace window.
+//
+// It must be safe to use concurrently.
+type KeyStore interface {
+	// Keys returns the keys of the actor, in the order they were set. If
+	// the actor has no keys, then a nil slice and nil error must be
+	// returned.
+	Keys(c context.Context, actorIRI *url.URL) ([]ActorKey, error)
+	// SetKeys replaces the keys of the actor.
+	SetKeys(c context.Context, actorIRI *url.URL, keys []ActorKey) error
+}
+
+// KeyStore must be implemented by MemoryKeyStore.
+var _ KeyStore = &MemoryKeyStore{}
+
+// MemoryKeyStore is a KeyStore that keeps keys in memory.
+//
+// Keys are lost when the process exits, so it is best suited for tests and
+// applications that persist keys elsewhere as well.
+//
+// It is safe to use concurrently.
+type MemoryKeyStore struct {
+	mu   *sync.RWMutex
+	keys map[string][]ActorKey
+}
+
+// NewMemoryKeyStore returns a new, empty MemoryKeyStore.
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{
+		mu:   &sync.RWMutex{},
+		keys: make(map[string][]ActorKey),
+	}
+}
+
+// Keys returns the keys of the actor, in the order they were set.
+func (m *MemoryKeyStore) Keys(c context.Context, actorIRI *url.URL) ([]ActorKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys, ok := m.keys[actorIRI.String()]
+	if !ok {
+		return nil, nil
+	}
+	out := make([]ActorKey, len(keys))
+	copy(out, keys)
+	return out, nil
+}
+
+// SetKeys replaces the keys of the actor.
+func (m *MemoryKeyStore) SetKeys(c context.Context, actorIRI *url.URL, keys []ActorKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(keys) == 0 {
+		delete(m.keys, actorIRI.String())
+		return nil
+	}
+	stored := make([]ActorKey, len(keys))
+	copy(stored, keys)
+	m.keys[actorIRI.String()] = stored
+	return nil
+}
@@ -0,0 +1,314 @@
+package pub
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// The IRI of the ActivityStreams JSON-LD context.
+	activityStreamsLDContextIRI = "https://www.w3.org/ns/activitystreams"
+	// The IRI of the JSON-LD context of Linked Data Signature options.
+	identityV1LDContextIRI = "https://w3id.org/identity/v1"
+)
+
+// The JSON-LD context documents that are canonicalized against when signing
+// and verifying Linked Data Signatures, keyed by their IRI. They are never
+// fetched, so that verifying a peer's signature does not make this server
+// issue requests on the peer's behalf.
+//
+// The identity context only contains the terms used by signature options.
+var preloadedLDContexts = map[string]string{
+	activityStreamsLDContextIRI:                   activityStreamsLDContext,
+	"https://w3id.org/security/v1":                securityV1LDContext,
+	identityV1LDContextIRI:                        identityV1LDContext,
+	"http://joinmastodon.org/ns":                  tootLDContext,
+	"https://forgefed.peers.community/ns":         forgeFedLDContext,
+	"https://w3id.org/security/data-integrity/v1": securityDataIntegrityV1LDContext,
+}
+
+// preloadedLDContextDocuments are the parsed preloadedLDContexts.
+var preloadedLDContextDocuments = make(map[string]interface{}, len(preloadedLDContexts))
+
+func init() {
+	for iri, doc := range preloadedLDContexts {
+		var v interface{}
+		if err := json.Unmarshal([]byte(doc), &v); err != nil {
+			panic(fmt.Sprintf("cannot parse JSON-LD context %s: %s", iri, err))
+		}
+		preloadedLDContextDocuments[iri] = v
+	}
+}
+
+const activityStreamsLDContext = `{
+	"@context": {
+		"@vocab": "_:",
+		"xsd": "http://www.w3.org/2001/XMLSchema#",
+		"as": "https://www.w3.org/ns/activitystreams#",
+		"ldp": "http://www.w3.org/ns/ldp#",
+		"vcard": "http://www.w3.org/2006/vcard/ns#",
+		"id": "@id",
+		"type": "@type",
+		"Accept": "as:Accept",
+		"Activity": "as:Activity",
+		"IntransitiveActivity": "as:IntransitiveActivity",
+		"Add": "as:Add",
+		"Announce": "as:Announce",
+		"Application": "as:Application",
+		"Arrive": "as:Arrive",
+		"Article": "as:Article",
+		"Audio": "as:Audio",
+		"Block": "as:Block",
+		"Collection": "as:Collection",
+		"CollectionPage": "as:CollectionPage",
+		"Relationship": "as:Relationship",
+		"Create": "as:Create",
+		"Delete": "as:Delete",
+		"Dislike": "as:Dislike",
+		"Document": "as:Document",
+		"Event": "as:Event",
+		"Follow": "as:Follow",
+		"Flag": "as:Flag",
+		"Group": "as:Group",
+		"Ignore": "as:Ignore",
+		"Image": "as:Image",
+		"Invite": "as:Invite",
+		"Join": "as:Join",
+		"Leave": "as:Leave",
+		"Like": "as:Like",
+		"Link": "as:Link",
+		"Mention": "as:Mention",
+		"Note": "as:Note",
+		"Object": "as:Object",
+		"Offer": "as:Offer",
+		"OrderedCollection": "as:OrderedCollection",
+		"OrderedCollectionPage": "as:OrderedCollectionPage",
+		"Organization": "as:Organization",
+		"Page": "as:Page",
+		"Person": "as:Person",
+		"Place": "as:Place",
+		"Profile": "as:Profile",
+		"Question": "as:Question",
+		"Reject": "as:Reject",
+		"Remove": "as:Remove",
+		"Service": "as:Service",
+		"TentativeAccept": "as:TentativeAccept",
+		"TentativeReject": "as:TentativeReject",
+		"Tombstone": "as:Tombstone",
+		"Undo": "as:Undo",
+		"Update": "as:Update",
+		"Video": "as:Video",
+		"View": "as:View",
+		"Listen": "as:Listen",
+		"Read": "as:Read",
+		"Move": "as:Move",
+		"Travel": "as:Travel",
+		"IsFollowing": "as:IsFollowing",
+		"IsFollowedBy": "as:IsFollowedBy",
+		"IsContact": "as:IsContact",
+		"IsMember": "as:IsMember",
+		"subject": {"@id": "as:subject", "@type": "@id"},
+		"relationship": {"@id": "as:relationship", "@type": "@id"},
+		"actor": {"@id": "as:actor", "@type": "@id"},
+		"attributedTo": {"@id": "as:attributedTo", "@type": "@id"},
+		"attachment": {"@id": "as:attachment", "@type": "@id"},
+		"bcc": {"@id": "as:bcc", "@type": "@id"},
+		"bto": {"@id": "as:bto", "@type": "@id"},
+		"cc": {"@id": "as:cc", "@type": "@id"},
+		"context": {"@id": "as:context", "@type": "@id"},
+		"current": {"@id": "as:current", "@type": "@id"},
+		"first": {"@id": "as:first", "@type": "@id"},
+		"generator": {"@id": "as:generator", "@type": "@id"},
+		"icon": {"@id": "as:icon", "@type": "@id"},
+		"image": {"@id": "as:image", "@type": "@id"},
+		"inReplyTo": {"@id": "as:inReplyTo", "@type": "@id"},
+		"items": {"@id": "as:items", "@type": "@id"},
+		"instrument": {"@id": "as:instrument", "@type": "@id"},
+		"last": {"@id": "as:last", "@type": "@id"},
+		"orderedItems": {"@id": "as:items", "@type": "@id", "@container": "@list"},
+		"location": {"@id": "as:location", "@type": "@id"},
+		"next": {"@id": "as:next", "@type": "@id"},
+		"object": {"@id": "as:object", "@type": "@id"},
+		"oneOf": {"@id": "as:oneOf", "@type": "@id"},
+		"anyOf": {"@id": "as:anyOf", "@type": "@id"},
+		"origin": {"@id": "as:origin", "@type": "@id"},
+		"prev": {"@id": "as:prev", "@type": "@id"},
+		"preview": {"@id": "as:preview", "@type": "@id"},
+		"replies": {"@id": "as:replies", "@type": "@id"},
+		"result": {"@id": "as:result", "@type": "@id"},
+		"audience": {"@id": "as:audience", "@type": "@id"},
+		"partOf": {"@id": "as:partOf", "@type": "@id"},
+		"tag": {"@id": "as:tag", "@type": "@id"},
+		"target": {"@id": "as:target", "@type": "@id"},
+		"to": {"@id": "as:to", "@type": "@id"},
+		"url": {"@id": "as:url", "@type": "@id"},
+		"href": {"@id": "as:href", "@type": "@id"},
+		"describes": {"@id": "as:describes", "@type": "@id"},
+		"closed": {"@id": "as:closed", "@type": "xsd:dateTime"},
+		"accuracy": {"@id": "as:accuracy", "@type": "xsd:float"},
+		"altitude": {"@id": "as:altitude", "@type": "xsd:float"},
+		"content": "as:content",
+		"contentMap": {"@id": "as:content", "@container": "@language"},
+		"name": "as:name",
+		"nameMap": {"@id": "as:name", "@container": "@language"},
+		"duration": {"@id": "as:duration", "@type": "xsd:duration"},
+		"endTime": {"@id": "as:endTime", "@type": "xsd:dateTime"},
+		"height": {"@id": "as:height", "@type": "xsd:nonNegativeInteger"},
+		"hreflang": "as:hreflang",
+		"latitude": {"@id": "as:latitude", "@type": "xsd:float"},
+		"longitude": {"@id": "as:longitude", "@type": "xsd:float"},
+		"mediaType": "as:mediaType",
+		"published": {"@id": "as:published", "@type": "xsd:dateTime"},
+		"radius": {"@id": "as:radius", "@type": "xsd:float"},
+		"rel": "as:rel",
+		"startIndex": {"@id": "as:startIndex", "@type": "xsd:nonNegativeInteger"},
+		"startTime": {"@id": "as:startTime", "@type": "xsd:dateTime"},
+		"summary": "as:summary",
+		"summaryMap": {"@id": "as:summary", "@container": "@language"},
+		"totalItems": {"@id": "as:totalItems", "@type": "xsd:nonNegativeInteger"},
+		"units": "as:units",
+		"updated": {"@id": "as:updated", "@type": "xsd:dateTime"},
+		"width": {"@id": "as:width", "@type": "xsd:nonNegativeInteger"},
+		"deleted": {"@id": "as:deleted", "@type": "xsd:dateTime"},
+		"inbox": {"@id": "ldp:inbox", "@type": "@id"},
+		"formerType": {"@id": "as:formerType", "@type": "@id"},
+		"outbox": {"@id": "as:outbox", "@type": "@id"},
+		"following": {"@id": "as:following", "@type": "@id"},
+		"followers": {"@id": "as:followers", "@type": "@id"},
+		"streams": {"@id": "as:streams", "@type": "@id"},
+		"endpoints": {"@id": "as:endpoints", "@type": "@id"},
+		"uploadMedia": {"@id": "as:uploadMedia", "@type": "@id"},
+		"proxyUrl": {"@id": "as:proxyUrl", "@type": "@id"},
+		"liked": {"@id": "as:liked", "@type": "@id"},
+		"oauthAuthorizationEndpoint": {"@id": "as:oauthAuthorizationEndpoint", "@type": "@id"},
+		"oauthTokenEndpoint": {"@id": "as:oauthTokenEndpoint", "@type": "@id"},
+		"provideClientKey": {"@id": "as:provideClientKey", "@type": "@id"},
+		"signClientKey": {"@id": "as:signClientKey", "@type": "@id"},
+		"sharedInbox": {"@id": "as:sharedInbox", "@type": "@id"},
+		"likes": {"@id": "as:likes", "@type": "@id"},
+		"shares": {"@id": "as:shares", "@type": "@id"},
+		"alsoKnownAs": {"@id": "as:alsoKnownAs", "@type": "@id"},
+		"preferredUsername": "as:preferredUsername",
+		"source": "as:source",
+		"sensitive": "as:sensitive",
+		"Public": {"@id": "as:Public", "@type": "@id"}
+	}
+}`
+
+const securityV1LDContext = `{
+	"@context": {
+		"id": "@id",
+		"type": "@type",
+
+		"dc": "http://purl.org/dc/terms/",
+		"sec": "https://w3id.org/security#",
+		"xsd": "http://www.w3.org/2001/XMLSchema#",
+
+		"EcdsaKoblitzSignature2016": "sec:EcdsaKoblitzSignature2016",
+		"Ed25519Signature2018": "sec:Ed25519Signature2018",
+		"EncryptedMessage": "sec:EncryptedMessage",
+		"GraphSignature2012": "sec:GraphSignature2012",
+		"LinkedDataSignature2015": "sec:LinkedDataSignature2015",
+		"LinkedDataSignature2016": "sec:LinkedDataSignature2016",
+		"CryptographicKey": "sec:Key",
+
+		"authenticationTag": "sec:authenticationTag",
+		"canonicalizationAlgorithm": "sec:canonicalizationAlgorithm",
+		"cipherAlgorithm": "sec:cipherAlgorithm",
+		"cipherData": "sec:cipherData",
+		"cipherKey": "sec:cipherKey",
+		"created": {"@id": "dc:created", "@type": "xsd:dateTime"},
+		"creator": {"@id": "dc:creator", "@type": "@id"},
+		"digestAlgorithm": "sec:digestAlgorithm",
+		"digestValue": "sec:digestValue",
+		"domain": "sec:domain",
+		"encryptionKey": "sec:encryptionKey",
+		"expiration": {"@id": "sec:expiration", "@type": "xsd:dateTime"},
+		"expires": {"@id": "sec:expiration", "@type": "xsd:dateTime"},
+		"initializationVector": "sec:initializationVector",
+		"iterationCount": "sec:iterationCount",
+		"nonce": "sec:nonce",
+		"normalizationAlgorithm": "sec:normalizationAlgorithm",
+		"owner": {"@id": "sec:owner", "@type": "@id"},
+		"password": "sec:password",
+		"privateKey": {"@id": "sec:privateKey", "@type": "@id"},
+		"privateKeyPem": "sec:privateKeyPem",
+		"publicKey": {"@id": "sec:publicKey", "@type": "@id"},
+		"publicKeyBase58": "sec:publicKeyBase58",
+		"publicKeyPem": "sec:publicKeyPem",
+		"publicKeyWif": "sec:publicKeyWif",
+		"publicKeyService": {"@id": "sec:publicKeyService", "@type": "@id"},
+		"revoked": {"@id": "sec:revoked", "@type": "xsd:dateTime"},
+		"salt": "sec:salt",
+		"signature": "sec:signature",
+		"signatureAlgorithm": "sec:signingAlgorithm",
+		"signatureValue": "sec:signatureValue"
+	}
+}`
+
+const identityV1LDContext = `{
+	"@context": {
+		"id": "@id",
+		"type": "@type",
+		"dc": "http://purl.org/dc/terms/",
+		"sec": "https://w3id.org/security#",
+		"xsd": "http://www.w3.org/2001/XMLSchema#",
+		"created": {"@id": "dc:created", "@type": "xsd:dateTime"},
+		"creator": {"@id": "dc:creator", "@type": "@id"},
+		"domain": "sec:domain",
+		"nonce": "sec:nonce",
+		"signatureValue": "sec:signatureValue"
+	}
+}`
+
+const tootLDContext = `{
+	"@context": {
+		"Emoji": "http://joinmastodon.org/ns#Emoji",
+		"featured": {"@id": "http://joinmastodon.org/ns#featured", "@type": "@id"},
+		"votersCount": "http://joinmastodon.org/ns#votersCount",
+		"blurhash": "http://joinmastodon.org/ns#blurhash",
+		"IdentityProof": "http://joinmastodon.org/ns#IdentityProof",
+		"signatureAlgorithm": "http://joinmastodon.org/ns#signatureAlgorithm",
+		"signatureValue": "http://joinmastodon.org/ns#signatureValue",
+		"discoverable": "http://joinmastodon.org/ns#discoverable"
+	}
+}`
+
+const forgeFedLDContext = `{
+	"@context": {
+		"Push": "https://forgefed.peers.community/ns#Push",
+		"Repository": "https://forgefed.peers.community/ns#Repository",
+		"Branch": "https://forgefed.peers.community/ns#Branch",
+		"Commit": "https://forgefed.peers.community/ns#Commit",
+		"TicketDependency": "https://forgefed.peers.community/ns#TicketDependency",
+		"Ticket": "https://forgefed.peers.community/ns#Ticket",
+		"earlyItems": {"@id": "https://forgefed.peers.community/ns#earlyItems", "@type": "@id"},
+		"assignedTo": {"@id": "https://forgefed.peers.community/ns#assignedTo", "@type": "@id"},
+		"isResolved": "https://forgefed.peers.community/ns#isResolved",
+		"dependsOn": {"@id": "https://forgefed.peers.community/ns#dependsOn", "@type": "@id"},
+		"dependedBy": {"@id": "https://forgefed.peers.community/ns#dependedBy", "@type": "@id"},
+		"dependencies": {"@id": "https://forgefed.peers.community/ns#dependencies", "@type": "@id"},
+		"dependants": {"@id": "https://forgefed.peers.community/ns#dependants", "@type": "@id"},
+		"description": {"@id": "https://forgefed.peers.community/ns#description", "@type": "@id"},
+		"committedBy": {"@id": "https://forgefed.peers.community/ns#committedBy", "@type": "@id"},
+		"hash": "https://forgefed.peers.community/ns#hash",
+		"committed": {"@id": "https://forgefed.peers.community/ns#committed", "@type": "http://www.w3.org/2001/XMLSchema#dateTime"},
+		"filesAdded": "https://forgefed.peers.community/ns#filesAdded",
+		"filesModified": "https://forgefed.peers.community/ns#filesModified",
+		"filesRemoved": "https://forgefed.peers.community/ns#filesRemoved",
+		"ref": "https://forgefed.peers.community/ns#ref",
+		"team": {"@id": "https://forgefed.peers.community/ns#team", "@type": "@id"},
+		"ticketsTrackedBy": {"@id": "https://forgefed.peers.community/ns#ticketsTrackedBy", "@type": "@id"},
+		"tracksTicketsFor": {"@id": "https://forgefed.peers.community/ns#tracksTicketsFor", "@type": "@id"},
+		"forks": {"@id": "https://forgefed.peers.community/ns#forks", "@type": "@id"}
+	}
+}`
+
+const securityDataIntegrityV1LDContext = `{
+	"@context": {
+		"Multikey": "https://w3id.org/security#Multikey",
+		"assertionMethod": {"@id": "https://w3id.org/security#assertionMethod", "@type": "@id"},
+		"publicKeyMultibase": "https://w3id.org/security#publicKeyMultibase",
+		"controller": {"@id": "https://w3id.org/security#controller", "@type": "@id"}
+	}
+}`
@@ -0,0 +1,260 @@
+package pub
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+const (
+	// The type of an RsaSignature2017 Linked Data Signature.
+	rsaSignature2017Type = "RsaSignature2017"
+	// The property of an activity holding its Linked Data Signature.
+	ldSignatureProperty = "signature"
+	// The format of the 'created' time of a Linked Data Signature.
+	ldSignatureTimeFormat = "2006-01-02T15:04:05Z"
+)
+
+// LDSigner attaches Linked Data Signatures to activities delivered by this
+// server, so that peers can verify the author of an activity even when it is
+// forwarded to them by another server.
+type LDSigner interface {
+	// SignLD attaches a Linked Data Signature to the serialized activity,
+	// replacing any existing one.
+	SignLD(c context.Context, m map[string]interface{}) error
+}
+
+// LDSigner must be implemented by RsaSignature2017Signer.
+var _ LDSigner = &RsaSignature2017Signer{}
+
+// RsaSignature2017Signer signs activities with RsaSignature2017 Linked Data
+// Signatures, as used by Mastodon and other fediverse software.
+//
+// The signature covers the URDNA2015 canonicalization of the activity, so the
+// JSON-LD contexts it refers to must be known. The ActivityStreams, security,
+// and other vocabulary contexts of this library are preloaded. It is safe to
+// use concurrently.
+type RsaSignature2017Signer struct {
+	clock   Clock
+	loader  *ldDocumentLoader
+	keyId   *url.URL
+	privKey *rsa.PrivateKey
+}
+
+// NewRsaSignature2017Signer returns a new RsaSignature2017Signer that signs
+// with the private key, identified to peers by the public key id.
+//
+// The contexts are JSON-LD context documents keyed by IRI, in addition to or
+// replacing the preloaded ones, and may be nil.
+func NewRsaSignature2017Signer(clock Clock, contexts map[string]interface{}, keyId *url.URL, privKey *rsa.PrivateKey) *RsaSignature2017Signer {
+	return &RsaSignature2017Signer{
+		clock:   clock,
+		loader:  newLDDocumentLoader(contexts),
+		keyId:   keyId,
+		privKey: privKey,
+	}
+}
+
+// SignLD attaches an RsaSignature2017 Linked Data Signature to the serialized
+// activity in its 'signature' property.
+func (s *RsaSignature2017Signer) SignLD(c context.Context, m map[string]interface{}) error {
+	options := map[string]interface{}{
+		"creator": s.keyId.String(),
+		"created": s.clock.Now().UTC().Format(ldSignatureTimeFormat),
+	}
+	hash, err := s.loader.signedHash(options, m)
+	if err != nil {
+		return err
+	}
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.privKey, crypto.SHA256, hash)
+	if err != nil {
+		return err
+	}
+	options["type"] = rsaSignature2017Type
+	options["signatureValue"] = base64.StdEncoding.EncodeToString(sig)
+	m[ldSignatureProperty] = options
+	return nil
+}
+
+// RsaSignature2017Verifier verifies the RsaSignature2017 Linked Data
+// Signatures of incoming activities.
+//
+// Only the preloaded and configured JSON-LD contexts are used to canonicalize
+// activities, so an activity referring to any other context does not verify.
+// It is safe to use concurrently.
+type RsaSignature2017Verifier struct {
+	loader *ldDocumentLoader
+}
+
+// NewRsaSignature2017Verifier returns a new RsaSignature2017Verifier.
+//
+// The contexts are JSON-LD context documents keyed by IRI, in addition to or
+// replacing the preloaded ones, and may be nil.
+func NewRsaSignature2017Verifier(contexts map[string]interface{}) *RsaSignature2017Verifier {
+	return &RsaSignature2017Verifier{
+		loader: newLDDocumentLoader(contexts),
+	}
+}
+
+// VerifyLD verifies the Linked Data Signature of the serialized activity and
+// returns the IRI of the owner of the key that created it.
+//
+// The Transport is used to dereference the public key, in the same manner as
+// for HTTP Signatures. A missing, malformed or unsupported signature, or one
+// that does not verify, results in verified being false and a nil error. An
+// error is only returned when the public key cannot be obtained.
+func (v *RsaSignature2017Verifier) VerifyLD(c context.Context, m map[string]interface{}, t Transport) (signer *url.URL, verified bool, err error) {
+	sig, ok := m[ldSignatureProperty].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if typ, ok := sig["type"].(string); !ok || typ != rsaSignature2017Type {
+		return
+	}
+	creator, ok := sig["creator"].(string)
+	if !ok {
+		return
+	}
+	keyId, perr := url.Parse(creator)
+	if perr != nil {
+		return
+	}
+	value, ok := sig["signatureValue"].(string)
+	if !ok {
+		return
+	}
+	sigBytes, perr := base64.StdEncoding.DecodeString(value)
+	if perr != nil {
+		return
+	}
+	options := make(map[string]interface{}, len(sig))
+	for k, val := range sig {
+		if k != "type" && k != "id" && k != "signatureValue" {
+			options[k] = val
+		}
+	}
+	hash, perr := v.loader.signedHash(options, m)
+	if perr != nil {
+		return
+	}
+	pubKey, owner, err := fetchPublicKey(c, t, keyId)
+	if err != nil {
+		return
+	} else if pubKey == nil || owner == nil {
+		return
+	}
+	rsaKey, ok := pubKey.(*rsa.PublicKey)
+	if !ok {
+		return
+	}
+	if rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, hash, sigBytes) == nil {
+		signer = owner
+		verified = true
+	}
+	return
+}
+
+// ldDocumentLoader loads JSON-LD context documents from a fixed set, never
+// from the network.
+type ldDocumentLoader struct {
+	documents map[string]interface{}
+}
+
+// newLDDocumentLoader returns a loader of the preloaded contexts and the given
+// contexts, which take precedence.
+func newLDDocumentLoader(contexts map[string]interface{}) *ldDocumentLoader {
+	documents := make(map[string]interface{}, len(preloadedLDContextDocuments)+len(contexts))
+	for iri, doc := range preloadedLDContextDocuments {
+		documents[iri] = doc
+	}
+	for iri, doc := range contexts {
+		documents[iri] = doc
+	}
+	return &ldDocumentLoader{
+		documents: documents,
+	}
+}
+
+// LoadDocument returns the context document with the IRI, ignoring any
+// fragment, or an error if it is not known.
+func (l *ldDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	iri := u
+	if i := strings.IndexByte(iri, '#'); i >= 0 {
+		iri = iri[:i]
+	}
+	doc, ok := l.documents[iri]
+	if !ok {
+		return nil, ld.NewJsonLdError(ld.LoadingDocumentFailed, fmt.Sprintf("JSON-LD context %s is not known", u))
+	}
+	return &ld.RemoteDocument{
+		DocumentURL: u,
+		Document:    doc,
+	}, nil
+}
+
+// signedHash returns the SHA-256 hash that a Linked Data Signature with the
+// options signs for the document: the hex encoded hashes of the canonicalized
+// options and of the canonicalized document without its signature,
+// concatenated.
+func (l *ldDocumentLoader) signedHash(options, m map[string]interface{}) ([]byte, error) {
+	opts := make(map[string]interface{}, len(options)+1)
+	for k, v := range options {
+		opts[k] = v
+	}
+	opts["@context"] = identityV1LDContextIRI
+	optionsHash, err := l.canonicalHash(opts)
+	if err != nil {
+		return nil, err
+	}
+	doc := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k != ldSignatureProperty {
+			doc[k] = v
+		}
+	}
+	docHash, err := l.canonicalHash(doc)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256([]byte(optionsHash + docHash))
+	return h[:], nil
+}
+
+// canonicalHash returns the hex encoded SHA-256 hash of the URDNA2015
+// canonicalization of the JSON-LD document.
+func (l *ldDocumentLoader) canonicalHash(m map[string]interface{}) (string, error) {
+	// Round trip through JSON so that the processor only sees the types
+	// produced by encoding/json, and cannot modify the caller's values.
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	var doc interface{}
+	if err = json.Unmarshal(b, &doc); err != nil {
+		return "", err
+	}
+	opts := ld.NewJsonLdOptions("")
+	opts.Algorithm = "URDNA2015"
+	opts.Format = "application/n-quads"
+	opts.DocumentLoader = l
+	n, err := ld.NewJsonLdProcessor().Normalize(doc, opts)
+	if err != nil {
+		return "", err
+	}
+	s, ok := n.(string)
+	if !ok {
+		return "", fmt.Errorf("canonicalization produced %T instead of n-quads", n)
+	}
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:]), nil
+}
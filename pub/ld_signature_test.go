@@ -0,0 +1,135 @@
+package pub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/golang/mock/gomock"
+)
+
+// TestRsaSignature2017 tests signing and verifying Linked Data Signatures.
+func TestRsaSignature2017(t *testing.T) {
+	ctx := context.Background()
+	setupData()
+	key := mustGenerateRSAKey()
+	actor := newPersonWithKey(testFederatedActorIRI, testFederatedKeyId, testFederatedActorIRI, &key.PublicKey)
+	t.Run("VerifiesSignedActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		s := NewRsaSignature2017Signer(&fixedClock{now()}, nil, mustParse(testFederatedKeyId), key)
+		v := NewRsaSignature2017Verifier(nil)
+		tp := NewMockTransport(ctl)
+		m := mustSerialize(testCreate)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		err := s.SignLD(ctx, m)
+		assertEqual(t, err, nil)
+		signer, verified, err := v.VerifyLD(ctx, m, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, true)
+		assertEqual(t, signer.String(), testFederatedActorIRI)
+		sig := m[ldSignatureProperty].(map[string]interface{})
+		assertEqual(t, sig["type"], rsaSignature2017Type)
+		assertEqual(t, sig["creator"], testFederatedKeyId)
+		assertEqual(t, sig["created"], now().UTC().Format(ldSignatureTimeFormat))
+	})
+	t.Run("DoesNotVerifyModifiedActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		s := NewRsaSignature2017Signer(&fixedClock{now()}, nil, mustParse(testFederatedKeyId), key)
+		v := NewRsaSignature2017Verifier(nil)
+		tp := NewMockTransport(ctl)
+		m := mustSerialize(testCreate)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		err := s.SignLD(ctx, m)
+		assertEqual(t, err, nil)
+		m["actor"] = testFederatedActorIRI2
+		signer, verified, err := v.VerifyLD(ctx, m, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, false)
+		assertEqual(t, signer == nil, true)
+	})
+	t.Run("DoesNotVerifyUnknownContext", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		s := NewRsaSignature2017Signer(&fixedClock{now()}, nil, mustParse(testFederatedKeyId), key)
+		v := NewRsaSignature2017Verifier(nil)
+		tp := NewMockTransport(ctl)
+		m := mustSerialize(testCreate)
+		m["@context"] = "https://other.example.com/context"
+		// Run
+		err := s.SignLD(ctx, m)
+		// Verify
+		assertNotEqual(t, err, nil)
+		m[ldSignatureProperty] = map[string]interface{}{
+			"type":           rsaSignature2017Type,
+			"creator":        testFederatedKeyId,
+			"created":        now().UTC().Format(ldSignatureTimeFormat),
+			"signatureValue": "",
+		}
+		signer, verified, err := v.VerifyLD(ctx, m, tp)
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, false)
+		assertEqual(t, signer == nil, true)
+	})
+	t.Run("UsesSuppliedContext", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		contexts := map[string]interface{}{
+			"https://other.example.com/context": map[string]interface{}{
+				"@context": map[string]interface{}{
+					"actor": map[string]interface{}{
+						"@id":   "https://www.w3.org/ns/activitystreams#actor",
+						"@type": "@id",
+					},
+				},
+			},
+		}
+		s := NewRsaSignature2017Signer(&fixedClock{now()}, contexts, mustParse(testFederatedKeyId), key)
+		v := NewRsaSignature2017Verifier(contexts)
+		tp := NewMockTransport(ctl)
+		m := mustSerialize(testCreate)
+		m["@context"] = "https://other.example.com/context"
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		err := s.SignLD(ctx, m)
+		assertEqual(t, err, nil)
+		signer, verified, err := v.VerifyLD(ctx, m, tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, true)
+		assertEqual(t, signer.String(), testFederatedActorIRI)
+	})
+	t.Run("VerifiesDeserializedActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		s := NewRsaSignature2017Signer(&fixedClock{now()}, nil, mustParse(testFederatedKeyId), key)
+		v := NewRsaSignature2017Verifier(nil)
+		tp := NewMockTransport(ctl)
+		m := mustSerialize(testCreate)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(mustSerializeToBytes(actor), nil)
+		// Run
+		err := s.SignLD(ctx, m)
+		assertEqual(t, err, nil)
+		asValue, err := streams.ToType(ctx, m)
+		assertEqual(t, err, nil)
+		signer, verified, err := v.VerifyLD(ctx, mustSerialize(asValue), tp)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, true)
+		assertEqual(t, signer.String(), testFederatedActorIRI)
+	})
+}
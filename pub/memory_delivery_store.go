@@ -0,0 +1,107 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DeliveryStore must be implemented by MemoryDeliveryStore.
+var _ DeliveryStore = &MemoryDeliveryStore{}
+
+// MemoryDeliveryStore is a DeliveryStore that keeps deliveries in memory.
+//
+// Pending deliveries are lost when the process exits, so it is best suited for
+// tests and applications that can tolerate losing them.
+//
+// It is safe to use concurrently.
+type MemoryDeliveryStore struct {
+	mu          *sync.Mutex
+	nextId      int64
+	pending     map[string]Delivery
+	deadLetters []Delivery
+}
+
+// NewMemoryDeliveryStore returns a new, empty MemoryDeliveryStore.
+func NewMemoryDeliveryStore() *MemoryDeliveryStore {
+	return &MemoryDeliveryStore{
+		mu:      &sync.Mutex{},
+		pending: make(map[string]Delivery),
+	}
+}
+
+// Add saves new pending deliveries, assigning each a unique Id.
+func (m *MemoryDeliveryStore) Add(c context.Context, d []*Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range d {
+		m.nextId++
+		v.Id = strconv.FormatInt(m.nextId, 10)
+		m.pending[v.Id] = *v
+	}
+	return nil
+}
+
+// Due returns the pending deliveries whose NextAttempt is not after now, in
+// the order they were added.
+func (m *MemoryDeliveryStore) Due(c context.Context, now time.Time) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []*Delivery
+	for _, v := range m.pending {
+		if !v.NextAttempt.After(now) {
+			d := v
+			due = append(due, &d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		a, _ := strconv.ParseInt(due[i].Id, 10, 64)
+		b, _ := strconv.ParseInt(due[j].Id, 10, 64)
+		return a < b
+	})
+	return due, nil
+}
+
+// Update saves the changes made to a pending delivery.
+func (m *MemoryDeliveryStore) Update(c context.Context, d *Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.pending[d.Id]; !ok {
+		return fmt.Errorf("no pending delivery with id %q", d.Id)
+	}
+	m.pending[d.Id] = *d
+	return nil
+}
+
+// Remove deletes a pending delivery.
+func (m *MemoryDeliveryStore) Remove(c context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pending, id)
+	return nil
+}
+
+// DeadLetter removes a pending delivery and adds it to the dead-letter list.
+func (m *MemoryDeliveryStore) DeadLetter(c context.Context, d *Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pending, d.Id)
+	m.deadLetters = append(m.deadLetters, *d)
+	return nil
+}
+
+// DeadLetters returns the dead-letter list, in the order deliveries were added
+// to it.
+func (m *MemoryDeliveryStore) DeadLetters(c context.Context) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	dl := make([]*Delivery, 0, len(m.deadLetters))
+	for _, v := range m.deadLetters {
+		d := v
+		dl = append(dl, &d)
+	}
+	return dl, nil
+}
@@ -0,0 +1,88 @@
+package pub
+
+import (
+	"container/list"
+	"context"
+	"net/url"
+	"sync"
+)
+
+// DereferenceCache must be implemented by MemoryDereferenceCache.
+var _ DereferenceCache = &MemoryDereferenceCache{}
+
+// MemoryDereferenceCache is a DereferenceCache that keeps a bounded number of
+// entries in memory, evicting the least recently used entry when full.
+//
+// It is safe to use concurrently.
+type MemoryDereferenceCache struct {
+	mu       *sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+// memoryDereferenceCacheItem is an element of the MemoryDereferenceCache's
+// recency list.
+type memoryDereferenceCacheItem struct {
+	iri   string
+	entry DereferenceCacheEntry
+}
+
+// NewMemoryDereferenceCache returns a new, empty MemoryDereferenceCache
+// holding at most capacity entries. A capacity of zero or less means there is
+// no bound.
+func NewMemoryDereferenceCache(capacity int) *MemoryDereferenceCache {
+	return &MemoryDereferenceCache{
+		mu:       &sync.Mutex{},
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry for the IRI, or nil if there is none, marking it as
+// the most recently used.
+func (m *MemoryDereferenceCache) Get(c context.Context, iri *url.URL) (*DereferenceCacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[iri.String()]
+	if !ok {
+		return nil, nil
+	}
+	m.order.MoveToFront(e)
+	entry := e.Value.(*memoryDereferenceCacheItem).entry
+	return &entry, nil
+}
+
+// Put stores the entry for the IRI as the most recently used, evicting the
+// least recently used entry if the cache is full.
+func (m *MemoryDereferenceCache) Put(c context.Context, iri *url.URL, entry *DereferenceCacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[iri.String()]; ok {
+		e.Value.(*memoryDereferenceCacheItem).entry = *entry
+		m.order.MoveToFront(e)
+		return nil
+	}
+	m.entries[iri.String()] = m.order.PushFront(&memoryDereferenceCacheItem{
+		iri:   iri.String(),
+		entry: *entry,
+	})
+	if m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryDereferenceCacheItem).iri)
+	}
+	return nil
+}
+
+// Remove deletes the entry for the IRI, if any.
+func (m *MemoryDereferenceCache) Remove(c context.Context, iri *url.URL) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[iri.String()]; ok {
+		m.order.Remove(e)
+		delete(m.entries, iri.String())
+	}
+	return nil
+}
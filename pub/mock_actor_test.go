@@ -0,0 +1,357 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: actor.go
+
+// Package pub is a generated GoMock package.
+package pub
+
+import (
+	context "context"
+	vocab "github.com/go-fed/activity/streams/vocab"
+	gomock "github.com/golang/mock/gomock"
+	http "net/http"
+	url "net/url"
+	reflect "reflect"
+)
+
+// MockActor is a mock of Actor interface
+type MockActor struct {
+	ctrl     *gomock.Controller
+	recorder *MockActorMockRecorder
+}
+
+// MockActorMockRecorder is the mock recorder for MockActor
+type MockActorMockRecorder struct {
+	mock *MockActor
+}
+
+// NewMockActor creates a new mock instance
+func NewMockActor(ctrl *gomock.Controller) *MockActor {
+	mock := &MockActor{ctrl: ctrl}
+	mock.recorder = &MockActorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockActor) EXPECT() *MockActorMockRecorder {
+	return m.recorder
+}
+
+// PostInbox mocks base method
+func (m *MockActor) PostInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInbox indicates an expected call of PostInbox
+func (mr *MockActorMockRecorder) PostInbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInbox", reflect.TypeOf((*MockActor)(nil).PostInbox), c, w, r)
+}
+
+// PostInboxScheme mocks base method
+func (m *MockActor) PostInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInboxScheme indicates an expected call of PostInboxScheme
+func (mr *MockActorMockRecorder) PostInboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInboxScheme", reflect.TypeOf((*MockActor)(nil).PostInboxScheme), c, w, r, scheme)
+}
+
+// GetInbox mocks base method
+func (m *MockActor) GetInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox
+func (mr *MockActorMockRecorder) GetInbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockActor)(nil).GetInbox), c, w, r)
+}
+
+// GetInboxScheme mocks base method
+func (m *MockActor) GetInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxScheme indicates an expected call of GetInboxScheme
+func (mr *MockActorMockRecorder) GetInboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxScheme", reflect.TypeOf((*MockActor)(nil).GetInboxScheme), c, w, r, scheme)
+}
+
+// PostOutbox mocks base method
+func (m *MockActor) PostOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostOutbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostOutbox indicates an expected call of PostOutbox
+func (mr *MockActorMockRecorder) PostOutbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostOutbox", reflect.TypeOf((*MockActor)(nil).PostOutbox), c, w, r)
+}
+
+// PostOutboxScheme mocks base method
+func (m *MockActor) PostOutboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostOutboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostOutboxScheme indicates an expected call of PostOutboxScheme
+func (mr *MockActorMockRecorder) PostOutboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostOutboxScheme", reflect.TypeOf((*MockActor)(nil).PostOutboxScheme), c, w, r, scheme)
+}
+
+// GetOutbox mocks base method
+func (m *MockActor) GetOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutbox indicates an expected call of GetOutbox
+func (mr *MockActorMockRecorder) GetOutbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockActor)(nil).GetOutbox), c, w, r)
+}
+
+// GetOutboxScheme mocks base method
+func (m *MockActor) GetOutboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxScheme indicates an expected call of GetOutboxScheme
+func (mr *MockActorMockRecorder) GetOutboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxScheme", reflect.TypeOf((*MockActor)(nil).GetOutboxScheme), c, w, r, scheme)
+}
+
+// MockFederatingActor is a mock of FederatingActor interface
+type MockFederatingActor struct {
+	ctrl     *gomock.Controller
+	recorder *MockFederatingActorMockRecorder
+}
+
+// MockFederatingActorMockRecorder is the mock recorder for MockFederatingActor
+type MockFederatingActorMockRecorder struct {
+	mock *MockFederatingActor
+}
+
+// NewMockFederatingActor creates a new mock instance
+func NewMockFederatingActor(ctrl *gomock.Controller) *MockFederatingActor {
+	mock := &MockFederatingActor{ctrl: ctrl}
+	mock.recorder = &MockFederatingActorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockFederatingActor) EXPECT() *MockFederatingActorMockRecorder {
+	return m.recorder
+}
+
+// PostInbox mocks base method
+func (m *MockFederatingActor) PostInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInbox indicates an expected call of PostInbox
+func (mr *MockFederatingActorMockRecorder) PostInbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInbox", reflect.TypeOf((*MockFederatingActor)(nil).PostInbox), c, w, r)
+}
+
+// PostInboxScheme mocks base method
+func (m *MockFederatingActor) PostInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInboxScheme indicates an expected call of PostInboxScheme
+func (mr *MockFederatingActorMockRecorder) PostInboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInboxScheme", reflect.TypeOf((*MockFederatingActor)(nil).PostInboxScheme), c, w, r, scheme)
+}
+
+// GetInbox mocks base method
+func (m *MockFederatingActor) GetInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox
+func (mr *MockFederatingActorMockRecorder) GetInbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockFederatingActor)(nil).GetInbox), c, w, r)
+}
+
+// GetInboxScheme mocks base method
+func (m *MockFederatingActor) GetInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxScheme indicates an expected call of GetInboxScheme
+func (mr *MockFederatingActorMockRecorder) GetInboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxScheme", reflect.TypeOf((*MockFederatingActor)(nil).GetInboxScheme), c, w, r, scheme)
+}
+
+// PostOutbox mocks base method
+func (m *MockFederatingActor) PostOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostOutbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostOutbox indicates an expected call of PostOutbox
+func (mr *MockFederatingActorMockRecorder) PostOutbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostOutbox", reflect.TypeOf((*MockFederatingActor)(nil).PostOutbox), c, w, r)
+}
+
+// PostOutboxScheme mocks base method
+func (m *MockFederatingActor) PostOutboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostOutboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostOutboxScheme indicates an expected call of PostOutboxScheme
+func (mr *MockFederatingActorMockRecorder) PostOutboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostOutboxScheme", reflect.TypeOf((*MockFederatingActor)(nil).PostOutboxScheme), c, w, r, scheme)
+}
+
+// GetOutbox mocks base method
+func (m *MockFederatingActor) GetOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutbox", c, w, r)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutbox indicates an expected call of GetOutbox
+func (mr *MockFederatingActorMockRecorder) GetOutbox(c, w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockFederatingActor)(nil).GetOutbox), c, w, r)
+}
+
+// GetOutboxScheme mocks base method
+func (m *MockFederatingActor) GetOutboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxScheme", c, w, r, scheme)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxScheme indicates an expected call of GetOutboxScheme
+func (mr *MockFederatingActorMockRecorder) GetOutboxScheme(c, w, r, scheme interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxScheme", reflect.TypeOf((*MockFederatingActor)(nil).GetOutboxScheme), c, w, r, scheme)
+}
+
+// Send mocks base method
+func (m *MockFederatingActor) Send(c context.Context, outbox *url.URL, t vocab.Type) (Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", c, outbox, t)
+	ret0, _ := ret[0].(Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send
+func (mr *MockFederatingActorMockRecorder) Send(c, outbox, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockFederatingActor)(nil).Send), c, outbox, t)
+}
+
+// ApproveFollowRequest mocks base method
+func (m *MockFederatingActor) ApproveFollowRequest(c context.Context, inbox, follow *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveFollowRequest", c, inbox, follow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveFollowRequest indicates an expected call of ApproveFollowRequest
+func (mr *MockFederatingActorMockRecorder) ApproveFollowRequest(c, inbox, follow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveFollowRequest", reflect.TypeOf((*MockFederatingActor)(nil).ApproveFollowRequest), c, inbox, follow)
+}
+
+// DenyFollowRequest mocks base method
+func (m *MockFederatingActor) DenyFollowRequest(c context.Context, inbox, follow *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyFollowRequest", c, inbox, follow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyFollowRequest indicates an expected call of DenyFollowRequest
+func (mr *MockFederatingActorMockRecorder) DenyFollowRequest(c, inbox, follow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyFollowRequest", reflect.TypeOf((*MockFederatingActor)(nil).DenyFollowRequest), c, inbox, follow)
+}
+
+// ForwardReport mocks base method
+func (m *MockFederatingActor) ForwardReport(c context.Context, inbox, report *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForwardReport", c, inbox, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForwardReport indicates an expected call of ForwardReport
+func (mr *MockFederatingActorMockRecorder) ForwardReport(c, inbox, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardReport", reflect.TypeOf((*MockFederatingActor)(nil).ForwardReport), c, inbox, report)
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: caching_transport.go
+
+// Package pub is a generated GoMock package.
+package pub
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	url "net/url"
+	reflect "reflect"
+)
+
+// MockDereferenceCache is a mock of DereferenceCache interface
+type MockDereferenceCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockDereferenceCacheMockRecorder
+}
+
+// MockDereferenceCacheMockRecorder is the mock recorder for MockDereferenceCache
+type MockDereferenceCacheMockRecorder struct {
+	mock *MockDereferenceCache
+}
+
+// NewMockDereferenceCache creates a new mock instance
+func NewMockDereferenceCache(ctrl *gomock.Controller) *MockDereferenceCache {
+	mock := &MockDereferenceCache{ctrl: ctrl}
+	mock.recorder = &MockDereferenceCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDereferenceCache) EXPECT() *MockDereferenceCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockDereferenceCache) Get(c context.Context, iri *url.URL) (*DereferenceCacheEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", c, iri)
+	ret0, _ := ret[0].(*DereferenceCacheEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockDereferenceCacheMockRecorder) Get(c, iri interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDereferenceCache)(nil).Get), c, iri)
+}
+
+// Put mocks base method
+func (m *MockDereferenceCache) Put(c context.Context, iri *url.URL, entry *DereferenceCacheEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", c, iri, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put
+func (mr *MockDereferenceCacheMockRecorder) Put(c, iri, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockDereferenceCache)(nil).Put), c, iri, entry)
+}
+
+// Remove mocks base method
+func (m *MockDereferenceCache) Remove(c context.Context, iri *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", c, iri)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove
+func (mr *MockDereferenceCacheMockRecorder) Remove(c, iri interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockDereferenceCache)(nil).Remove), c, iri)
+}
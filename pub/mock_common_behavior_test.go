@@ -1,12 +1,11 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: pub/common_behavior.go
+// Source: common_behavior.go
 
 // Package pub is a generated GoMock package.
 package pub
 
 import (
 	context "context"
-	vocab "github.com/go-fed/activity/streams/vocab"
 	gomock "github.com/golang/mock/gomock"
 	http "net/http"
 	url "net/url"
@@ -68,21 +67,6 @@ func (mr *MockCommonBehaviorMockRecorder) AuthenticateGetOutbox(c, w, r interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateGetOutbox", reflect.TypeOf((*MockCommonBehavior)(nil).AuthenticateGetOutbox), c, w, r)
 }
 
-// GetOutbox mocks base method
-func (m *MockCommonBehavior) GetOutbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOutbox", c, r)
-	ret0, _ := ret[0].(vocab.ActivityStreamsOrderedCollectionPage)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetOutbox indicates an expected call of GetOutbox
-func (mr *MockCommonBehaviorMockRecorder) GetOutbox(c, r interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockCommonBehavior)(nil).GetOutbox), c, r)
-}
-
 // NewTransport mocks base method
 func (m *MockCommonBehavior) NewTransport(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (Transport, error) {
 	m.ctrl.T.Helper()
@@ -97,3 +81,40 @@ func (mr *MockCommonBehaviorMockRecorder) NewTransport(c, actorBoxIRI, gofedAgen
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransport", reflect.TypeOf((*MockCommonBehavior)(nil).NewTransport), c, actorBoxIRI, gofedAgent)
 }
+
+// MockCollectionPagePolicy is a mock of CollectionPagePolicy interface
+type MockCollectionPagePolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionPagePolicyMockRecorder
+}
+
+// MockCollectionPagePolicyMockRecorder is the mock recorder for MockCollectionPagePolicy
+type MockCollectionPagePolicyMockRecorder struct {
+	mock *MockCollectionPagePolicy
+}
+
+// NewMockCollectionPagePolicy creates a new mock instance
+func NewMockCollectionPagePolicy(ctrl *gomock.Controller) *MockCollectionPagePolicy {
+	mock := &MockCollectionPagePolicy{ctrl: ctrl}
+	mock.recorder = &MockCollectionPagePolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCollectionPagePolicy) EXPECT() *MockCollectionPagePolicyMockRecorder {
+	return m.recorder
+}
+
+// CollectionPageSize mocks base method
+func (m *MockCollectionPagePolicy) CollectionPageSize(c context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectionPageSize", c)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// CollectionPageSize indicates an expected call of CollectionPageSize
+func (mr *MockCollectionPagePolicyMockRecorder) CollectionPageSize(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectionPageSize", reflect.TypeOf((*MockCollectionPagePolicy)(nil).CollectionPageSize), c)
+}
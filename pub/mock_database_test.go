@@ -268,6 +268,22 @@ func (mr *MockDatabaseMockRecorder) SetOutbox(c, outbox interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutbox", reflect.TypeOf((*MockDatabase)(nil).SetOutbox), c, outbox)
 }
 
+// GetOrderedCollectionItems mocks base method
+func (m *MockDatabase) GetOrderedCollectionItems(c context.Context, collectionIRI *url.URL, cursor CollectionCursor) (vocab.ActivityStreamsOrderedItemsProperty, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderedCollectionItems", c, collectionIRI, cursor)
+	ret0, _ := ret[0].(vocab.ActivityStreamsOrderedItemsProperty)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrderedCollectionItems indicates an expected call of GetOrderedCollectionItems
+func (mr *MockDatabaseMockRecorder) GetOrderedCollectionItems(c, collectionIRI, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderedCollectionItems", reflect.TypeOf((*MockDatabase)(nil).GetOrderedCollectionItems), c, collectionIRI, cursor)
+}
+
 // NewID mocks base method
 func (m *MockDatabase) NewID(c context.Context, t vocab.Type) (*url.URL, error) {
 	m.ctrl.T.Helper()
@@ -327,3 +343,41 @@ func (mr *MockDatabaseMockRecorder) Liked(c, actorIRI interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liked", reflect.TypeOf((*MockDatabase)(nil).Liked), c, actorIRI)
 }
+
+// MockFollowRequestsDatabase is a mock of FollowRequestsDatabase interface
+type MockFollowRequestsDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowRequestsDatabaseMockRecorder
+}
+
+// MockFollowRequestsDatabaseMockRecorder is the mock recorder for MockFollowRequestsDatabase
+type MockFollowRequestsDatabaseMockRecorder struct {
+	mock *MockFollowRequestsDatabase
+}
+
+// NewMockFollowRequestsDatabase creates a new mock instance
+func NewMockFollowRequestsDatabase(ctrl *gomock.Controller) *MockFollowRequestsDatabase {
+	mock := &MockFollowRequestsDatabase{ctrl: ctrl}
+	mock.recorder = &MockFollowRequestsDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockFollowRequestsDatabase) EXPECT() *MockFollowRequestsDatabaseMockRecorder {
+	return m.recorder
+}
+
+// FollowRequests mocks base method
+func (m *MockFollowRequestsDatabase) FollowRequests(c context.Context, actorIRI *url.URL) (vocab.ActivityStreamsCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowRequests", c, actorIRI)
+	ret0, _ := ret[0].(vocab.ActivityStreamsCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowRequests indicates an expected call of FollowRequests
+func (mr *MockFollowRequestsDatabaseMockRecorder) FollowRequests(c, actorIRI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowRequests", reflect.TypeOf((*MockFollowRequestsDatabase)(nil).FollowRequests), c, actorIRI)
+}
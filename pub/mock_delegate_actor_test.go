@@ -82,6 +82,20 @@ func (mr *MockDelegateActorMockRecorder) AuthenticatePostInbox(c, w, r interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticatePostInbox", reflect.TypeOf((*MockDelegateActor)(nil).AuthenticatePostInbox), c, w, r)
 }
 
+// PostInboxDigestAlgorithms mocks base method
+func (m *MockDelegateActor) PostInboxDigestAlgorithms(c context.Context, inboxIRI *url.URL) []DigestAlgorithm {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInboxDigestAlgorithms", c, inboxIRI)
+	ret0, _ := ret[0].([]DigestAlgorithm)
+	return ret0
+}
+
+// PostInboxDigestAlgorithms indicates an expected call of PostInboxDigestAlgorithms
+func (mr *MockDelegateActorMockRecorder) PostInboxDigestAlgorithms(c, inboxIRI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInboxDigestAlgorithms", reflect.TypeOf((*MockDelegateActor)(nil).PostInboxDigestAlgorithms), c, inboxIRI)
+}
+
 // AuthenticateGetInbox mocks base method
 func (m *MockDelegateActor) AuthenticateGetInbox(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +198,34 @@ func (mr *MockDelegateActorMockRecorder) Deliver(c, outbox, activity interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockDelegateActor)(nil).Deliver), c, outbox, activity)
 }
 
+// RespondToFollowRequest mocks base method
+func (m *MockDelegateActor) RespondToFollowRequest(c context.Context, inboxIRI, followIRI *url.URL, accept bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondToFollowRequest", c, inboxIRI, followIRI, accept)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondToFollowRequest indicates an expected call of RespondToFollowRequest
+func (mr *MockDelegateActorMockRecorder) RespondToFollowRequest(c, inboxIRI, followIRI, accept interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToFollowRequest", reflect.TypeOf((*MockDelegateActor)(nil).RespondToFollowRequest), c, inboxIRI, followIRI, accept)
+}
+
+// ForwardReport mocks base method
+func (m *MockDelegateActor) ForwardReport(c context.Context, inboxIRI, reportIRI *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForwardReport", c, inboxIRI, reportIRI)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForwardReport indicates an expected call of ForwardReport
+func (mr *MockDelegateActorMockRecorder) ForwardReport(c, inboxIRI, reportIRI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardReport", reflect.TypeOf((*MockDelegateActor)(nil).ForwardReport), c, inboxIRI, reportIRI)
+}
+
 // AuthenticatePostOutbox mocks base method
 func (m *MockDelegateActor) AuthenticatePostOutbox(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
 	m.ctrl.T.Helper()
@@ -232,31 +274,47 @@ func (mr *MockDelegateActorMockRecorder) WrapInCreate(c, value, outboxIRI interf
 }
 
 // GetOutbox mocks base method
-func (m *MockDelegateActor) GetOutbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error) {
+func (m *MockDelegateActor) GetOutbox(c context.Context, r *http.Request, outboxIRI *url.URL, cursor CollectionCursor) (vocab.ActivityStreamsOrderedItemsProperty, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOutbox", c, r)
-	ret0, _ := ret[0].(vocab.ActivityStreamsOrderedCollectionPage)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetOutbox", c, r, outboxIRI, cursor)
+	ret0, _ := ret[0].(vocab.ActivityStreamsOrderedItemsProperty)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetOutbox indicates an expected call of GetOutbox
-func (mr *MockDelegateActorMockRecorder) GetOutbox(c, r interface{}) *gomock.Call {
+func (mr *MockDelegateActorMockRecorder) GetOutbox(c, r, outboxIRI, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockDelegateActor)(nil).GetOutbox), c, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockDelegateActor)(nil).GetOutbox), c, r, outboxIRI, cursor)
 }
 
 // GetInbox mocks base method
-func (m *MockDelegateActor) GetInbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error) {
+func (m *MockDelegateActor) GetInbox(c context.Context, r *http.Request, inboxIRI *url.URL, cursor CollectionCursor) (vocab.ActivityStreamsOrderedItemsProperty, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInbox", c, r)
-	ret0, _ := ret[0].(vocab.ActivityStreamsOrderedCollectionPage)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetInbox", c, r, inboxIRI, cursor)
+	ret0, _ := ret[0].(vocab.ActivityStreamsOrderedItemsProperty)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetInbox indicates an expected call of GetInbox
-func (mr *MockDelegateActorMockRecorder) GetInbox(c, r interface{}) *gomock.Call {
+func (mr *MockDelegateActorMockRecorder) GetInbox(c, r, inboxIRI, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockDelegateActor)(nil).GetInbox), c, r, inboxIRI, cursor)
+}
+
+// CollectionPageSize mocks base method
+func (m *MockDelegateActor) CollectionPageSize(c context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectionPageSize", c)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// CollectionPageSize indicates an expected call of CollectionPageSize
+func (mr *MockDelegateActorMockRecorder) CollectionPageSize(c interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockDelegateActor)(nil).GetInbox), c, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectionPageSize", reflect.TypeOf((*MockDelegateActor)(nil).CollectionPageSize), c)
 }
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery_queue.go
+
+// Package pub is a generated GoMock package.
+package pub
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	url "net/url"
+	reflect "reflect"
+	time "time"
+)
+
+// MockDeliveryQueue is a mock of DeliveryQueue interface
+type MockDeliveryQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryQueueMockRecorder
+}
+
+// MockDeliveryQueueMockRecorder is the mock recorder for MockDeliveryQueue
+type MockDeliveryQueueMockRecorder struct {
+	mock *MockDeliveryQueue
+}
+
+// NewMockDeliveryQueue creates a new mock instance
+func NewMockDeliveryQueue(ctrl *gomock.Controller) *MockDeliveryQueue {
+	mock := &MockDeliveryQueue{ctrl: ctrl}
+	mock.recorder = &MockDeliveryQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeliveryQueue) EXPECT() *MockDeliveryQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method
+func (m *MockDeliveryQueue) Enqueue(c context.Context, boxIRI *url.URL, b []byte, recipients []*url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", c, boxIRI, b, recipients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue
+func (mr *MockDeliveryQueueMockRecorder) Enqueue(c, boxIRI, b, recipients interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockDeliveryQueue)(nil).Enqueue), c, boxIRI, b, recipients)
+}
+
+// MockDeliveryStore is a mock of DeliveryStore interface
+type MockDeliveryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryStoreMockRecorder
+}
+
+// MockDeliveryStoreMockRecorder is the mock recorder for MockDeliveryStore
+type MockDeliveryStoreMockRecorder struct {
+	mock *MockDeliveryStore
+}
+
+// NewMockDeliveryStore creates a new mock instance
+func NewMockDeliveryStore(ctrl *gomock.Controller) *MockDeliveryStore {
+	mock := &MockDeliveryStore{ctrl: ctrl}
+	mock.recorder = &MockDeliveryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeliveryStore) EXPECT() *MockDeliveryStoreMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method
+func (m *MockDeliveryStore) Add(c context.Context, d []*Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", c, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add
+func (mr *MockDeliveryStoreMockRecorder) Add(c, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockDeliveryStore)(nil).Add), c, d)
+}
+
+// Due mocks base method
+func (m *MockDeliveryStore) Due(c context.Context, now time.Time) ([]*Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Due", c, now)
+	ret0, _ := ret[0].([]*Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Due indicates an expected call of Due
+func (mr *MockDeliveryStoreMockRecorder) Due(c, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Due", reflect.TypeOf((*MockDeliveryStore)(nil).Due), c, now)
+}
+
+// Update mocks base method
+func (m *MockDeliveryStore) Update(c context.Context, d *Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", c, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockDeliveryStoreMockRecorder) Update(c, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeliveryStore)(nil).Update), c, d)
+}
+
+// Remove mocks base method
+func (m *MockDeliveryStore) Remove(c context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", c, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove
+func (mr *MockDeliveryStoreMockRecorder) Remove(c, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockDeliveryStore)(nil).Remove), c, id)
+}
+
+// DeadLetter mocks base method
+func (m *MockDeliveryStore) DeadLetter(c context.Context, d *Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetter", c, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeadLetter indicates an expected call of DeadLetter
+func (mr *MockDeliveryStoreMockRecorder) DeadLetter(c, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetter", reflect.TypeOf((*MockDeliveryStore)(nil).DeadLetter), c, d)
+}
+
+// DeadLetters mocks base method
+func (m *MockDeliveryStore) DeadLetters(c context.Context) ([]*Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters", c)
+	ret0, _ := ret[0].([]*Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters
+func (mr *MockDeliveryStoreMockRecorder) DeadLetters(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockDeliveryStore)(nil).DeadLetters), c)
+}
@@ -6,7 +6,6 @@ package pub
 
 import (
 	context "context"
-	vocab "github.com/go-fed/activity/streams/vocab"
 	gomock "github.com/golang/mock/gomock"
 	http "net/http"
 	url "net/url"
@@ -155,17 +154,314 @@ func (mr *MockFederatingProtocolMockRecorder) FilterForwarding(c, potentialRecip
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterForwarding", reflect.TypeOf((*MockFederatingProtocol)(nil).FilterForwarding), c, potentialRecipients, a)
 }
 
-// GetInbox mocks base method
-func (m *MockFederatingProtocol) GetInbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error) {
+// MockBlockStoreProvider is a mock of BlockStoreProvider interface
+type MockBlockStoreProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStoreProviderMockRecorder
+}
+
+// MockBlockStoreProviderMockRecorder is the mock recorder for MockBlockStoreProvider
+type MockBlockStoreProviderMockRecorder struct {
+	mock *MockBlockStoreProvider
+}
+
+// NewMockBlockStoreProvider creates a new mock instance
+func NewMockBlockStoreProvider(ctrl *gomock.Controller) *MockBlockStoreProvider {
+	mock := &MockBlockStoreProvider{ctrl: ctrl}
+	mock.recorder = &MockBlockStoreProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockBlockStoreProvider) EXPECT() *MockBlockStoreProviderMockRecorder {
+	return m.recorder
+}
+
+// BlockStore mocks base method
+func (m *MockBlockStoreProvider) BlockStore(c context.Context) BlockStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockStore", c)
+	ret0, _ := ret[0].(BlockStore)
+	return ret0
+}
+
+// BlockStore indicates an expected call of BlockStore
+func (mr *MockBlockStoreProviderMockRecorder) BlockStore(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockStore", reflect.TypeOf((*MockBlockStoreProvider)(nil).BlockStore), c)
+}
+
+// MockPostInboxDigestPolicy is a mock of PostInboxDigestPolicy interface
+type MockPostInboxDigestPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostInboxDigestPolicyMockRecorder
+}
+
+// MockPostInboxDigestPolicyMockRecorder is the mock recorder for MockPostInboxDigestPolicy
+type MockPostInboxDigestPolicyMockRecorder struct {
+	mock *MockPostInboxDigestPolicy
+}
+
+// NewMockPostInboxDigestPolicy creates a new mock instance
+func NewMockPostInboxDigestPolicy(ctrl *gomock.Controller) *MockPostInboxDigestPolicy {
+	mock := &MockPostInboxDigestPolicy{ctrl: ctrl}
+	mock.recorder = &MockPostInboxDigestPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPostInboxDigestPolicy) EXPECT() *MockPostInboxDigestPolicyMockRecorder {
+	return m.recorder
+}
+
+// PostInboxDigestAlgorithms mocks base method
+func (m *MockPostInboxDigestPolicy) PostInboxDigestAlgorithms(c context.Context, inboxIRI *url.URL) []DigestAlgorithm {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInboxDigestAlgorithms", c, inboxIRI)
+	ret0, _ := ret[0].([]DigestAlgorithm)
+	return ret0
+}
+
+// PostInboxDigestAlgorithms indicates an expected call of PostInboxDigestAlgorithms
+func (mr *MockPostInboxDigestPolicyMockRecorder) PostInboxDigestAlgorithms(c, inboxIRI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInboxDigestAlgorithms", reflect.TypeOf((*MockPostInboxDigestPolicy)(nil).PostInboxDigestAlgorithms), c, inboxIRI)
+}
+
+// MockDeliveryCollectionPolicy is a mock of DeliveryCollectionPolicy interface
+type MockDeliveryCollectionPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryCollectionPolicyMockRecorder
+}
+
+// MockDeliveryCollectionPolicyMockRecorder is the mock recorder for MockDeliveryCollectionPolicy
+type MockDeliveryCollectionPolicyMockRecorder struct {
+	mock *MockDeliveryCollectionPolicy
+}
+
+// NewMockDeliveryCollectionPolicy creates a new mock instance
+func NewMockDeliveryCollectionPolicy(ctrl *gomock.Controller) *MockDeliveryCollectionPolicy {
+	mock := &MockDeliveryCollectionPolicy{ctrl: ctrl}
+	mock.recorder = &MockDeliveryCollectionPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeliveryCollectionPolicy) EXPECT() *MockDeliveryCollectionPolicyMockRecorder {
+	return m.recorder
+}
+
+// MaxDeliveryCollectionPages mocks base method
+func (m *MockDeliveryCollectionPolicy) MaxDeliveryCollectionPages(c context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxDeliveryCollectionPages", c)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// MaxDeliveryCollectionPages indicates an expected call of MaxDeliveryCollectionPages
+func (mr *MockDeliveryCollectionPolicyMockRecorder) MaxDeliveryCollectionPages(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxDeliveryCollectionPages", reflect.TypeOf((*MockDeliveryCollectionPolicy)(nil).MaxDeliveryCollectionPages), c)
+}
+
+// MaxDeliveryCollectionItems mocks base method
+func (m *MockDeliveryCollectionPolicy) MaxDeliveryCollectionItems(c context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxDeliveryCollectionItems", c)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// MaxDeliveryCollectionItems indicates an expected call of MaxDeliveryCollectionItems
+func (mr *MockDeliveryCollectionPolicyMockRecorder) MaxDeliveryCollectionItems(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxDeliveryCollectionItems", reflect.TypeOf((*MockDeliveryCollectionPolicy)(nil).MaxDeliveryCollectionItems), c)
+}
+
+// MockPublicSharedInboxesProvider is a mock of PublicSharedInboxesProvider interface
+type MockPublicSharedInboxesProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicSharedInboxesProviderMockRecorder
+}
+
+// MockPublicSharedInboxesProviderMockRecorder is the mock recorder for MockPublicSharedInboxesProvider
+type MockPublicSharedInboxesProviderMockRecorder struct {
+	mock *MockPublicSharedInboxesProvider
+}
+
+// NewMockPublicSharedInboxesProvider creates a new mock instance
+func NewMockPublicSharedInboxesProvider(ctrl *gomock.Controller) *MockPublicSharedInboxesProvider {
+	mock := &MockPublicSharedInboxesProvider{ctrl: ctrl}
+	mock.recorder = &MockPublicSharedInboxesProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPublicSharedInboxesProvider) EXPECT() *MockPublicSharedInboxesProviderMockRecorder {
+	return m.recorder
+}
+
+// PublicSharedInboxes mocks base method
+func (m *MockPublicSharedInboxesProvider) PublicSharedInboxes(c context.Context) ([]*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicSharedInboxes", c)
+	ret0, _ := ret[0].([]*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublicSharedInboxes indicates an expected call of PublicSharedInboxes
+func (mr *MockPublicSharedInboxesProviderMockRecorder) PublicSharedInboxes(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicSharedInboxes", reflect.TypeOf((*MockPublicSharedInboxesProvider)(nil).PublicSharedInboxes), c)
+}
+
+// MockDeliveryQueueProvider is a mock of DeliveryQueueProvider interface
+type MockDeliveryQueueProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryQueueProviderMockRecorder
+}
+
+// MockDeliveryQueueProviderMockRecorder is the mock recorder for MockDeliveryQueueProvider
+type MockDeliveryQueueProviderMockRecorder struct {
+	mock *MockDeliveryQueueProvider
+}
+
+// NewMockDeliveryQueueProvider creates a new mock instance
+func NewMockDeliveryQueueProvider(ctrl *gomock.Controller) *MockDeliveryQueueProvider {
+	mock := &MockDeliveryQueueProvider{ctrl: ctrl}
+	mock.recorder = &MockDeliveryQueueProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeliveryQueueProvider) EXPECT() *MockDeliveryQueueProviderMockRecorder {
+	return m.recorder
+}
+
+// DeliveryQueue mocks base method
+func (m *MockDeliveryQueueProvider) DeliveryQueue(c context.Context) DeliveryQueue {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryQueue", c)
+	ret0, _ := ret[0].(DeliveryQueue)
+	return ret0
+}
+
+// DeliveryQueue indicates an expected call of DeliveryQueue
+func (mr *MockDeliveryQueueProviderMockRecorder) DeliveryQueue(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryQueue", reflect.TypeOf((*MockDeliveryQueueProvider)(nil).DeliveryQueue), c)
+}
+
+// MockModerationQueueProvider is a mock of ModerationQueueProvider interface
+type MockModerationQueueProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationQueueProviderMockRecorder
+}
+
+// MockModerationQueueProviderMockRecorder is the mock recorder for MockModerationQueueProvider
+type MockModerationQueueProviderMockRecorder struct {
+	mock *MockModerationQueueProvider
+}
+
+// NewMockModerationQueueProvider creates a new mock instance
+func NewMockModerationQueueProvider(ctrl *gomock.Controller) *MockModerationQueueProvider {
+	mock := &MockModerationQueueProvider{ctrl: ctrl}
+	mock.recorder = &MockModerationQueueProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockModerationQueueProvider) EXPECT() *MockModerationQueueProviderMockRecorder {
+	return m.recorder
+}
+
+// ModerationQueue mocks base method
+func (m *MockModerationQueueProvider) ModerationQueue(c context.Context) ModerationQueue {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerationQueue", c)
+	ret0, _ := ret[0].(ModerationQueue)
+	return ret0
+}
+
+// ModerationQueue indicates an expected call of ModerationQueue
+func (mr *MockModerationQueueProviderMockRecorder) ModerationQueue(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerationQueue", reflect.TypeOf((*MockModerationQueueProvider)(nil).ModerationQueue), c)
+}
+
+// MockVoteStoreProvider is a mock of VoteStoreProvider interface
+type MockVoteStoreProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoteStoreProviderMockRecorder
+}
+
+// MockVoteStoreProviderMockRecorder is the mock recorder for MockVoteStoreProvider
+type MockVoteStoreProviderMockRecorder struct {
+	mock *MockVoteStoreProvider
+}
+
+// NewMockVoteStoreProvider creates a new mock instance
+func NewMockVoteStoreProvider(ctrl *gomock.Controller) *MockVoteStoreProvider {
+	mock := &MockVoteStoreProvider{ctrl: ctrl}
+	mock.recorder = &MockVoteStoreProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVoteStoreProvider) EXPECT() *MockVoteStoreProviderMockRecorder {
+	return m.recorder
+}
+
+// VoteStore mocks base method
+func (m *MockVoteStoreProvider) VoteStore(c context.Context) VoteStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteStore", c)
+	ret0, _ := ret[0].(VoteStore)
+	return ret0
+}
+
+// VoteStore indicates an expected call of VoteStore
+func (mr *MockVoteStoreProviderMockRecorder) VoteStore(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteStore", reflect.TypeOf((*MockVoteStoreProvider)(nil).VoteStore), c)
+}
+
+// MockLDSignerProvider is a mock of LDSignerProvider interface
+type MockLDSignerProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockLDSignerProviderMockRecorder
+}
+
+// MockLDSignerProviderMockRecorder is the mock recorder for MockLDSignerProvider
+type MockLDSignerProviderMockRecorder struct {
+	mock *MockLDSignerProvider
+}
+
+// NewMockLDSignerProvider creates a new mock instance
+func NewMockLDSignerProvider(ctrl *gomock.Controller) *MockLDSignerProvider {
+	mock := &MockLDSignerProvider{ctrl: ctrl}
+	mock.recorder = &MockLDSignerProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLDSignerProvider) EXPECT() *MockLDSignerProviderMockRecorder {
+	return m.recorder
+}
+
+// LDSigner mocks base method
+func (m *MockLDSignerProvider) LDSigner(c context.Context, outboxIRI *url.URL) (LDSigner, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInbox", c, r)
-	ret0, _ := ret[0].(vocab.ActivityStreamsOrderedCollectionPage)
+	ret := m.ctrl.Call(m, "LDSigner", c, outboxIRI)
+	ret0, _ := ret[0].(LDSigner)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetInbox indicates an expected call of GetInbox
-func (mr *MockFederatingProtocolMockRecorder) GetInbox(c, r interface{}) *gomock.Call {
+// LDSigner indicates an expected call of LDSigner
+func (mr *MockLDSignerProviderMockRecorder) LDSigner(c, outboxIRI interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockFederatingProtocol)(nil).GetInbox), c, r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LDSigner", reflect.TypeOf((*MockLDSignerProvider)(nil).LDSigner), c, outboxIRI)
 }
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handlers.go
+
+// Package pub is a generated GoMock package.
+package pub
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	http "net/http"
+	url "net/url"
+	reflect "reflect"
+)
+
+// MockRequestVerifier is a mock of RequestVerifier interface
+type MockRequestVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestVerifierMockRecorder
+}
+
+// MockRequestVerifierMockRecorder is the mock recorder for MockRequestVerifier
+type MockRequestVerifierMockRecorder struct {
+	mock *MockRequestVerifier
+}
+
+// NewMockRequestVerifier creates a new mock instance
+func NewMockRequestVerifier(ctrl *gomock.Controller) *MockRequestVerifier {
+	mock := &MockRequestVerifier{ctrl: ctrl}
+	mock.recorder = &MockRequestVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRequestVerifier) EXPECT() *MockRequestVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyRequest mocks base method
+func (m *MockRequestVerifier) VerifyRequest(c context.Context, r *http.Request, t Transport) (*url.URL, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyRequest", c, r, t)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyRequest indicates an expected call of VerifyRequest
+func (mr *MockRequestVerifierMockRecorder) VerifyRequest(c, r, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRequest", reflect.TypeOf((*MockRequestVerifier)(nil).VerifyRequest), c, r, t)
+}
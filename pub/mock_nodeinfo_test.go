@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: nodeinfo.go
+
+// Package pub is a generated GoMock package.
+package pub
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockNodeInfoProvider is a mock of NodeInfoProvider interface
+type MockNodeInfoProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeInfoProviderMockRecorder
+}
+
+// MockNodeInfoProviderMockRecorder is the mock recorder for MockNodeInfoProvider
+type MockNodeInfoProviderMockRecorder struct {
+	mock *MockNodeInfoProvider
+}
+
+// NewMockNodeInfoProvider creates a new mock instance
+func NewMockNodeInfoProvider(ctrl *gomock.Controller) *MockNodeInfoProvider {
+	mock := &MockNodeInfoProvider{ctrl: ctrl}
+	mock.recorder = &MockNodeInfoProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNodeInfoProvider) EXPECT() *MockNodeInfoProviderMockRecorder {
+	return m.recorder
+}
+
+// Software mocks base method
+func (m *MockNodeInfoProvider) Software(c context.Context) (NodeInfoSoftware, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Software", c)
+	ret0, _ := ret[0].(NodeInfoSoftware)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Software indicates an expected call of Software
+func (mr *MockNodeInfoProviderMockRecorder) Software(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Software", reflect.TypeOf((*MockNodeInfoProvider)(nil).Software), c)
+}
+
+// Protocols mocks base method
+func (m *MockNodeInfoProvider) Protocols(c context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Protocols", c)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Protocols indicates an expected call of Protocols
+func (mr *MockNodeInfoProviderMockRecorder) Protocols(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Protocols", reflect.TypeOf((*MockNodeInfoProvider)(nil).Protocols), c)
+}
+
+// Users mocks base method
+func (m *MockNodeInfoProvider) Users(c context.Context) (NodeInfoUsers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users", c)
+	ret0, _ := ret[0].(NodeInfoUsers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Users indicates an expected call of Users
+func (mr *MockNodeInfoProviderMockRecorder) Users(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockNodeInfoProvider)(nil).Users), c)
+}
+
+// LocalPosts mocks base method
+func (m *MockNodeInfoProvider) LocalPosts(c context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalPosts", c)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalPosts indicates an expected call of LocalPosts
+func (mr *MockNodeInfoProviderMockRecorder) LocalPosts(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalPosts", reflect.TypeOf((*MockNodeInfoProvider)(nil).LocalPosts), c)
+}
+
+// OpenRegistrations mocks base method
+func (m *MockNodeInfoProvider) OpenRegistrations(c context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenRegistrations", c)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenRegistrations indicates an expected call of OpenRegistrations
+func (mr *MockNodeInfoProviderMockRecorder) OpenRegistrations(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenRegistrations", reflect.TypeOf((*MockNodeInfoProvider)(nil).OpenRegistrations), c)
+}
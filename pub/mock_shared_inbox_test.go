@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: shared_inbox.go
+
+// Package pub is a generated GoMock package.
+package pub
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	url "net/url"
+	reflect "reflect"
+)
+
+// MockSharedInboxLookup is a mock of SharedInboxLookup interface
+type MockSharedInboxLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockSharedInboxLookupMockRecorder
+}
+
+// MockSharedInboxLookupMockRecorder is the mock recorder for MockSharedInboxLookup
+type MockSharedInboxLookupMockRecorder struct {
+	mock *MockSharedInboxLookup
+}
+
+// NewMockSharedInboxLookup creates a new mock instance
+func NewMockSharedInboxLookup(ctrl *gomock.Controller) *MockSharedInboxLookup {
+	mock := &MockSharedInboxLookup{ctrl: ctrl}
+	mock.recorder = &MockSharedInboxLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSharedInboxLookup) EXPECT() *MockSharedInboxLookupMockRecorder {
+	return m.recorder
+}
+
+// InboxForActor mocks base method
+func (m *MockSharedInboxLookup) InboxForActor(c context.Context, actorIRI *url.URL) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InboxForActor", c, actorIRI)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InboxForActor indicates an expected call of InboxForActor
+func (mr *MockSharedInboxLookupMockRecorder) InboxForActor(c, actorIRI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InboxForActor", reflect.TypeOf((*MockSharedInboxLookup)(nil).InboxForActor), c, actorIRI)
+}
+
+// FollowerInboxes mocks base method
+func (m *MockSharedInboxLookup) FollowerInboxes(c context.Context, actorIRI *url.URL) (*url.URL, []*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowerInboxes", c, actorIRI)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].([]*url.URL)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FollowerInboxes indicates an expected call of FollowerInboxes
+func (mr *MockSharedInboxLookupMockRecorder) FollowerInboxes(c, actorIRI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowerInboxes", reflect.TypeOf((*MockSharedInboxLookup)(nil).FollowerInboxes), c, actorIRI)
+}
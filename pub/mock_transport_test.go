@@ -78,6 +78,89 @@ func (mr *MockTransportMockRecorder) BatchDeliver(c, b, recipients interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeliver", reflect.TypeOf((*MockTransport)(nil).BatchDeliver), c, b, recipients)
 }
 
+// MockConditionalTransport is a mock of ConditionalTransport interface
+type MockConditionalTransport struct {
+	ctrl     *gomock.Controller
+	recorder *MockConditionalTransportMockRecorder
+}
+
+// MockConditionalTransportMockRecorder is the mock recorder for MockConditionalTransport
+type MockConditionalTransportMockRecorder struct {
+	mock *MockConditionalTransport
+}
+
+// NewMockConditionalTransport creates a new mock instance
+func NewMockConditionalTransport(ctrl *gomock.Controller) *MockConditionalTransport {
+	mock := &MockConditionalTransport{ctrl: ctrl}
+	mock.recorder = &MockConditionalTransportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockConditionalTransport) EXPECT() *MockConditionalTransportMockRecorder {
+	return m.recorder
+}
+
+// Dereference mocks base method
+func (m *MockConditionalTransport) Dereference(c context.Context, iri *url.URL) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dereference", c, iri)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dereference indicates an expected call of Dereference
+func (mr *MockConditionalTransportMockRecorder) Dereference(c, iri interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dereference", reflect.TypeOf((*MockConditionalTransport)(nil).Dereference), c, iri)
+}
+
+// Deliver mocks base method
+func (m *MockConditionalTransport) Deliver(c context.Context, b []byte, to *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", c, b, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver
+func (mr *MockConditionalTransportMockRecorder) Deliver(c, b, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockConditionalTransport)(nil).Deliver), c, b, to)
+}
+
+// BatchDeliver mocks base method
+func (m *MockConditionalTransport) BatchDeliver(c context.Context, b []byte, recipients []*url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeliver", c, b, recipients)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchDeliver indicates an expected call of BatchDeliver
+func (mr *MockConditionalTransportMockRecorder) BatchDeliver(c, b, recipients interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeliver", reflect.TypeOf((*MockConditionalTransport)(nil).BatchDeliver), c, b, recipients)
+}
+
+// ConditionalDereference mocks base method
+func (m *MockConditionalTransport) ConditionalDereference(c context.Context, iri *url.URL, reqHeader http.Header) ([]byte, http.Header, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConditionalDereference", c, iri, reqHeader)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(http.Header)
+	ret2, _ := ret[2].(bool)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ConditionalDereference indicates an expected call of ConditionalDereference
+func (mr *MockConditionalTransportMockRecorder) ConditionalDereference(c, iri, reqHeader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConditionalDereference", reflect.TypeOf((*MockConditionalTransport)(nil).ConditionalDereference), c, iri, reqHeader)
+}
+
 // MockHttpClient is a mock of HttpClient interface
 type MockHttpClient struct {
 	ctrl     *gomock.Controller
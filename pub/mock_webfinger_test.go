@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webfinger.go
+
+// Package pub is a generated GoMock package.
+package pub
+
+import (
+	context "context"
+	gomock "github.com/golang/mock/gomock"
+	url "net/url"
+	reflect "reflect"
+)
+
+// MockWebFingerLookup is a mock of WebFingerLookup interface
+type MockWebFingerLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebFingerLookupMockRecorder
+}
+
+// MockWebFingerLookupMockRecorder is the mock recorder for MockWebFingerLookup
+type MockWebFingerLookupMockRecorder struct {
+	mock *MockWebFingerLookup
+}
+
+// NewMockWebFingerLookup creates a new mock instance
+func NewMockWebFingerLookup(ctrl *gomock.Controller) *MockWebFingerLookup {
+	mock := &MockWebFingerLookup{ctrl: ctrl}
+	mock.recorder = &MockWebFingerLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockWebFingerLookup) EXPECT() *MockWebFingerLookupMockRecorder {
+	return m.recorder
+}
+
+// ActorIRI mocks base method
+func (m *MockWebFingerLookup) ActorIRI(c context.Context, username, host string) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActorIRI", c, username, host)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActorIRI indicates an expected call of ActorIRI
+func (mr *MockWebFingerLookupMockRecorder) ActorIRI(c, username, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActorIRI", reflect.TypeOf((*MockWebFingerLookup)(nil).ActorIRI), c, username, host)
+}
@@ -0,0 +1,124 @@
+package pub
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// Report is a Flag received from a federating peer, awaiting review by a
+// moderator.
+type Report struct {
+	// Id is the id of the Flag.
+	Id *url.URL
+	// InboxIRI is the inbox in which the Flag was received.
+	InboxIRI *url.URL
+	// Flag is the Flag as it was received.
+	Flag vocab.ActivityStreamsFlag
+	// Local are the reported objects owned by this server.
+	Local []*url.URL
+	// Remote are the reported objects owned by other servers, which the
+	// report may be forwarded to.
+	Remote []*url.URL
+}
+
+// ModerationQueue holds the reports received from federating peers until they
+// are resolved by a moderator.
+//
+// It is obtained from the ModerationQueueProvider's ModerationQueue method,
+// and a Report is added to it when a Flag of an object owned by this server is
+// received. Reports may be forwarded to the actors owning their remote objects
+// with the FederatingActor's ForwardReport method.
+//
+// It must be safe to use concurrently.
+type ModerationQueue interface {
+	// AddReport adds the report to the queue. Adding a report with the
+	// same id as one already in the queue replaces it.
+	AddReport(c context.Context, r Report) error
+	// Reports lists the unresolved reports, in the order they were added.
+	Reports(c context.Context) ([]Report, error)
+	// Report obtains the unresolved report with the given id.
+	//
+	// If there is no such report, then a nil Report and nil error must be
+	// returned.
+	Report(c context.Context, id *url.URL) (*Report, error)
+	// ResolveReport removes the report with the given id from the queue.
+	ResolveReport(c context.Context, id *url.URL) error
+}
+
+// ModerationQueue must be implemented by MemoryModerationQueue.
+var _ ModerationQueue = &MemoryModerationQueue{}
+
+// MemoryModerationQueue is a ModerationQueue that keeps reports in memory.
+//
+// Reports are lost when the process exits, so it is best suited for tests and
+// applications that persist reports elsewhere as well.
+//
+// It is safe to use concurrently.
+type MemoryModerationQueue struct {
+	mu      *sync.RWMutex
+	reports []Report
+}
+
+// NewMemoryModerationQueue returns a new, empty MemoryModerationQueue.
+func NewMemoryModerationQueue() *MemoryModerationQueue {
+	return &MemoryModerationQueue{
+		mu: &sync.RWMutex{},
+	}
+}
+
+// AddReport adds the report to the queue, replacing any with the same id.
+func (m *MemoryModerationQueue) AddReport(c context.Context, r Report) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i := m.indexOf(r.Id); i >= 0 {
+		m.reports[i] = r
+		return nil
+	}
+	m.reports = append(m.reports, r)
+	return nil
+}
+
+// Reports lists the unresolved reports, in the order they were added.
+func (m *MemoryModerationQueue) Reports(c context.Context) ([]Report, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	reports := make([]Report, len(m.reports))
+	copy(reports, m.reports)
+	return reports, nil
+}
+
+// Report obtains the unresolved report with the given id, or nil if there is
+// none.
+func (m *MemoryModerationQueue) Report(c context.Context, id *url.URL) (*Report, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if i := m.indexOf(id); i >= 0 {
+		r := m.reports[i]
+		return &r, nil
+	}
+	return nil, nil
+}
+
+// ResolveReport removes the report with the given id from the queue.
+func (m *MemoryModerationQueue) ResolveReport(c context.Context, id *url.URL) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i := m.indexOf(id); i >= 0 {
+		m.reports = append(m.reports[:i], m.reports[i+1:]...)
+	}
+	return nil
+}
+
+// indexOf returns the index of the report with the given id, or -1 if there is
+// none. The lock must be held.
+func (m *MemoryModerationQueue) indexOf(id *url.URL) int {
+	for i, r := range m.reports {
+		if r.Id.String() == id.String() {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,90 @@
+package pub
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// The Multibase prefix of base58btc encoded data.
+	multibaseBase58Btc = 'z'
+	// The base58btc alphabet.
+	base58BtcAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// The Multicodec prefix of an Ed25519 public key, 0xed as an unsigned varint.
+var multicodecEd25519Pub = []byte{0xed, 0x01}
+
+// Ed25519PublicKeyMultibase encodes an Ed25519 public key in the Multikey
+// format, for use as the 'publicKeyMultibase' of a Multikey in an actor's
+// 'assertionMethod'.
+func Ed25519PublicKeyMultibase(k ed25519.PublicKey) string {
+	b := make([]byte, 0, len(multicodecEd25519Pub)+len(k))
+	b = append(b, multicodecEd25519Pub...)
+	b = append(b, k...)
+	return string(multibaseBase58Btc) + encodeBase58Btc(b)
+}
+
+// parsePublicKeyMultibase decodes a public key in the Multikey format. Only
+// base58btc encoded Ed25519 keys are supported.
+func parsePublicKeyMultibase(s string) (crypto.PublicKey, error) {
+	if len(s) == 0 || s[0] != multibaseBase58Btc {
+		return nil, fmt.Errorf("publicKeyMultibase is not base58btc encoded")
+	}
+	b, err := decodeBase58Btc(s[1:])
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != len(multicodecEd25519Pub)+ed25519.PublicKeySize ||
+		b[0] != multicodecEd25519Pub[0] ||
+		b[1] != multicodecEd25519Pub[1] {
+		return nil, fmt.Errorf("publicKeyMultibase is not an Ed25519 public key")
+	}
+	return ed25519.PublicKey(b[len(multicodecEd25519Pub):]), nil
+}
+
+// encodeBase58Btc encodes the bytes with the base58btc alphabet, preserving
+// leading zero bytes as leading '1's.
+func encodeBase58Btc(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(int64(len(base58BtcAlphabet)))
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58BtcAlphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58BtcAlphabet[0])
+	}
+	// Reverse into most significant digit first.
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// decodeBase58Btc decodes a string in the base58btc alphabet.
+func decodeBase58Btc(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(int64(len(base58BtcAlphabet)))
+	for _, c := range s {
+		d := strings.IndexRune(base58BtcAlphabet, c)
+		if d < 0 {
+			return nil, fmt.Errorf("invalid base58btc character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58BtcAlphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
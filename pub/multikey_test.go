@@ -0,0 +1,42 @@
+package pub
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+)
+
+// TestEd25519PublicKeyMultibase tests encoding and decoding Ed25519 public keys
+// in the Multikey format.
+func TestEd25519PublicKeyMultibase(t *testing.T) {
+	t.Run("RoundTrips", func(t *testing.T) {
+		// Setup
+		k := mustGenerateEd25519Key().Public().(ed25519.PublicKey)
+		// Run
+		s := Ed25519PublicKeyMultibase(k)
+		pk, err := parsePublicKeyMultibase(s)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, strings.HasPrefix(s, "z6Mk"), true)
+		assertByteEqual(t, pk.(ed25519.PublicKey), k)
+	})
+	t.Run("PreservesLeadingZeroBytes", func(t *testing.T) {
+		// Setup
+		b := []byte{0, 0, 1, 2, 3}
+		// Run
+		s := encodeBase58Btc(b)
+		out, err := decodeBase58Btc(s)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, s, "11Ldp")
+		assertByteEqual(t, out, b)
+	})
+	t.Run("RejectsOtherKeyTypes", func(t *testing.T) {
+		// Setup
+		s := "z" + encodeBase58Btc(append([]byte{0x12, 0x00}, make([]byte, 32)...))
+		// Run
+		_, err := parsePublicKeyMultibase(s)
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+}
@@ -0,0 +1,220 @@
+package pub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// NodeInfoDiscoveryPath is the path at which the NodeInfo discovery
+	// document is served.
+	NodeInfoDiscoveryPath = "/.well-known/nodeinfo"
+	// NodeInfo20Path is the path at which the NodeInfo 2.0 document is
+	// served.
+	NodeInfo20Path = "/nodeinfo/2.0"
+	// NodeInfo21Path is the path at which the NodeInfo 2.1 document is
+	// served.
+	NodeInfo21Path = "/nodeinfo/2.1"
+	// The schemas of the NodeInfo documents, used as link relations in the
+	// discovery document.
+	nodeInfo20Schema = "http://nodeinfo.diaspora.software/ns/schema/2.0"
+	nodeInfo21Schema = "http://nodeinfo.diaspora.software/ns/schema/2.1"
+	// The name of the software used when the NodeInfoProvider does not
+	// provide one.
+	defaultNodeInfoSoftwareName = "go-fed"
+	// The protocol used when the NodeInfoProvider does not provide any.
+	activityPubProtocol = "activitypub"
+)
+
+// NodeInfoProvider supplies the statistics and software details served in
+// NodeInfo documents.
+type NodeInfoProvider interface {
+	// Software returns the name and version of the software running this
+	// server.
+	//
+	// An empty name or version defaults to those of this library. Only
+	// lowercase letters, digits, and hyphens are allowed in the name.
+	Software(c context.Context) (NodeInfoSoftware, error)
+	// Protocols returns the protocols supported by this server.
+	//
+	// When none are returned, only ActivityPub is listed.
+	Protocols(c context.Context) ([]string, error)
+	// Users returns the counts of users on this server.
+	Users(c context.Context) (NodeInfoUsers, error)
+	// LocalPosts returns the number of posts made by users on this server.
+	LocalPosts(c context.Context) (int, error)
+	// OpenRegistrations returns whether this server allows new users to
+	// sign up.
+	OpenRegistrations(c context.Context) (bool, error)
+}
+
+// NodeInfoSoftware describes the software running a server.
+type NodeInfoSoftware struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Repository and Homepage are only served in NodeInfo 2.1.
+	Repository string `json:"repository,omitempty"`
+	Homepage   string `json:"homepage,omitempty"`
+}
+
+// NodeInfoUsers counts the users of a server.
+type NodeInfoUsers struct {
+	Total          int `json:"total"`
+	ActiveHalfyear int `json:"activeHalfyear"`
+	ActiveMonth    int `json:"activeMonth"`
+}
+
+// NodeInfoUsage contains the usage statistics of a server.
+type NodeInfoUsage struct {
+	Users      NodeInfoUsers `json:"users"`
+	LocalPosts int           `json:"localPosts"`
+}
+
+// NodeInfoServices lists the third party services a server can interact with.
+type NodeInfoServices struct {
+	Inbound  []string `json:"inbound"`
+	Outbound []string `json:"outbound"`
+}
+
+// NodeInfo is a NodeInfo 2.0 or 2.1 document.
+type NodeInfo struct {
+	Version           string                 `json:"version"`
+	Software          NodeInfoSoftware       `json:"software"`
+	Protocols         []string               `json:"protocols"`
+	Services          NodeInfoServices       `json:"services"`
+	OpenRegistrations bool                   `json:"openRegistrations"`
+	Usage             NodeInfoUsage          `json:"usage"`
+	Metadata          map[string]interface{} `json:"metadata"`
+}
+
+// nodeInfoLink is a link in the NodeInfo discovery document.
+type nodeInfoLink struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+}
+
+// nodeInfoDiscovery is the NodeInfo discovery document.
+type nodeInfoDiscovery struct {
+	Links []nodeInfoLink `json:"links"`
+}
+
+// NewNodeInfoHandler creates a HandlerFunc to serve the NodeInfo discovery
+// document at NodeInfoDiscoveryPath, and the NodeInfo 2.0 and 2.1 documents at
+// NodeInfo20Path and NodeInfo21Path.
+//
+// The discovery document links to the NodeInfo documents on the host of the
+// request, and only supports the HTTPS scheme.
+//
+// Requests to other paths are not handled, so 'isASRequest' is false and
+// nothing is written to the ResponseWriter.
+func NewNodeInfoHandler(p NodeInfoProvider) HandlerFunc {
+	return func(c context.Context, w http.ResponseWriter, r *http.Request) (isASRequest bool, err error) {
+		if r.Method != "GET" {
+			return
+		}
+		var v interface{}
+		var contentType string
+		switch r.URL.Path {
+		case NodeInfoDiscoveryPath:
+			v = toNodeInfoDiscovery(r.Host)
+			contentType = "application/json"
+		case NodeInfo20Path:
+			v, err = toNodeInfo(c, p, "2.0")
+			contentType = fmt.Sprintf("application/json; profile=\"%s#\"", nodeInfo20Schema)
+		case NodeInfo21Path:
+			v, err = toNodeInfo(c, p, "2.1")
+			contentType = fmt.Sprintf("application/json; profile=\"%s#\"", nodeInfo21Schema)
+		default:
+			return
+		}
+		isASRequest = true
+		if err != nil {
+			return
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		w.Header().Set(contentTypeHeader, contentType)
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.WriteHeader(http.StatusOK)
+		n, err := w.Write(raw)
+		if err != nil {
+			return
+		} else if n != len(raw) {
+			err = fmt.Errorf("only wrote %d of %d bytes", n, len(raw))
+			return
+		}
+		return
+	}
+}
+
+// toNodeInfoDiscovery creates the discovery document linking to the NodeInfo
+// documents on the host.
+func toNodeInfoDiscovery(host string) nodeInfoDiscovery {
+	href := func(path string) string {
+		u := &url.URL{Scheme: "https", Host: host, Path: path}
+		return u.String()
+	}
+	return nodeInfoDiscovery{
+		Links: []nodeInfoLink{
+			{Rel: nodeInfo20Schema, Href: href(NodeInfo20Path)},
+			{Rel: nodeInfo21Schema, Href: href(NodeInfo21Path)},
+		},
+	}
+}
+
+// toNodeInfo creates the NodeInfo document of the given version with the
+// statistics from the NodeInfoProvider.
+func toNodeInfo(c context.Context, p NodeInfoProvider, version string) (ni NodeInfo, err error) {
+	ni.Version = version
+	ni.Software, err = p.Software(c)
+	if err != nil {
+		return
+	}
+	if len(ni.Software.Name) == 0 {
+		ni.Software.Name = defaultNodeInfoSoftwareName
+	}
+	if len(ni.Software.Version) == 0 {
+		ni.Software.Version = defaultNodeInfoSoftwareVersion()
+	}
+	if version == "2.0" {
+		ni.Software.Repository = ""
+		ni.Software.Homepage = ""
+	}
+	ni.Protocols, err = p.Protocols(c)
+	if err != nil {
+		return
+	}
+	if len(ni.Protocols) == 0 {
+		ni.Protocols = []string{activityPubProtocol}
+	}
+	ni.Usage.Users, err = p.Users(c)
+	if err != nil {
+		return
+	}
+	ni.Usage.LocalPosts, err = p.LocalPosts(c)
+	if err != nil {
+		return
+	}
+	ni.OpenRegistrations, err = p.OpenRegistrations(c)
+	if err != nil {
+		return
+	}
+	ni.Services = NodeInfoServices{
+		Inbound:  []string{},
+		Outbound: []string{},
+	}
+	ni.Metadata = map[string]interface{}{}
+	return
+}
+
+// defaultNodeInfoSoftwareVersion returns the version of this library, as
+// used in its User-Agent, without the leading 'v'.
+func defaultNodeInfoSoftwareVersion() string {
+	return strings.TrimPrefix(version, "v")
+}
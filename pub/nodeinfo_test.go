@@ -0,0 +1,113 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestNodeInfoHandler tests serving the NodeInfo discovery and statistics
+// documents.
+func TestNodeInfoHandler(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (p *MockNodeInfoProvider, h HandlerFunc) {
+		p = NewMockNodeInfoProvider(ctl)
+		h = NewNodeInfoHandler(p)
+		return
+	}
+	expectStats := func(p *MockNodeInfoProvider, software NodeInfoSoftware) {
+		p.EXPECT().Software(ctx).Return(software, nil)
+		p.EXPECT().Protocols(ctx).Return(nil, nil)
+		p.EXPECT().Users(ctx).Return(NodeInfoUsers{Total: 3, ActiveHalfyear: 2, ActiveMonth: 1}, nil)
+		p.EXPECT().LocalPosts(ctx).Return(42, nil)
+		p.EXPECT().OpenRegistrations(ctx).Return(true, nil)
+	}
+	t.Run("IgnoresOtherPaths", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", testMyInboxIRI, nil)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, false)
+	})
+	t.Run("ServesDiscovery", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "https://example.com"+NodeInfoDiscoveryPath, nil)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(`{"links":[{"rel":"http://nodeinfo.diaspora.software/ns/schema/2.0","href":"https://example.com/nodeinfo/2.0"},{"rel":"http://nodeinfo.diaspora.software/ns/schema/2.1","href":"https://example.com/nodeinfo/2.1"}]}`))
+	})
+	t.Run("ServesNodeInfo21WithDefaults", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		p, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "https://example.com"+NodeInfo21Path, nil)
+		// Mock
+		expectStats(p, NodeInfoSoftware{})
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+		assertEqual(t, resp.Header().Get(contentTypeHeader), `application/json; profile="http://nodeinfo.diaspora.software/ns/schema/2.1#"`)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(fmt.Sprintf(`{"version":"2.1","software":{"name":"go-fed","version":"%s"},"protocols":["activitypub"],"services":{"inbound":[],"outbound":[]},"openRegistrations":true,"usage":{"users":{"total":3,"activeHalfyear":2,"activeMonth":1},"localPosts":42},"metadata":{}}`, defaultNodeInfoSoftwareVersion())))
+	})
+	t.Run("ServesNodeInfo20WithoutSoftwareLinks", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		p, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "https://example.com"+NodeInfo20Path, nil)
+		// Mock
+		expectStats(p, NodeInfoSoftware{Name: "myapp", Version: "2.3.4", Homepage: "https://example.com"})
+		// Run
+		_, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(`{"version":"2.0","software":{"name":"myapp","version":"2.3.4"},"protocols":["activitypub"],"services":{"inbound":[],"outbound":[]},"openRegistrations":true,"usage":{"users":{"total":3,"activeHalfyear":2,"activeMonth":1},"localPosts":42},"metadata":{}}`))
+	})
+	t.Run("ReturnsProviderError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		p, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "https://example.com"+NodeInfo21Path, nil)
+		testErr := fmt.Errorf("test error")
+		// Mock
+		p.EXPECT().Software(ctx).Return(NodeInfoSoftware{}, testErr)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
+}
@@ -42,6 +42,16 @@ type orderedItemser interface {
 	SetActivityStreamsOrderedItems(vocab.ActivityStreamsOrderedItemsProperty)
 }
 
+// firster is an ActivityStreams type with a 'first' property
+type firster interface {
+	GetActivityStreamsFirst() vocab.ActivityStreamsFirstProperty
+}
+
+// nexter is an ActivityStreams type with a 'next' property
+type nexter interface {
+	GetActivityStreamsNext() vocab.ActivityStreamsNextProperty
+}
+
 // publisheder is an ActivityStreams type with a 'published' property
 type publisheder interface {
 	GetActivityStreamsPublished() vocab.ActivityStreamsPublishedProperty
@@ -87,6 +97,16 @@ type inboxer interface {
 	GetActivityStreamsInbox() vocab.ActivityStreamsInboxProperty
 }
 
+// outboxer is an ActivityStreams type with an 'outbox' property
+type outboxer interface {
+	GetActivityStreamsOutbox() vocab.ActivityStreamsOutboxProperty
+}
+
+// followerser is an ActivityStreams type with a 'followers' property
+type followerser interface {
+	GetActivityStreamsFollowers() vocab.ActivityStreamsFollowersProperty
+}
+
 // attributedToer is an ActivityStreams type with an 'attributedTo' property
 type attributedToer interface {
 	GetActivityStreamsAttributedTo() vocab.ActivityStreamsAttributedToProperty
@@ -111,7 +131,47 @@ type actorer interface {
 	SetActivityStreamsActor(i vocab.ActivityStreamsActorProperty)
 }
 
+// namer is an ActivityStreams type with a 'name' property
+type namer interface {
+	GetActivityStreamsName() vocab.ActivityStreamsNameProperty
+}
+
+// replieser is an ActivityStreams type with a 'replies' property
+type replieser interface {
+	GetActivityStreamsReplies() vocab.ActivityStreamsRepliesProperty
+	SetActivityStreamsReplies(i vocab.ActivityStreamsRepliesProperty)
+}
+
+// totalItemser is an ActivityStreams type with a 'totalItems' property
+type totalItemser interface {
+	GetActivityStreamsTotalItems() vocab.ActivityStreamsTotalItemsProperty
+	SetActivityStreamsTotalItems(i vocab.ActivityStreamsTotalItemsProperty)
+}
+
+// alsoKnownAser is an ActivityStreams type with an 'alsoKnownAs' property
+type alsoKnownAser interface {
+	GetActivityStreamsAlsoKnownAs() vocab.ActivityStreamsAlsoKnownAsProperty
+}
+
 // appendIRIer is an ActivityStreams type that can Append IRIs.
 type appendIRIer interface {
 	AppendIRI(v *url.URL)
 }
+
+// publicKeyer is an ActivityStreams type with a 'publicKey' property
+type publicKeyer interface {
+	GetW3IDSecurityV1PublicKey() vocab.W3IDSecurityV1PublicKeyProperty
+	SetW3IDSecurityV1PublicKey(vocab.W3IDSecurityV1PublicKeyProperty)
+}
+
+// assertionMethoder is an ActivityStreams type with an 'assertionMethod'
+// property
+type assertionMethoder interface {
+	GetW3IDSecurityDataIntegrityV1AssertionMethod() vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty
+}
+
+// unknownPropertieser is an ActivityStreams type with properties that are not
+// part of any known vocabulary, such as 'endpoints'.
+type unknownPropertieser interface {
+	GetUnknownProperties() map[string]interface{}
+}
@@ -26,6 +26,9 @@ const (
 	testFederatedActorIRI4    = "https://other.example.com/jessie"
 	testFederatedInboxIRI     = "https://other.example.com/dakota/inbox"
 	testFederatedInboxIRI2    = "https://other.example.com/addison/inbox"
+	testFederatedSharedInbox  = "https://other.example.com/inbox"
+	testKnownSharedInbox      = "https://known.example.com/inbox"
+	testMyInboxPageIRI        = "https://example.com/addison/inbox?page=true"
 	testNoteId1               = "https://example.com/note/1"
 	testNoteId2               = "https://example.com/note/2"
 	testNewActivityIRI        = "https://example.com/new/1"
@@ -98,12 +101,13 @@ var (
 	// ids.
 	testOrderedCollectionUniqueElems vocab.ActivityStreamsOrderedCollectionPage
 	// testOrderedCollectionUniqueElemsString is the JSON-LD version of the
-	// testOrderedCollectionUniqueElems value
+	// testOrderedCollectionUniqueElems value as the first page of the inbox
 	testOrderedCollectionUniqueElemsString string
 	// testOrderedCollectionDupedElems is a collection with duplicated ids.
 	testOrderedCollectionDupedElems vocab.ActivityStreamsOrderedCollectionPage
 	// testOrderedCollectionDedupedElemsString is the JSON-LD version of the
-	// testOrderedCollectionDedupedElems value with duplicates removed
+	// testOrderedCollectionDedupedElems value with duplicates removed as the
+	// first page of the inbox
 	testOrderedCollectionDedupedElemsString string
 	// testEmptyOrderedCollection is an empty OrderedCollectionPage.
 	testEmptyOrderedCollection vocab.ActivityStreamsOrderedCollectionPage
@@ -256,7 +260,7 @@ func setupData() {
 		oi.AppendIRI(mustParse(testNoteId1))
 		oi.AppendIRI(mustParse(testNoteId2))
 		testOrderedCollectionUniqueElems.SetActivityStreamsOrderedItems(oi)
-		testOrderedCollectionUniqueElemsString = `{"@context":"https://www.w3.org/ns/activitystreams","orderedItems":["https://example.com/note/1","https://example.com/note/2"],"type":"OrderedCollectionPage"}`
+		testOrderedCollectionUniqueElemsString = `{"@context":"https://www.w3.org/ns/activitystreams","id":"https://example.com/addison/inbox?page=true","orderedItems":["https://example.com/note/1","https://example.com/note/2"],"partOf":"https://example.com/addison/inbox","type":"OrderedCollectionPage"}`
 	}()
 	// testOrderedCollectionDupedElems and
 	// testOrderedCollectionDedupedElemsString
@@ -266,7 +270,7 @@ func setupData() {
 		oi.AppendIRI(mustParse(testNoteId1))
 		oi.AppendIRI(mustParse(testNoteId1))
 		testOrderedCollectionDupedElems.SetActivityStreamsOrderedItems(oi)
-		testOrderedCollectionDedupedElemsString = `{"@context":"https://www.w3.org/ns/activitystreams","orderedItems":"https://example.com/note/1","type":"OrderedCollectionPage"}`
+		testOrderedCollectionDedupedElemsString = `{"@context":"https://www.w3.org/ns/activitystreams","id":"https://example.com/addison/inbox?page=true","orderedItems":"https://example.com/note/1","partOf":"https://example.com/addison/inbox","type":"OrderedCollectionPage"}`
 	}()
 	// testEmptyOrderedCollection
 	func() {
@@ -479,6 +483,20 @@ func mustSerializeToBytes(t vocab.Type) []byte {
 	return b
 }
 
+// mustSerializeWithSharedInbox serializes a type with a 'sharedInbox' in its
+// 'endpoints' to bytes or panics.
+func mustSerializeWithSharedInbox(t vocab.Type, sharedInbox string) []byte {
+	m := mustSerialize(t)
+	m["endpoints"] = map[string]interface{}{
+		"sharedInbox": sharedInbox,
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
 // mustSerialize serializes a type or panics.
 func mustSerialize(t vocab.Type) map[string]interface{} {
 	m, err := streams.Serialize(t)
@@ -520,6 +538,16 @@ func now() time.Time {
 	return time.Date(2000, 2, 3, 4, 5, 6, 7, l)
 }
 
+// fixedClock is a Clock that always returns the same time.
+type fixedClock struct {
+	t time.Time
+}
+
+// Now returns the fixed time.
+func (f *fixedClock) Now() time.Time {
+	return f.t
+}
+
 // nowDateHeader returns the "current" time formatted in a form expected by the
 // Date header in HTTP responses.
 func nowDateHeader() string {
@@ -554,6 +582,12 @@ func toAPRequest(r *http.Request) *http.Request {
 // toPostInboxRequest creates a new POST HTTP request with the given type as
 // the payload.
 func toPostInboxRequest(t vocab.Type) *http.Request {
+	buf := bytes.NewBuffer(toPostInboxBody(t))
+	return httptest.NewRequest("POST", testMyInboxIRI, buf)
+}
+
+// toPostInboxBody serializes a type to the body sent by toPostInboxRequest.
+func toPostInboxBody(t vocab.Type) []byte {
 	m, err := streams.Serialize(t)
 	if err != nil {
 		panic(err)
@@ -562,8 +596,7 @@ func toPostInboxRequest(t vocab.Type) *http.Request {
 	if err != nil {
 		panic(err)
 	}
-	buf := bytes.NewBuffer(b)
-	return httptest.NewRequest("POST", testMyInboxIRI, buf)
+	return b
 }
 
 // toPostOutboxRequest creates a new POST HTTP request with the given type as
@@ -612,6 +645,12 @@ func toGetInboxRequest() *http.Request {
 	return httptest.NewRequest("GET", testMyInboxIRI, nil)
 }
 
+// toGetInboxPageRequest creates a new GET HTTP request for the first page of
+// the inbox.
+func toGetInboxPageRequest() *http.Request {
+	return httptest.NewRequest("GET", testMyInboxPageIRI, nil)
+}
+
 // toGetOutboxRequest creates a new GET HTTP request.
 func toGetOutboxRequest() *http.Request {
 	return httptest.NewRequest("GET", testMyOutboxIRI, nil)
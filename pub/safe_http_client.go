@@ -0,0 +1,204 @@
+package pub
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// HttpClient must be implemented by SafeHttpClient.
+var _ HttpClient = &SafeHttpClient{}
+
+// privateNetworks are the address ranges that a SafeHttpClient refuses to
+// connect to unless allow-listed: the unspecified, loopback, private,
+// shared, link-local, benchmarking, documentation, multicast and reserved
+// ranges of IPv4 and IPv6, and the IPv6 ranges translating to IPv4 addresses.
+var privateNetworks []*net.IPNet
+
+func init() {
+	for _, cidr := range []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+		"172.16.0.0/12",
+		"192.0.0.0/24",
+		"192.0.2.0/24",
+		"192.168.0.0/16",
+		"198.18.0.0/15",
+		"198.51.100.0/24",
+		"203.0.113.0/24",
+		"224.0.0.0/4",
+		"240.0.0.0/4",
+		"::/128",
+		"::1/128",
+		"64:ff9b::/96",
+		"64:ff9b:1::/48",
+		"2001::/32",
+		"2001:db8::/32",
+		"2002::/16",
+		"fc00::/7",
+		"fe80::/10",
+		"ff00::/8",
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		privateNetworks = append(privateNetworks, n)
+	}
+}
+
+// SafeHttpClient is a HttpClient for the HttpSigTransport that is hardened
+// against the IRIs that peers place in their activities.
+//
+// It only connects to public addresses, checking the address actually dialed
+// after hostname resolution so that DNS records cannot point it at this
+// server's network. Private, loopback and link-local ranges are refused unless
+// they are explicitly allowed. Environment proxies are not used, since they
+// would bypass this check.
+//
+// It also bounds the duration of each request, the number of redirects
+// followed, and the size of response bodies, and rejects successful GET
+// responses that are not ActivityStreams, WebFinger or other JSON content.
+//
+// It is safe to use concurrently.
+type SafeHttpClient struct {
+	client           *http.Client
+	maxResponseBytes int64
+}
+
+// NewSafeHttpClient returns a new SafeHttpClient.
+//
+// The allowed networks are private networks that may nonetheless be
+// connected to, such as a local peer used in development, and may be nil.
+//
+// The timeout bounds each request, including reading its response body. At
+// most maxRedirects redirects are followed; zero disables redirects. Response
+// bodies larger than maxResponseBytes fail with an error when read.
+func NewSafeHttpClient(allowed []*net.IPNet, timeout time.Duration, maxRedirects int, maxResponseBytes int64) *SafeHttpClient {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkDialAddress(allowed, address)
+		},
+	}
+	return &SafeHttpClient{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext:           dialer.DialContext,
+				TLSHandshakeTimeout:   timeout,
+				ResponseHeaderTimeout: timeout,
+				MaxIdleConns:          100,
+				IdleConnTimeout:       90 * time.Second,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				return nil
+			},
+			Timeout: timeout,
+		},
+		maxResponseBytes: maxResponseBytes,
+	}
+}
+
+// Do sends the request, returning an error instead of a response that is too
+// large or, for a successful GET request, is not ActivityStreams, WebFinger or
+// other JSON content.
+func (s *SafeHttpClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength > s.maxResponseBytes {
+		resp.Body.Close()
+		return nil, fmt.Errorf("response from %s is too large: %d bytes", req.URL, resp.ContentLength)
+	}
+	if req.Method == http.MethodGet &&
+		resp.StatusCode == http.StatusOK &&
+		!isFetchableMediaType(resp.Header.Get(contentTypeHeader)) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("response from %s is not ActivityStreams or JSON content: %q", req.URL, resp.Header.Get(contentTypeHeader))
+	}
+	resp.Body = &limitedReadCloser{
+		rc:        resp.Body,
+		remaining: s.maxResponseBytes,
+	}
+	return resp, nil
+}
+
+// isFetchableMediaType determines whether the Content-Type header of a GET
+// response is one a SafeHttpClient returns: an ActivityStreams media type, or
+// the JSON and JRD media types of WebFinger and NodeInfo documents.
+func isFetchableMediaType(header string) bool {
+	if headerIsActivityPubMediaType(header) {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return false
+	}
+	return mediaType == jrdContentType || mediaType == "application/json"
+}
+
+// checkDialAddress returns an error if the dialed host and port is in a
+// private network that is not allowed.
+func checkDialAddress(allowed []*net.IPNet, address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("cannot dial non-IP address %q", host)
+	}
+	for _, n := range allowed {
+		if n.Contains(ip) {
+			return nil
+		}
+	}
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return fmt.Errorf("refusing to dial private address %s", ip)
+		}
+	}
+	return nil
+}
+
+// limitedReadCloser reads from a ReadCloser until the remaining number of bytes
+// is exhausted, after which it returns an error rather than truncating the
+// content.
+type limitedReadCloser struct {
+	rc        io.ReadCloser
+	remaining int64
+}
+
+// Read reads from the underlying ReadCloser, failing if more bytes remain once
+// the limit is reached.
+func (l *limitedReadCloser) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, fmt.Errorf("response body exceeds the size limit")
+	}
+	// Read one byte past the limit to detect content that exceeds it.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.rc.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), fmt.Errorf("response body exceeds the size limit")
+	}
+	return n, err
+}
+
+// Close closes the underlying ReadCloser.
+func (l *limitedReadCloser) Close() error {
+	return l.rc.Close()
+}
@@ -0,0 +1,140 @@
+package pub
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSafeHttpClient tests the protections of the SafeHttpClient against a
+// local server.
+func TestSafeHttpClient(t *testing.T) {
+	_, loopback, err := net.ParseCIDR("127.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := []*net.IPNet{loopback}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/actor", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentTypeHeader, "application/activity+json; charset=utf-8")
+		w.Write(testRespBody)
+	})
+	mux.HandleFunc("/html", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentTypeHeader, "text/html")
+		w.Write(testRespBody)
+	})
+	mux.HandleFunc("/webfinger", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentTypeHeader, jrdContentType)
+		w.Write(testRespBody)
+	})
+	mux.HandleFunc("/nodeinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentTypeHeader, "application/json; profile=\"http://nodeinfo.diaspora.software/ns/schema/2.1#\"")
+		w.Write(testRespBody)
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentTypeHeader, contentTypeHeaderValue)
+		w.Write([]byte(strings.Repeat("a", 100)))
+	})
+	mux.HandleFunc("/large-chunked", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(contentTypeHeader, contentTypeHeaderValue)
+		for i := 0; i < 10; i++ {
+			w.Write([]byte(strings.Repeat("a", 10)))
+			w.(http.Flusher).Flush()
+		}
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/actor", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	getFn := func(s *SafeHttpClient, path string) (*http.Response, error) {
+		req, err := http.NewRequest("GET", server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s.Do(req)
+	}
+	t.Run("RefusesPrivateAddress", func(t *testing.T) {
+		s := NewSafeHttpClient(nil, time.Second, 0, 50)
+		_, err := getFn(s, "/actor")
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("AllowsAllowedNetwork", func(t *testing.T) {
+		s := NewSafeHttpClient(allowed, time.Second, 0, 50)
+		resp, err := getFn(s, "/actor")
+		assertEqual(t, err, nil)
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, testRespBody)
+	})
+	t.Run("RejectsNonActivityStreamsContent", func(t *testing.T) {
+		s := NewSafeHttpClient(allowed, time.Second, 0, 50)
+		_, err := getFn(s, "/html")
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("AllowsWebFingerAndNodeInfoContent", func(t *testing.T) {
+		s := NewSafeHttpClient(allowed, time.Second, 0, 50)
+		resp, err := getFn(s, "/webfinger")
+		assertEqual(t, err, nil)
+		resp.Body.Close()
+		resp, err = getFn(s, "/nodeinfo")
+		assertEqual(t, err, nil)
+		resp.Body.Close()
+	})
+	t.Run("RejectsLargeContentLength", func(t *testing.T) {
+		s := NewSafeHttpClient(allowed, time.Second, 0, 50)
+		_, err := getFn(s, "/large")
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("FailsReadingLargeBody", func(t *testing.T) {
+		s := NewSafeHttpClient(allowed, time.Second, 0, 50)
+		resp, err := getFn(s, "/large-chunked")
+		assertEqual(t, err, nil)
+		defer resp.Body.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		assertNotEqual(t, err, nil)
+		assertEqual(t, len(b), 50)
+	})
+	t.Run("LimitsRedirects", func(t *testing.T) {
+		s := NewSafeHttpClient(allowed, time.Second, 0, 50)
+		_, err := getFn(s, "/redirect")
+		assertNotEqual(t, err, nil)
+		s = NewSafeHttpClient(allowed, time.Second, 1, 50)
+		resp, err := getFn(s, "/redirect")
+		assertEqual(t, err, nil)
+		resp.Body.Close()
+	})
+}
+
+// TestCheckDialAddress ensures the special purpose ranges are refused.
+func TestCheckDialAddress(t *testing.T) {
+	for _, address := range []string{
+		"10.0.0.1:443",
+		"192.0.2.1:443",
+		"198.51.100.1:443",
+		"203.0.113.1:443",
+		"[::1]:443",
+		"[64:ff9b::a00:1]:443",
+		"[64:ff9b:1::a00:1]:443",
+		"[2001:0:4136:e378:8000:63bf:f5ff:fffe]:443",
+		"[2001:db8::1]:443",
+		"[2002:a00:1::1]:443",
+	} {
+		if err := checkDialAddress(nil, address); err == nil {
+			t.Errorf("expected %s to be refused", address)
+		}
+	}
+	for _, address := range []string{
+		"93.184.216.34:443",
+		"[2606:2800:220:1::1]:443",
+	} {
+		if err := checkDialAddress(nil, address); err != nil {
+			t.Errorf("expected %s to be allowed, got %s", address, err)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package pub
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// SharedInboxLookup finds the local actors receiving an activity posted to a
+// shared inbox.
+type SharedInboxLookup interface {
+	// InboxForActor returns the inbox IRI of the local actor with the given
+	// IRI.
+	//
+	// If the IRI is not a local actor, such as when it belongs to another
+	// server, then a nil IRI and nil error must be returned.
+	InboxForActor(c context.Context, actorIRI *url.URL) (inboxIRI *url.URL, err error)
+	// FollowerInboxes returns the inbox IRIs of the local actors following
+	// the actor with the given IRI, along with the IRI of that actor's
+	// 'followers' collection.
+	//
+	// The local followers only receive an activity addressed to the
+	// 'followers' collection or to the Public collection. If the
+	// 'followers' IRI is nil, they only receive public activities.
+	FollowerInboxes(c context.Context, actorIRI *url.URL) (followersIRI *url.URL, inboxIRIs []*url.URL, err error)
+}
+
+// SharedInbox handles POST requests to the 'sharedInbox' endpoint of a server,
+// which peers use to deliver an activity once for all of its local recipients.
+//
+// The SharedInbox can be created once in an application and reused to handle
+// multiple requests concurrently.
+type SharedInbox struct {
+	delegate DelegateActor
+	lookup   SharedInboxLookup
+}
+
+// NewSharedInbox builds a SharedInbox that applies the same side effects to an
+// activity as a FederatingActor built with NewFederatingActor.
+//
+// The side effects specific to the actor owning an inbox, such as accepting a
+// Follow or counting a vote, are applied for each local recipient. The others,
+// such as storing the objects of a Create or updating the 'likes' of a local
+// object, are applied once for the activity. The application's callbacks are
+// called for each local recipient.
+//
+// Blocks are applied for each local recipient if the FederatingProtocol
+// implements BlockStoreProvider: the activity is not posted to the inbox of a
+// recipient that has blocked one of its actors.
+func NewSharedInbox(common CommonBehavior,
+	s2s FederatingProtocol,
+	db Database,
+	clock Clock,
+	lookup SharedInboxLookup) *SharedInbox {
+	return NewCustomSharedInbox(&sideEffectActor{
+		common: common,
+		s2s:    s2s,
+		db:     db,
+		clock:  clock,
+	}, lookup)
+}
+
+// NewCustomSharedInbox builds a SharedInbox that relies on the DelegateActor to
+// apply the side effects of an activity for each local recipient, as the
+// FederatingActor built with NewCustomActor does.
+func NewCustomSharedInbox(delegate DelegateActor, lookup SharedInboxLookup) *SharedInbox {
+	return &SharedInbox{
+		delegate: delegate,
+		lookup:   lookup,
+	}
+}
+
+// PostInbox returns true if the request was handled as an ActivityPub POST to
+// the shared inbox. If false, the request was not an ActivityPub request and
+// may still be handled by the caller in another way.
+//
+// The request is authenticated and authorized once. The local recipients are
+// the actors addressed in 'to', 'cc', 'bto' or 'audience', and the local
+// followers of the activity's actors when it is addressed to their 'followers'
+// or to the Public collection. Recipients are deduplicated, and an activity
+// already in a recipient's inbox is not posted to it again, so redeliveries of
+// the same activity id have no further side effects.
+//
+// If the error is nil, then the ResponseWriter's headers and response has
+// already been written. If a non-nil error is returned, then no response has
+// been written.
+//
+// The request and data of your application will be interpreted as having an
+// HTTPS protocol scheme.
+func (s *SharedInbox) PostInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	return s.PostInboxScheme(c, w, r, "https")
+}
+
+// PostInboxScheme is similar to PostInbox, except clients are able to specify
+// which protocol scheme to handle the incoming request and the data stored
+// within the application (HTTP, HTTPS, etc).
+func (s *SharedInbox) PostInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	// Do nothing if it is not an ActivityPub POST request.
+	if !isActivityPubPost(r) {
+		return false, nil
+	}
+	c, _, activity, raw, err := receiveInboxActivity(c, s.delegate, w, r, scheme)
+	if err != nil || activity == nil {
+		return true, err
+	}
+	inboxes, err := s.recipientInboxes(c, activity)
+	if err != nil {
+		return true, err
+	}
+	c = withSharedInboxDelivery(c)
+	for _, inboxIRI := range inboxes {
+		if rejected, err := postInboxActivity(c, s.delegate, w, inboxIRI, activity, raw); err == ErrBlocked {
+			// Only this recipient has blocked the peer.
+			continue
+		} else if err != nil || rejected {
+			return true, err
+		}
+	}
+	// Request has been processed. Begin responding to the request.
+	//
+	// Simply respond with an OK status to the peer.
+	w.WriteHeader(http.StatusOK)
+	return true, nil
+}
+
+// sharedInboxDelivery tracks an activity posted to a shared inbox while it is
+// posted to the inbox of each local recipient.
+type sharedInboxDelivery struct {
+	// applied is set once the side effects of the activity that do not
+	// depend on the recipient have been applied.
+	applied bool
+}
+
+// withSharedInboxDelivery returns a copy of the context tracking the delivery
+// of an activity posted to a shared inbox.
+func withSharedInboxDelivery(c context.Context) context.Context {
+	return context.WithValue(c, sharedInboxDeliveryContextKey, &sharedInboxDelivery{})
+}
+
+// sharedInboxDeliveryFrom returns the delivery of an activity posted to a
+// shared inbox, or nil if the context does not track one.
+func sharedInboxDeliveryFrom(c context.Context) *sharedInboxDelivery {
+	d, _ := c.Value(sharedInboxDeliveryContextKey).(*sharedInboxDelivery)
+	return d
+}
+
+// recipientInboxes determines the inboxes of the local recipients of the
+// activity, without duplicates.
+func (s *SharedInbox) recipientInboxes(c context.Context, activity Activity) ([]*url.URL, error) {
+	var inboxes []*url.URL
+	seen := make(map[string]bool)
+	addInbox := func(inboxIRI *url.URL) {
+		if !seen[inboxIRI.String()] {
+			seen[inboxIRI.String()] = true
+			inboxes = append(inboxes, inboxIRI)
+		}
+	}
+	// Find the actors addressed directly.
+	addressed, err := sharedInboxAddresses(activity)
+	if err != nil {
+		return nil, err
+	}
+	isPublic := false
+	for _, iri := range addressed {
+		if IsPublic(iri.String()) {
+			isPublic = true
+			continue
+		}
+		inboxIRI, err := s.lookup.InboxForActor(c, iri)
+		if err != nil {
+			return nil, err
+		} else if inboxIRI != nil {
+			addInbox(inboxIRI)
+		}
+	}
+	// Find the followers of the activity's actors, if addressed.
+	actors := activity.GetActivityStreamsActor()
+	if actors == nil {
+		return inboxes, nil
+	}
+	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+		actorIRI, err := ToId(iter)
+		if err != nil {
+			return nil, err
+		}
+		followersIRI, followerInboxes, err := s.lookup.FollowerInboxes(c, actorIRI)
+		if err != nil {
+			return nil, err
+		}
+		if !isPublic && !containsIRI(addressed, followersIRI) {
+			continue
+		}
+		for _, inboxIRI := range followerInboxes {
+			addInbox(inboxIRI)
+		}
+	}
+	return inboxes, nil
+}
+
+// sharedInboxAddresses returns the IRIs in the 'to', 'cc', 'bto' and
+// 'audience' of the activity.
+func sharedInboxAddresses(activity Activity) (iris []*url.URL, err error) {
+	if to := activity.GetActivityStreamsTo(); to != nil {
+		for iter := to.Begin(); iter != to.End(); iter = iter.Next() {
+			var val *url.URL
+			if val, err = ToId(iter); err != nil {
+				return
+			}
+			iris = append(iris, val)
+		}
+	}
+	if cc := activity.GetActivityStreamsCc(); cc != nil {
+		for iter := cc.Begin(); iter != cc.End(); iter = iter.Next() {
+			var val *url.URL
+			if val, err = ToId(iter); err != nil {
+				return
+			}
+			iris = append(iris, val)
+		}
+	}
+	if bto := activity.GetActivityStreamsBto(); bto != nil {
+		for iter := bto.Begin(); iter != bto.End(); iter = iter.Next() {
+			var val *url.URL
+			if val, err = ToId(iter); err != nil {
+				return
+			}
+			iris = append(iris, val)
+		}
+	}
+	if audience := activity.GetActivityStreamsAudience(); audience != nil {
+		for iter := audience.Begin(); iter != audience.End(); iter = iter.Next() {
+			var val *url.URL
+			if val, err = ToId(iter); err != nil {
+				return
+			}
+			iris = append(iris, val)
+		}
+	}
+	return
+}
+
+// containsIRI determines whether the IRI is in the slice. A nil IRI is never
+// contained.
+func containsIRI(iris []*url.URL, iri *url.URL) bool {
+	if iri == nil {
+		return false
+	}
+	for _, u := range iris {
+		if u.String() == iri.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,317 @@
+package pub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testSharedInboxIRI      = "https://example.com/inbox"
+	testMyInboxIRI2         = "https://example.com/sam/inbox"
+	testFederatedFollowers  = "https://other.example.com/dakota/followers"
+	testFederatedFollowing  = "https://other.example.com/dakota/following"
+	testFederatedSharedNote = "https://other.example.com/note/1"
+)
+
+// TestSharedInbox tests fanning out activities posted to the shared inbox.
+func TestSharedInbox(t *testing.T) {
+	setupData()
+	ctx := context.Background()
+	newCreateFn := func(to ...string) vocab.ActivityStreamsCreate {
+		c := streams.NewActivityStreamsCreate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		c.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		c.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedSharedNote))
+		c.SetActivityStreamsObject(op)
+		toProp := streams.NewActivityStreamsToProperty()
+		for _, iri := range to {
+			toProp.AppendIRI(mustParse(iri))
+		}
+		c.SetActivityStreamsTo(toProp)
+		return c
+	}
+	newRequestFn := func(a vocab.Type) *http.Request {
+		m, err := streams.Serialize(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return toAPRequest(httptest.NewRequest("POST", testSharedInboxIRI, bytes.NewBuffer(b)))
+	}
+	setupFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, lookup *MockSharedInboxLookup, s *SharedInbox) {
+		delegate = NewMockDelegateActor(ctl)
+		lookup = NewMockSharedInboxLookup(ctl)
+		s = NewCustomSharedInbox(delegate, lookup)
+		return
+	}
+	expectReceiveFn := func(delegate *MockDelegateActor, resp http.ResponseWriter, req *http.Request, a vocab.Type) {
+		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testSharedInboxIRI)).Return(nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(a)).Return(ctx, nil)
+		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(a)).Return(true, nil)
+	}
+	t.Run("IgnoresNonActivityPubRequest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, s := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", testSharedInboxIRI, nil)
+		// Run
+		handled, err := s.PostInbox(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, false)
+	})
+	t.Run("PostsOnceToEachLocalRecipient", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, lookup, s := setupFn(ctl)
+		a := newCreateFn(PublicActivityPubIRI, testMyActorIRI, testFederatedFollowers)
+		resp := httptest.NewRecorder()
+		req := newRequestFn(a)
+		// Mock
+		expectReceiveFn(delegate, resp, req, a)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testMyActorIRI)).Return(mustParse(testMyInboxIRI), nil)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testFederatedFollowers)).Return(nil, nil)
+		lookup.EXPECT().FollowerInboxes(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustParse(testFederatedFollowers),
+			[]*url.URL{mustParse(testMyInboxIRI), mustParse(testMyInboxIRI2)},
+			nil)
+		delegate.EXPECT().PostInbox(withSharedInboxDelivery(ctx), mustParse(testMyInboxIRI), toDeserializedForm(a)).Return(nil)
+		delegate.EXPECT().InboxForwarding(withReceivedActivity(withSharedInboxDelivery(ctx), mustSerializeToBytes(a)), mustParse(testMyInboxIRI), toDeserializedForm(a)).Return(nil)
+		delegate.EXPECT().PostInbox(withSharedInboxDelivery(ctx), mustParse(testMyInboxIRI2), toDeserializedForm(a)).Return(nil)
+		delegate.EXPECT().InboxForwarding(withReceivedActivity(withSharedInboxDelivery(ctx), mustSerializeToBytes(a)), mustParse(testMyInboxIRI2), toDeserializedForm(a)).Return(nil)
+		// Run
+		handled, err := s.PostInbox(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("SkipsFollowersWhenNotAddressed", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, lookup, s := setupFn(ctl)
+		a := newCreateFn(testMyActorIRI, testFederatedFollowing)
+		resp := httptest.NewRecorder()
+		req := newRequestFn(a)
+		// Mock
+		expectReceiveFn(delegate, resp, req, a)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testMyActorIRI)).Return(mustParse(testMyInboxIRI), nil)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testFederatedFollowing)).Return(nil, nil)
+		lookup.EXPECT().FollowerInboxes(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustParse(testFederatedFollowers),
+			[]*url.URL{mustParse(testMyInboxIRI2)},
+			nil)
+		delegate.EXPECT().PostInbox(withSharedInboxDelivery(ctx), mustParse(testMyInboxIRI), toDeserializedForm(a)).Return(nil)
+		delegate.EXPECT().InboxForwarding(withReceivedActivity(withSharedInboxDelivery(ctx), mustSerializeToBytes(a)), mustParse(testMyInboxIRI), toDeserializedForm(a)).Return(nil)
+		// Run
+		handled, err := s.PostInbox(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("SkipsRecipientThatBlockedSender", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, lookup, s := setupFn(ctl)
+		a := newCreateFn(testMyActorIRI, testFederatedFollowers)
+		resp := httptest.NewRecorder()
+		req := newRequestFn(a)
+		// Mock
+		expectReceiveFn(delegate, resp, req, a)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testMyActorIRI)).Return(mustParse(testMyInboxIRI), nil)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testFederatedFollowers)).Return(nil, nil)
+		lookup.EXPECT().FollowerInboxes(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustParse(testFederatedFollowers),
+			[]*url.URL{mustParse(testMyInboxIRI2)},
+			nil)
+		delegate.EXPECT().PostInbox(withSharedInboxDelivery(ctx), mustParse(testMyInboxIRI), toDeserializedForm(a)).Return(ErrBlocked)
+		delegate.EXPECT().PostInbox(withSharedInboxDelivery(ctx), mustParse(testMyInboxIRI2), toDeserializedForm(a)).Return(nil)
+		delegate.EXPECT().InboxForwarding(withReceivedActivity(withSharedInboxDelivery(ctx), mustSerializeToBytes(a)), mustParse(testMyInboxIRI2), toDeserializedForm(a)).Return(nil)
+		// Run
+		handled, err := s.PostInbox(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("BadRequestForErrObjectRequired", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, lookup, s := setupFn(ctl)
+		a := newCreateFn(testMyActorIRI)
+		resp := httptest.NewRecorder()
+		req := newRequestFn(a)
+		// Mock
+		expectReceiveFn(delegate, resp, req, a)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testMyActorIRI)).Return(mustParse(testMyInboxIRI), nil)
+		lookup.EXPECT().FollowerInboxes(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil, nil)
+		delegate.EXPECT().PostInbox(withSharedInboxDelivery(ctx), mustParse(testMyInboxIRI), toDeserializedForm(a)).Return(ErrObjectRequired)
+		// Run
+		handled, err := s.PostInbox(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
+	newCreateNoteFn := func() vocab.ActivityStreamsCreate {
+		a := newCreateFn(testMyActorIRI, testFederatedFollowers)
+		note := streams.NewActivityStreamsNote()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedSharedNote))
+		note.SetJSONLDId(id)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsNote(note)
+		a.SetActivityStreamsObject(op)
+		return a
+	}
+	setupSideEffectsFn := func(ctl *gomock.Controller) (fp *MockFederatingProtocol, provider *MockBlockStoreProvider, db *MockDatabase, lookup *MockSharedInboxLookup, s *SharedInbox) {
+		fp = NewMockFederatingProtocol(ctl)
+		provider = NewMockBlockStoreProvider(ctl)
+		db = NewMockDatabase(ctl)
+		lookup = NewMockSharedInboxLookup(ctl)
+		s = NewSharedInbox(NewMockCommonBehavior(ctl), blockStoreFederatingProtocol{fp, provider}, db, NewMockClock(ctl), lookup)
+		return
+	}
+	expectSideEffectsReceiveFn := func(fp *MockFederatingProtocol, lookup *MockSharedInboxLookup, resp http.ResponseWriter, req *http.Request, a vocab.Type) {
+		fp.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		fp.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(a)).Return(ctx, nil)
+		fp.EXPECT().Blocked(ctx, []*url.URL{mustParse(testFederatedActorIRI)}).Return(false, nil)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testMyActorIRI)).Return(mustParse(testMyInboxIRI), nil)
+		lookup.EXPECT().InboxForActor(ctx, mustParse(testFederatedFollowers)).Return(nil, nil)
+		lookup.EXPECT().FollowerInboxes(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustParse(testFederatedFollowers),
+			[]*url.URL{mustParse(testMyInboxIRI2)},
+			nil)
+	}
+	// addToInboxFn lists the calls adding the activity to a new inbox.
+	addToInboxFn := func(db *MockDatabase, c context.Context, inboxIRI *url.URL) []*gomock.Call {
+		inbox := streams.NewActivityStreamsOrderedCollectionPage()
+		return []*gomock.Call{
+			db.EXPECT().Lock(c, inboxIRI),
+			db.EXPECT().InboxContains(c, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(c, inboxIRI).Return(inbox, nil),
+			db.EXPECT().SetInbox(c, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(c, inboxIRI),
+		}
+	}
+	// createNoteFn lists the calls storing the Note and the activity, the
+	// latter when the first recipient's inbox forwarding is considered.
+	createNoteFn := func(db *MockDatabase, c, forwardCtx context.Context, a vocab.Type) []*gomock.Call {
+		note := toDeserializedForm(a).(vocab.ActivityStreamsCreate).GetActivityStreamsObject().At(0).GetType()
+		return []*gomock.Call{
+			db.EXPECT().Lock(c, mustParse(testFederatedSharedNote)),
+			db.EXPECT().Create(c, note).Return(nil),
+			db.EXPECT().Unlock(c, mustParse(testFederatedSharedNote)),
+			db.EXPECT().Lock(forwardCtx, mustParse(testFederatedActivityIRI)),
+			db.EXPECT().Exists(forwardCtx, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().Create(forwardCtx, toDeserializedForm(a)).Return(nil),
+			db.EXPECT().Unlock(forwardCtx, mustParse(testFederatedActivityIRI)),
+			db.EXPECT().Lock(forwardCtx, mustParse(testMyActorIRI)),
+			db.EXPECT().Owns(forwardCtx, mustParse(testMyActorIRI)).Return(false, nil),
+			db.EXPECT().Unlock(forwardCtx, mustParse(testMyActorIRI)),
+			db.EXPECT().Lock(forwardCtx, mustParse(testFederatedFollowers)),
+			db.EXPECT().Owns(forwardCtx, mustParse(testFederatedFollowers)).Return(false, nil),
+			db.EXPECT().Unlock(forwardCtx, mustParse(testFederatedFollowers)),
+		}
+	}
+	t.Run("StoresObjectsOnceForAllRecipients", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		fp, provider, db, lookup, s := setupSideEffectsFn(ctl)
+		a := newCreateNoteFn()
+		resp := httptest.NewRecorder()
+		req := newRequestFn(a)
+		// The delivery is marked as applied once the first recipient's
+		// side effects are.
+		deliveryCtx := withSharedInboxDelivery(ctx)
+		appliedCtx := withSharedInboxDelivery(ctx)
+		sharedInboxDeliveryFrom(appliedCtx).applied = true
+		forwardCtx := withReceivedActivity(appliedCtx, mustSerializeToBytes(a))
+		// Mock
+		expectSideEffectsReceiveFn(fp, lookup, resp, req, a)
+		provider.EXPECT().BlockStore(deliveryCtx).Return(nil)
+		provider.EXPECT().BlockStore(appliedCtx).Return(nil)
+		fp.EXPECT().FederatingCallbacks(deliveryCtx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		fp.EXPECT().FederatingCallbacks(appliedCtx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		var calls []*gomock.Call
+		calls = append(calls, addToInboxFn(db, deliveryCtx, mustParse(testMyInboxIRI))...)
+		calls = append(calls, createNoteFn(db, deliveryCtx, forwardCtx, a)...)
+		calls = append(calls, addToInboxFn(db, appliedCtx, mustParse(testMyInboxIRI2))...)
+		calls = append(calls,
+			db.EXPECT().Lock(forwardCtx, mustParse(testFederatedActivityIRI)),
+			db.EXPECT().Exists(forwardCtx, mustParse(testFederatedActivityIRI)).Return(true, nil),
+			db.EXPECT().Unlock(forwardCtx, mustParse(testFederatedActivityIRI)),
+		)
+		gomock.InOrder(calls...)
+		// Run
+		handled, err := s.PostInbox(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("AppliesBlocksOfEachRecipient", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		fp, provider, db, lookup, s := setupSideEffectsFn(ctl)
+		a := newCreateNoteFn()
+		resp := httptest.NewRecorder()
+		req := newRequestFn(a)
+		store := NewMemoryBlockStore()
+		store.AddBlocks(ctx, mustParse(testPersonIRI), []*url.URL{mustParse(testFederatedActorIRI)})
+		// The blocked recipient applies no side effects, so they are
+		// applied for the next one.
+		deliveryCtx := withSharedInboxDelivery(ctx)
+		appliedCtx := withSharedInboxDelivery(ctx)
+		sharedInboxDeliveryFrom(appliedCtx).applied = true
+		forwardCtx := withReceivedActivity(appliedCtx, mustSerializeToBytes(a))
+		// Mock
+		expectSideEffectsReceiveFn(fp, lookup, resp, req, a)
+		provider.EXPECT().BlockStore(deliveryCtx).Return(store).Times(2)
+		fp.EXPECT().FederatingCallbacks(deliveryCtx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		calls := []*gomock.Call{
+			db.EXPECT().Lock(deliveryCtx, mustParse(testMyInboxIRI)),
+			db.EXPECT().ActorForInbox(deliveryCtx, mustParse(testMyInboxIRI)).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(deliveryCtx, mustParse(testMyInboxIRI)),
+			db.EXPECT().Lock(deliveryCtx, mustParse(testMyInboxIRI2)),
+			db.EXPECT().ActorForInbox(deliveryCtx, mustParse(testMyInboxIRI2)).Return(mustParse(testMyActorIRI), nil),
+			db.EXPECT().Unlock(deliveryCtx, mustParse(testMyInboxIRI2)),
+		}
+		calls = append(calls, addToInboxFn(db, deliveryCtx, mustParse(testMyInboxIRI2))...)
+		calls = append(calls, createNoteFn(db, deliveryCtx, forwardCtx, a)...)
+		gomock.InOrder(calls...)
+		// Run
+		handled, err := s.PostInbox(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+}
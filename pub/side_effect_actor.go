@@ -43,6 +43,15 @@ func (a *sideEffectActor) AuthenticatePostInbox(c context.Context, w http.Respon
 	return a.s2s.AuthenticatePostInbox(c, w, r)
 }
 
+// PostInboxDigestAlgorithms defers to the delegate if it implements
+// PostInboxDigestPolicy, otherwise no digest is required.
+func (a *sideEffectActor) PostInboxDigestAlgorithms(c context.Context, inboxIRI *url.URL) []DigestAlgorithm {
+	if p, ok := a.s2s.(PostInboxDigestPolicy); ok {
+		return p.PostInboxDigestAlgorithms(c, inboxIRI)
+	}
+	return nil
+}
+
 // AuthenticateGetInbox defers to the delegate to authenticate the request.
 func (a *sideEffectActor) AuthenticateGetInbox(c context.Context, w http.ResponseWriter, r *http.Request) (out context.Context, authenticated bool, err error) {
 	return a.common.AuthenticateGetInbox(c, w, r)
@@ -58,14 +67,25 @@ func (a *sideEffectActor) AuthenticateGetOutbox(c context.Context, w http.Respon
 	return a.common.AuthenticateGetOutbox(c, w, r)
 }
 
-// GetOutbox delegates to the SocialProtocol.
-func (a *sideEffectActor) GetOutbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error) {
-	return a.common.GetOutbox(c, r)
+// GetOutbox obtains the outbox's items from the Database.
+func (a *sideEffectActor) GetOutbox(c context.Context, r *http.Request, outboxIRI *url.URL, cursor CollectionCursor) (items vocab.ActivityStreamsOrderedItemsProperty, totalItems int, err error) {
+	return a.getOrderedCollectionItems(c, outboxIRI, cursor)
+}
+
+// GetInbox obtains the inbox's items from the Database.
+func (a *sideEffectActor) GetInbox(c context.Context, r *http.Request, inboxIRI *url.URL, cursor CollectionCursor) (items vocab.ActivityStreamsOrderedItemsProperty, totalItems int, err error) {
+	return a.getOrderedCollectionItems(c, inboxIRI, cursor)
 }
 
-// GetInbox delegates to the FederatingProtocol.
-func (a *sideEffectActor) GetInbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error) {
-	return a.s2s.GetInbox(c, r)
+// CollectionPageSize defers to the CommonBehavior if it implements
+// CollectionPagePolicy, otherwise pages have the default size.
+func (a *sideEffectActor) CollectionPageSize(c context.Context) int {
+	if p, ok := a.common.(CollectionPagePolicy); ok {
+		if n := p.CollectionPageSize(c); n > 0 {
+			return n
+		}
+	}
+	return collectionPageSize
 }
 
 // AuthorizePostInbox defers to the federating protocol whether the peer request
@@ -105,21 +125,24 @@ func (a *sideEffectActor) AuthorizePostInbox(c context.Context, w http.ResponseW
 // request, adding the activity to the actor's inbox, and triggering side
 // effects based on the activity's type.
 func (a *sideEffectActor) PostInbox(c context.Context, inboxIRI *url.URL, activity Activity) error {
+	if blocked, err := a.blockedByInboxOwner(c, inboxIRI, activity); err != nil {
+		return err
+	} else if blocked {
+		return ErrBlocked
+	}
 	isNew, err := a.addToInboxIfNew(c, inboxIRI, activity)
 	if err != nil {
 		return err
 	}
 	if isNew {
-		wrapped, other, err := a.s2s.FederatingCallbacks(c)
+		wrapped, other, err := a.federatingCallbacks(c, inboxIRI)
 		if err != nil {
 			return err
 		}
-		// Populate side channels.
-		wrapped.db = a.db
-		wrapped.inboxIRI = inboxIRI
-		wrapped.newTransport = a.common.NewTransport
-		wrapped.deliver = a.Deliver
-		wrapped.addNewIds = a.AddNewIDs
+		// An activity posted to a shared inbox only has the side
+		// effects that do not depend on the recipient applied once.
+		delivery := sharedInboxDeliveryFrom(c)
+		wrapped.recipientOnly = delivery != nil && delivery.applied
 		res, err := streams.NewTypeResolver(wrapped.callbacks(other)...)
 		if err != nil {
 			return err
@@ -132,15 +155,107 @@ func (a *sideEffectActor) PostInbox(c context.Context, inboxIRI *url.URL, activi
 				return err
 			}
 		}
+		if delivery != nil {
+			delivery.applied = true
+		}
+	}
+	return nil
+}
+
+// blockStore returns the BlockStore of the FederatingProtocol if it implements
+// BlockStoreProvider, otherwise nil.
+func (a *sideEffectActor) blockStore(c context.Context) BlockStore {
+	if p, ok := a.s2s.(BlockStoreProvider); ok {
+		return p.BlockStore(c)
 	}
 	return nil
 }
 
+// blockedByInboxOwner determines whether the actor owning the inbox has blocked
+// any of the actors of the activity, according to the BlockStore.
+func (a *sideEffectActor) blockedByInboxOwner(c context.Context, inboxIRI *url.URL, activity Activity) (bool, error) {
+	store := a.blockStore(c)
+	if store == nil {
+		return false, nil
+	}
+	actors := activity.GetActivityStreamsActor()
+	if actors == nil {
+		return false, nil
+	}
+	iris := make([]*url.URL, 0, actors.Len())
+	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return false, err
+		}
+		iris = append(iris, id)
+	}
+	if err := a.db.Lock(c, inboxIRI); err != nil {
+		return false, err
+	}
+	// WARNING: Unlock not deferred.
+	actorIRI, err := a.db.ActorForInbox(c, inboxIRI)
+	if err != nil {
+		a.db.Unlock(c, inboxIRI)
+		return false, err
+	}
+	a.db.Unlock(c, inboxIRI)
+	// Unlock must be called by now and every branch above.
+	return store.Blocked(c, actorIRI, iris)
+}
+
+// RespondToFollowRequest accepts or rejects a Follow in the actor's pending
+// follow requests, delivering the response and updating the followers as the
+// Follow callback would have done for an automatic response.
+func (a *sideEffectActor) RespondToFollowRequest(c context.Context, inboxIRI, followIRI *url.URL, accept bool) error {
+	wrapped, _, err := a.federatingCallbacks(c, inboxIRI)
+	if err != nil {
+		return err
+	}
+	return wrapped.resolveFollowRequest(c, followIRI, accept)
+}
+
+// ForwardReport sends the remote objects of a Report in the ModerationQueue to
+// the servers owning them.
+func (a *sideEffectActor) ForwardReport(c context.Context, inboxIRI, reportIRI *url.URL) error {
+	wrapped, _, err := a.federatingCallbacks(c, inboxIRI)
+	if err != nil {
+		return err
+	}
+	return wrapped.forwardReport(c, reportIRI)
+}
+
+// federatingCallbacks obtains the callbacks from the federating protocol, with
+// the side channels of the wrapped callbacks populated for the inbox.
+func (a *sideEffectActor) federatingCallbacks(c context.Context, inboxIRI *url.URL) (wrapped FederatingWrappedCallbacks, other []interface{}, err error) {
+	wrapped, other, err = a.s2s.FederatingCallbacks(c)
+	if err != nil {
+		return
+	}
+	// Populate side channels.
+	wrapped.db = a.db
+	wrapped.inboxIRI = inboxIRI
+	wrapped.newTransport = a.common.NewTransport
+	wrapped.deliver = a.Deliver
+	wrapped.addNewIds = a.AddNewIDs
+	if p, ok := a.s2s.(ModerationQueueProvider); ok {
+		wrapped.moderationQueue = p.ModerationQueue
+	}
+	if p, ok := a.s2s.(VoteStoreProvider); ok {
+		wrapped.voteStore = p.VoteStore
+	}
+	wrapped.clock = a.clock
+	return
+}
+
 // InboxForwarding implements the 3-part inbox forwarding algorithm specified in
 // the ActivityPub specification. Does not modify the Activity, but may send
 // outbound requests as a side effect.
 //
 // InboxForwarding sets the federated data in the database.
+//
+// The activity is forwarded in the raw body it was received in, so that peers
+// can authenticate it with its author's Linked Data Signature, if any.
 func (a *sideEffectActor) InboxForwarding(c context.Context, inboxIRI *url.URL, activity Activity) error {
 	// 1. Must be first time we have seen this Activity.
 	//
@@ -313,7 +428,21 @@ func (a *sideEffectActor) InboxForwarding(c context.Context, inboxIRI *url.URL,
 			}
 		}
 	}
-	return a.deliverToRecipients(c, inboxIRI, activity, recipients)
+	// Forward the activity as it was received, as serializing it again
+	// may drop JSON-LD context terms covered by the author's Linked Data
+	// Signature.
+	if raw, ok := receivedActivity(c); ok {
+		return a.deliverToRecipients(c, inboxIRI, raw, recipients)
+	}
+	m, err := streams.Serialize(activity)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return a.deliverToRecipients(c, inboxIRI, b, recipients)
 }
 
 // PostOutbox handles the side effects of adding the activity to the actor's
@@ -337,6 +466,7 @@ func (a *sideEffectActor) PostOutbox(c context.Context, activity Activity, outbo
 		wrapped.rawActivity = rawJSON
 		wrapped.clock = a.clock
 		wrapped.newTransport = a.common.NewTransport
+		wrapped.blockStore = a.blockStore
 		undeliverable := false
 		wrapped.undeliverable = &undeliverable
 		var res *streams.TypeResolver
@@ -397,13 +527,79 @@ func (a *sideEffectActor) AddNewIDs(c context.Context, activity Activity) error
 // deliver will complete the peer-to-peer sending of a federated message to
 // another server.
 //
+// An activity addressed to the Public collection is given a Linked Data
+// Signature if the FederatingProtocol is an LDSignerProvider that provides
+// an LDSigner for the outbox. Other activities are delivered unsigned, as
+// they are not meant to be forwarded beyond their recipients.
+//
 // Must be called if at least the federated protocol is supported.
 func (a *sideEffectActor) Deliver(c context.Context, outboxIRI *url.URL, activity Activity) error {
+	isPublic, err := isAddressedToPublic(activity)
+	if err != nil {
+		return err
+	}
 	recipients, err := a.prepare(c, outboxIRI, activity)
 	if err != nil {
 		return err
 	}
-	return a.deliverToRecipients(c, outboxIRI, activity, recipients)
+	m, err := streams.Serialize(activity)
+	if err != nil {
+		return err
+	}
+	if p, ok := a.s2s.(LDSignerProvider); ok && isPublic {
+		signer, err := p.LDSigner(c, outboxIRI)
+		if err != nil {
+			return err
+		} else if signer != nil {
+			if err = signer.SignLD(c, m); err != nil {
+				return err
+			}
+		}
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return a.deliverToRecipients(c, outboxIRI, b, recipients)
+}
+
+// isAddressedToPublic determines whether the Public collection is in the
+// 'to', 'cc' or 'audience' of the activity.
+func isAddressedToPublic(activity Activity) (bool, error) {
+	var iris []*url.URL
+	if to := activity.GetActivityStreamsTo(); to != nil {
+		for iter := to.Begin(); iter != to.End(); iter = iter.Next() {
+			val, err := ToId(iter)
+			if err != nil {
+				return false, err
+			}
+			iris = append(iris, val)
+		}
+	}
+	if cc := activity.GetActivityStreamsCc(); cc != nil {
+		for iter := cc.Begin(); iter != cc.End(); iter = iter.Next() {
+			val, err := ToId(iter)
+			if err != nil {
+				return false, err
+			}
+			iris = append(iris, val)
+		}
+	}
+	if audience := activity.GetActivityStreamsAudience(); audience != nil {
+		for iter := audience.Begin(); iter != audience.End(); iter = iter.Next() {
+			val, err := ToId(iter)
+			if err != nil {
+				return false, err
+			}
+			iris = append(iris, val)
+		}
+	}
+	for _, iri := range iris {
+		if IsPublic(iri.String()) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // WrapInCreate wraps an object with a Create activity.
@@ -423,16 +619,15 @@ func (a *sideEffectActor) WrapInCreate(c context.Context, obj vocab.Type, outbox
 	return wrapInCreate(c, obj, actorIRI)
 }
 
-// deliverToRecipients will take a prepared Activity and send it to specific
-// recipients on behalf of an actor.
-func (a *sideEffectActor) deliverToRecipients(c context.Context, boxIRI *url.URL, activity Activity, recipients []*url.URL) error {
-	m, err := streams.Serialize(activity)
-	if err != nil {
-		return err
-	}
-	b, err := json.Marshal(m)
-	if err != nil {
-		return err
+// deliverToRecipients will take a prepared and serialized Activity and send it
+// to specific recipients on behalf of an actor.
+//
+// The DeliveryQueue is used if the FederatingProtocol provides one.
+func (a *sideEffectActor) deliverToRecipients(c context.Context, boxIRI *url.URL, b []byte, recipients []*url.URL) error {
+	if p, ok := a.s2s.(DeliveryQueueProvider); ok {
+		if q := p.DeliveryQueue(c); q != nil {
+			return q.Enqueue(c, boxIRI, b, recipients)
+		}
 	}
 	tp, err := a.common.NewTransport(c, boxIRI, goFedUserAgent())
 	if err != nil {
@@ -616,7 +811,10 @@ func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI *
 //
 // Only call if both the social and federated protocol are supported.
 func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activity Activity) (r []*url.URL, err error) {
-	// Get inboxes of recipients
+	// Get inboxes of recipients. Hidden recipients are kept separate, as
+	// they will not be visible to a peer receiving the activity at a
+	// sharedInbox.
+	var hidden []*url.URL
 	if to := activity.GetActivityStreamsTo(); to != nil {
 		for iter := to.Begin(); iter != to.End(); iter = iter.Next() {
 			var val *url.URL
@@ -634,7 +832,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 			if err != nil {
 				return
 			}
-			r = append(r, val)
+			hidden = append(hidden, val)
 		}
 	}
 	if cc := activity.GetActivityStreamsCc(); cc != nil {
@@ -654,7 +852,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 			if err != nil {
 				return
 			}
-			r = append(r, val)
+			hidden = append(hidden, val)
 		}
 	}
 	if audience := activity.GetActivityStreamsAudience(); audience != nil {
@@ -675,19 +873,49 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 	// 2. If an object is addressed to the Public special collection, a
 	//    server MAY deliver that object to all known sharedInbox endpoints
 	//    on the network.
+	isPublic := false
+	for _, u := range r {
+		if IsPublic(u.String()) {
+			isPublic = true
+			break
+		}
+	}
 	r = filterURLs(r, IsPublic)
+	hidden = filterURLs(hidden, IsPublic)
+	var known []*url.URL
+	if p, ok := a.s2s.(PublicSharedInboxesProvider); ok && isPublic {
+		known, err = p.PublicSharedInboxes(c)
+		if err != nil {
+			return nil, err
+		}
+	}
 	t, err := a.common.NewTransport(c, outboxIRI, goFedUserAgent())
 	if err != nil {
 		return nil, err
 	}
-	receiverActors, err := a.resolveInboxes(c, t, r, 0, a.s2s.MaxDeliveryRecursionDepth(c))
+	maxDepth := a.s2s.MaxDeliveryRecursionDepth(c)
+	maxPages, maxItems := deliveryCollectionPages, deliveryCollectionItems
+	if p, ok := a.s2s.(DeliveryCollectionPolicy); ok {
+		maxPages = p.MaxDeliveryCollectionPages(c)
+		maxItems = p.MaxDeliveryCollectionItems(c)
+	}
+	receiverActors, err := a.resolveInboxes(c, t, r, 0, maxDepth, maxPages, maxItems)
+	if err != nil {
+		return nil, err
+	}
+	hiddenActors, err := a.resolveInboxes(c, t, hidden, 0, maxDepth, maxPages, maxItems)
 	if err != nil {
 		return nil, err
 	}
-	targets, err := getInboxes(receiverActors)
+	targets, err := getSharedInboxes(receiverActors, known)
 	if err != nil {
 		return nil, err
 	}
+	hiddenTargets, err := getInboxes(hiddenActors)
+	if err != nil {
+		return nil, err
+	}
+	targets = append(append(targets, known...), hiddenTargets...)
 	// Get inboxes of sender.
 	err = a.db.Lock(c, outboxIRI)
 	if err != nil {
@@ -727,13 +955,15 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 // instances of actorObject. It attempts to apply recursively when it encounters
 // a target that is a Collection or OrderedCollection.
 //
-// If maxDepth is zero or negative, then recursion is infinitely applied.
+// If maxDepth is zero or negative, then recursion is infinitely applied. The
+// maxPages and maxItems limit the traversal of each collection's pages, and
+// are the defaults unless the FederatingProtocol is a DeliveryCollectionPolicy.
 //
 // If a recipient is a Collection or OrderedCollection, then the server MUST
 // dereference the collection, WITH the user's credentials.
 //
 // Note that this also applies to CollectionPage and OrderedCollectionPage.
-func (a *sideEffectActor) resolveInboxes(c context.Context, t Transport, r []*url.URL, depth, maxDepth int) (actors []vocab.Type, err error) {
+func (a *sideEffectActor) resolveInboxes(c context.Context, t Transport, r []*url.URL, depth, maxDepth, maxPages, maxItems int) (actors []vocab.Type, err error) {
 	if maxDepth > 0 && depth >= maxDepth {
 		return
 	}
@@ -742,13 +972,13 @@ func (a *sideEffectActor) resolveInboxes(c context.Context, t Transport, r []*ur
 		var more []*url.URL
 		// TODO: Determine if more logic is needed here for inaccessible
 		// collections owned by peer servers.
-		act, more, err = a.dereferenceForResolvingInboxes(c, t, u)
+		act, more, err = a.dereferenceForResolvingInboxes(c, t, u, maxPages, maxItems)
 		if err != nil {
 			// Missing recipient -- skip.
 			continue
 		}
 		var recurActors []vocab.Type
-		recurActors, err = a.resolveInboxes(c, t, more, depth+1, maxDepth)
+		recurActors, err = a.resolveInboxes(c, t, more, depth+1, maxDepth, maxPages, maxItems)
 		if err != nil {
 			return
 		}
@@ -764,8 +994,9 @@ func (a *sideEffectActor) resolveInboxes(c context.Context, t Transport, r []*ur
 // actor's inbox IRI to deliver to.
 //
 // The returned actor could be nil, if it wasn't an actor (ex: a Collection or
-// OrderedCollection).
-func (a *sideEffectActor) dereferenceForResolvingInboxes(c context.Context, t Transport, actorIRI *url.URL) (actor vocab.Type, moreActorIRIs []*url.URL, err error) {
+// OrderedCollection). The items of collections are obtained from all of their
+// pages, up to maxPages pages and maxItems items.
+func (a *sideEffectActor) dereferenceForResolvingInboxes(c context.Context, t Transport, actorIRI *url.URL, maxPages, maxItems int) (actor vocab.Type, moreActorIRIs []*url.URL, err error) {
 	var resp []byte
 	resp, err = t.Dereference(c, actorIRI)
 	if err != nil {
@@ -780,31 +1011,39 @@ func (a *sideEffectActor) dereferenceForResolvingInboxes(c context.Context, t Tr
 		return
 	}
 	// Attempt to see if the 'actor' is really some sort of type that has
-	// an 'items' or 'orderedItems' property.
-	if v, ok := actor.(itemser); ok {
-		if i := v.GetActivityStreamsItems(); i != nil {
-			for iter := i.Begin(); iter != i.End(); iter = iter.Next() {
-				var id *url.URL
-				id, err = ToId(iter)
-				if err != nil {
-					return
-				}
-				moreActorIRIs = append(moreActorIRIs, id)
+	// an 'items' or 'orderedItems' property, and walk its pages.
+	if hasItems(actor) {
+		iter := NewCollectionIterator(t, actor, maxPages, maxItems)
+		for {
+			var item IdProperty
+			item, err = iter.Next(c)
+			if err != nil {
+				// An inaccessible page -- deliver to the
+				// items obtained so far.
+				err = nil
+				break
+			} else if item == nil {
+				break
 			}
-		}
-		actor = nil
-	} else if v, ok := actor.(orderedItemser); ok {
-		if i := v.GetActivityStreamsOrderedItems(); i != nil {
-			for iter := i.Begin(); iter != i.End(); iter = iter.Next() {
-				var id *url.URL
-				id, err = ToId(iter)
-				if err != nil {
-					return
-				}
-				moreActorIRIs = append(moreActorIRIs, id)
+			var id *url.URL
+			id, err = ToId(item)
+			if err != nil {
+				return
 			}
+			moreActorIRIs = append(moreActorIRIs, id)
 		}
 		actor = nil
 	}
 	return
 }
+
+// getOrderedCollectionItems obtains the items of an inbox or outbox selected
+// by the cursor from the Database.
+func (a *sideEffectActor) getOrderedCollectionItems(c context.Context, collectionIRI *url.URL, cursor CollectionCursor) (items vocab.ActivityStreamsOrderedItemsProperty, totalItems int, err error) {
+	err = a.db.Lock(c, collectionIRI)
+	if err != nil {
+		return
+	}
+	defer a.db.Unlock(c, collectionIRI)
+	return a.db.GetOrderedCollectionItems(c, collectionIRI, cursor)
+}
@@ -2,6 +2,7 @@ package pub
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http/httptest"
 	"net/url"
@@ -13,6 +14,62 @@ import (
 	"github.com/golang/mock/gomock"
 )
 
+// blockStoreFederatingProtocol is a FederatingProtocol that also implements
+// the optional BlockStoreProvider.
+type blockStoreFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockBlockStoreProvider
+}
+
+// digestPolicyFederatingProtocol is a FederatingProtocol that also implements
+// the optional PostInboxDigestPolicy.
+type digestPolicyFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockPostInboxDigestPolicy
+}
+
+// pagePolicyCommonBehavior is a CommonBehavior that also implements the
+// optional CollectionPagePolicy.
+type pagePolicyCommonBehavior struct {
+	*MockCommonBehavior
+	*MockCollectionPagePolicy
+}
+
+// deliveryCollectionFederatingProtocol is a FederatingProtocol that also
+// implements the optional DeliveryCollectionPolicy.
+type deliveryCollectionFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockDeliveryCollectionPolicy
+}
+
+// publicSharedInboxesFederatingProtocol is a FederatingProtocol that also
+// implements the optional PublicSharedInboxesProvider.
+type publicSharedInboxesFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockPublicSharedInboxesProvider
+}
+
+// deliveryQueueFederatingProtocol is a FederatingProtocol that also implements
+// the optional DeliveryQueueProvider.
+type deliveryQueueFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockDeliveryQueueProvider
+}
+
+// ldSignerFederatingProtocol is a FederatingProtocol that also implements the
+// optional LDSignerProvider.
+type ldSignerFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockLDSignerProvider
+}
+
+// moderationQueueFederatingProtocol is a FederatingProtocol that also
+// implements the optional ModerationQueueProvider.
+type moderationQueueFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockModerationQueueProvider
+}
+
 // TestPassThroughMethods tests the methods that pass-through to other
 // dependency-injected types.
 func TestPassThroughMethods(t *testing.T) {
@@ -48,6 +105,30 @@ func TestPassThroughMethods(t *testing.T) {
 		assertEqual(t, b, true)
 		assertEqual(t, err, testErr)
 	})
+	t.Run("PostInboxDigestAlgorithms", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, _, _, a := setupFn(ctl)
+		policy := NewMockPostInboxDigestPolicy(ctl)
+		a.(*sideEffectActor).s2s = digestPolicyFederatingProtocol{fp, policy}
+		policy.EXPECT().PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI)).Return([]DigestAlgorithm{DigestSha256})
+		// Run
+		algos := a.PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI))
+		// Verify
+		assertEqual(t, len(algos), 1)
+		assertEqual(t, algos[0], DigestSha256)
+	})
+	t.Run("PostInboxDigestAlgorithmsWithoutPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		// Run
+		algos := a.PostInboxDigestAlgorithms(ctx, mustParse(testMyInboxIRI))
+		// Verify
+		assertEqual(t, len(algos), 0)
+	})
 	t.Run("AuthenticateGetInbox", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -91,28 +172,65 @@ func TestPassThroughMethods(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		c, _, _, _, _, a := setupFn(ctl)
+		_, _, _, db, _, a := setupFn(ctl)
 		req := toAPRequest(toGetOutboxRequest())
-		c.EXPECT().GetOutbox(ctx, req).Return(testOrderedCollectionUniqueElems, testErr)
+		cursor := CollectionCursor{Limit: collectionPageSize}
+		items := testOrderedCollectionUniqueElems.GetActivityStreamsOrderedItems()
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI)),
+			db.EXPECT().GetOrderedCollectionItems(ctx, mustParse(testMyOutboxIRI), cursor).Return(items, 2, testErr),
+			db.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI)),
+		)
 		// Run
-		p, err := a.GetOutbox(ctx, req)
+		p, n, err := a.GetOutbox(ctx, req, mustParse(testMyOutboxIRI), cursor)
 		// Verify
-		assertEqual(t, p, testOrderedCollectionUniqueElems)
+		assertEqual(t, p, items)
+		assertEqual(t, n, 2)
 		assertEqual(t, err, testErr)
 	})
 	t.Run("GetInbox", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		_, fp, _, _, _, a := setupFn(ctl)
+		_, _, _, db, _, a := setupFn(ctl)
 		req := toAPRequest(toGetInboxRequest())
-		fp.EXPECT().GetInbox(ctx, req).Return(testOrderedCollectionUniqueElems, testErr)
+		cursor := CollectionCursor{Limit: collectionPageSize}
+		items := testOrderedCollectionUniqueElems.GetActivityStreamsOrderedItems()
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, mustParse(testMyInboxIRI)),
+			db.EXPECT().GetOrderedCollectionItems(ctx, mustParse(testMyInboxIRI), cursor).Return(items, 2, testErr),
+			db.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI)),
+		)
 		// Run
-		p, err := a.GetInbox(ctx, req)
+		p, n, err := a.GetInbox(ctx, req, mustParse(testMyInboxIRI), cursor)
 		// Verify
-		assertEqual(t, p, testOrderedCollectionUniqueElems)
+		assertEqual(t, p, items)
+		assertEqual(t, n, 2)
 		assertEqual(t, err, testErr)
 	})
+	t.Run("CollectionPageSize", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, _, _, _, _, a := setupFn(ctl)
+		policy := NewMockCollectionPagePolicy(ctl)
+		a.(*sideEffectActor).common = pagePolicyCommonBehavior{c, policy}
+		policy.EXPECT().CollectionPageSize(ctx).Return(5)
+		// Run
+		n := a.CollectionPageSize(ctx)
+		// Verify
+		assertEqual(t, n, 5)
+	})
+	t.Run("CollectionPageSizeWithoutPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		// Run
+		n := a.CollectionPageSize(ctx)
+		// Verify
+		assertEqual(t, n, collectionPageSize)
+	})
 }
 
 // TestAuthorizePostInbox tests the Authorization for a federated message, which
@@ -345,6 +463,77 @@ func TestPostInbox(t *testing.T) {
 		assertEqual(t, err, nil)
 		assertEqual(t, pass, true)
 	})
+	t.Run("RejectsActivityFromBlockedActor", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		provider := NewMockBlockStoreProvider(ctl)
+		a.(*sideEffectActor).s2s = blockStoreFederatingProtocol{fp, provider}
+		inboxIRI := mustParse(testMyInboxIRI)
+		store := NewMemoryBlockStore()
+		store.AddBlocks(ctx, mustParse(testPersonIRI), []*url.URL{mustParse(testFederatedActorIRI)})
+		provider.EXPECT().BlockStore(ctx).Return(store)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().ActorForInbox(ctx, inboxIRI).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, ErrBlocked)
+	})
+	t.Run("RejectsActivityFromBlockedActorWithoutSocialProtocol", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		provider := NewMockBlockStoreProvider(ctl)
+		a.(*sideEffectActor).s2s = blockStoreFederatingProtocol{fp, provider}
+		a.(*sideEffectActor).c2s = nil
+		inboxIRI := mustParse(testMyInboxIRI)
+		store := NewMemoryBlockStore()
+		store.AddBlocks(ctx, mustParse(testPersonIRI), []*url.URL{mustParse(testFederatedActorIRI)})
+		provider.EXPECT().BlockStore(ctx).Return(store)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().ActorForInbox(ctx, inboxIRI).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, ErrBlocked)
+	})
+	t.Run("AddsToInboxIfOtherActorIsBlocked", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		provider := NewMockBlockStoreProvider(ctl)
+		a.(*sideEffectActor).s2s = blockStoreFederatingProtocol{fp, provider}
+		inboxIRI := mustParse(testMyInboxIRI)
+		store := NewMemoryBlockStore()
+		store.AddBlocks(ctx, mustParse(testPersonIRI), []*url.URL{mustParse(testFederatedActorIRI2)})
+		provider.EXPECT().BlockStore(ctx).Return(store)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().ActorForInbox(ctx, inboxIRI).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		fp.EXPECT().DefaultCallback(ctx, testListen).Return(nil)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, nil)
+	})
 }
 
 // TestInboxForwarding ensures that the inbox forwarding logic is correct.
@@ -646,6 +835,94 @@ func TestInboxForwarding(t *testing.T) {
 		// Verify
 		assertEqual(t, err, nil)
 	})
+	t.Run("ForwardsReceivedBytesWithLDSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		cm, fp, _, db, _, a := setupFn(ctl)
+		key := mustGenerateRSAKey()
+		aud := streams.NewActivityStreamsAudienceProperty()
+		aud.AppendIRI(mustParse(testAudienceIRI))
+		testListen.SetActivityStreamsAudience(aud)
+		// Sign the activity with context terms that streams does not
+		// know about.
+		m := mustSerialize(testListen)
+		m["@context"] = []interface{}{
+			m["@context"],
+			map[string]interface{}{
+				"toot":      "http://joinmastodon.org/ns#",
+				"sensitive": "as:sensitive",
+			},
+		}
+		m["object"].(map[string]interface{})["sensitive"] = true
+		signer := NewRsaSignature2017Signer(&fixedClock{now()}, nil, mustParse(testFederatedKeyId), key)
+		assertEqual(t, signer.SignLD(ctx, m), nil)
+		raw, err := json.Marshal(m)
+		assertEqual(t, err, nil)
+		asValue, err := streams.ToType(ctx, m)
+		assertEqual(t, err, nil)
+		input := asValue.(Activity)
+		c := withReceivedActivity(ctx, raw)
+		tPort := NewMockTransport(ctl)
+		var forwarded []byte
+		gomock.InOrder(
+			db.EXPECT().Lock(c, mustParse(testFederatedActivityIRI)),
+			db.EXPECT().Exists(c, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().Create(c, input).Return(nil),
+			db.EXPECT().Unlock(c, mustParse(testFederatedActivityIRI)),
+			db.EXPECT().Lock(c, mustParse(testAudienceIRI)),
+			db.EXPECT().Owns(c, mustParse(testAudienceIRI)).Return(true, nil),
+			db.EXPECT().Unlock(c, mustParse(testAudienceIRI)),
+			db.EXPECT().Lock(c, mustParse(testAudienceIRI)),
+			db.EXPECT().Get(c, mustParse(testAudienceIRI)).Return(testCollectionOfActors, nil),
+			fp.EXPECT().MaxInboxForwardingRecursionDepth(c).Return(0),
+			// hasInboxForwardingValues
+			db.EXPECT().Lock(c, mustParse(testNoteId1)),
+			db.EXPECT().Owns(c, mustParse(testNoteId1)).Return(true, nil),
+			db.EXPECT().Unlock(c, mustParse(testNoteId1)),
+			// after hasInboxForwardingValues
+			fp.EXPECT().FilterForwarding(
+				c,
+				[]*url.URL{
+					mustParse(testAudienceIRI),
+				},
+				input,
+			).Return(
+				[]*url.URL{
+					mustParse(testAudienceIRI),
+				},
+				nil,
+			),
+			// deliverToRecipients
+			cm.EXPECT().NewTransport(c, mustParse(testMyInboxIRI), goFedUserAgent()).Return(tPort, nil),
+			tPort.EXPECT().BatchDeliver(
+				c,
+				gomock.Any(),
+				[]*url.URL{
+					mustParse(testFederatedActorIRI),
+					mustParse(testFederatedActorIRI2),
+				},
+			).DoAndReturn(func(c context.Context, b []byte, recipients []*url.URL) error {
+				forwarded = b
+				return nil
+			}),
+			// Deferred
+			db.EXPECT().Unlock(c, mustParse(testAudienceIRI)),
+		)
+		tPort.EXPECT().Dereference(ctx, mustParse(testFederatedKeyId)).Return(
+			mustSerializeToBytes(newPersonWithKey(testFederatedActorIRI, testFederatedKeyId, testFederatedActorIRI, &key.PublicKey)), nil)
+		// Run
+		err = a.InboxForwarding(c, mustParse(testMyInboxIRI), input)
+		// Verify
+		assertEqual(t, err, nil)
+		assertByteEqual(t, forwarded, raw)
+		var fm map[string]interface{}
+		assertEqual(t, json.Unmarshal(forwarded, &fm), nil)
+		ldSigner, verified, err := NewRsaSignature2017Verifier(nil).VerifyLD(ctx, fm, tPort)
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, true)
+		assertEqual(t, ldSigner.String(), testFederatedActorIRI)
+	})
 	t.Run("ForwardsToRecipientsIfChainIsNested", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -979,6 +1256,124 @@ func TestPostOutbox(t *testing.T) {
 		assertEqual(t, deliverable, true)
 		assertEqual(t, pass, true)
 	})
+	t.Run("BlockSeversFollowsAndRecordsBlock", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, sp, db, _, a := setupFn(ctl)
+		provider := NewMockBlockStoreProvider(ctl)
+		a.(*sideEffectActor).s2s = blockStoreFederatingProtocol{fp, provider}
+		outboxIRI := mustParse(testMyOutboxIRI)
+		actorIRI := mustParse(testPersonIRI)
+		store := NewMemoryBlockStore()
+		block := streams.NewActivityStreamsBlock()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		block.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(actorIRI)
+		block.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActorIRI))
+		block.SetActivityStreamsObject(op)
+		newCollectionFn := func(ids ...string) vocab.ActivityStreamsCollection {
+			col := streams.NewActivityStreamsCollection()
+			items := streams.NewActivityStreamsItemsProperty()
+			for _, id := range ids {
+				items.AppendIRI(mustParse(id))
+			}
+			col.SetActivityStreamsItems(items)
+			return col
+		}
+		emptyFollowing := newCollectionFn(testFederatedActorIRI)
+		emptyFollowing.GetActivityStreamsItems().Remove(0)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, outboxIRI),
+			db.EXPECT().ActorForOutbox(ctx, outboxIRI).Return(actorIRI, nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+			db.EXPECT().Lock(ctx, actorIRI),
+			db.EXPECT().Followers(ctx, actorIRI).Return(newCollectionFn(testFederatedActorIRI, testFederatedActorIRI2), nil),
+			db.EXPECT().Update(ctx, newCollectionFn(testFederatedActorIRI2)).Return(nil),
+			db.EXPECT().Unlock(ctx, actorIRI),
+			db.EXPECT().Lock(ctx, outboxIRI),
+			db.EXPECT().ActorForOutbox(ctx, outboxIRI).Return(actorIRI, nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+			db.EXPECT().Lock(ctx, actorIRI),
+			db.EXPECT().Following(ctx, actorIRI).Return(newCollectionFn(testFederatedActorIRI), nil),
+			db.EXPECT().Update(ctx, emptyFollowing).Return(nil),
+			db.EXPECT().Unlock(ctx, actorIRI),
+			db.EXPECT().Lock(ctx, outboxIRI),
+			db.EXPECT().ActorForOutbox(ctx, outboxIRI).Return(actorIRI, nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+			db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Create(ctx, block),
+			db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Lock(ctx, outboxIRI),
+			db.EXPECT().GetOutbox(ctx, outboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetOutbox(ctx, testOrderedCollectionWithNewId).Return(nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+		)
+		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{}, nil, nil)
+		provider.EXPECT().BlockStore(ctx).Return(store)
+		// Run
+		deliverable, err := a.PostOutbox(ctx, block, outboxIRI, mustSerialize(block))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, deliverable, false)
+		blocked, err := store.Blocked(ctx, actorIRI, []*url.URL{mustParse(testFederatedActorIRI)})
+		assertEqual(t, err, nil)
+		assertEqual(t, blocked, true)
+	})
+	t.Run("MoveAddressesFollowers", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, db, _, a := setupFn(ctl)
+		outboxIRI := mustParse(testMyOutboxIRI)
+		actorIRI := mustParse(testPersonIRI)
+		followersIRI := mustParse(testPersonIRI + "/followers")
+		move := streams.NewActivityStreamsMove()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		move.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(actorIRI)
+		move.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(actorIRI)
+		move.SetActivityStreamsObject(op)
+		target := streams.NewActivityStreamsTargetProperty()
+		target.AppendIRI(mustParse(testFederatedActorIRI))
+		move.SetActivityStreamsTarget(target)
+		followers := streams.NewActivityStreamsCollection()
+		followersId := streams.NewJSONLDIdProperty()
+		followersId.Set(followersIRI)
+		followers.SetJSONLDId(followersId)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, outboxIRI),
+			db.EXPECT().ActorForOutbox(ctx, outboxIRI).Return(actorIRI, nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+			db.EXPECT().Lock(ctx, actorIRI),
+			db.EXPECT().Followers(ctx, actorIRI).Return(followers, nil),
+			db.EXPECT().Unlock(ctx, actorIRI),
+			db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Create(ctx, move),
+			db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Lock(ctx, outboxIRI),
+			db.EXPECT().GetOutbox(ctx, outboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetOutbox(ctx, testOrderedCollectionWithNewId).Return(nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+		)
+		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{}, nil, nil)
+		// Run
+		deliverable, err := a.PostOutbox(ctx, move, outboxIRI, mustSerialize(move))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, deliverable, true)
+		to := move.GetActivityStreamsTo()
+		assertEqual(t, to.Len(), 1)
+		assertEqual(t, to.At(0).GetIRI().String(), followersIRI.String())
+	})
 }
 
 // TestAddNewIDs ensures that new 'id' properties are set on an activity and all
@@ -1331,7 +1726,7 @@ func TestDeliver(t *testing.T) {
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
 	})
-	t.Run("RecursivelyResolveCollectionActors", func(t *testing.T) {
+	t.Run("CollapsesRecipientsSharingASharedInbox", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -1339,22 +1734,20 @@ func TestDeliver(t *testing.T) {
 		mockTp := NewMockTransport(ctl)
 		act := baseActivityFn()
 		to := streams.NewActivityStreamsToProperty()
-		to.AppendIRI(mustParse(testAudienceIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
 		act.SetActivityStreamsTo(to)
 		expectRecip := []*url.URL{
-			mustParse(testFederatedInboxIRI),
-			mustParse(testFederatedInboxIRI2),
+			mustParse(testFederatedSharedInbox),
 		}
 		// Mock
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
-		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
-			mustSerializeToBytes(testCollectionOfActors), nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
-			mustSerializeToBytes(testFederatedPerson1), nil)
+			mustSerializeWithSharedInbox(testFederatedPerson1, testFederatedSharedInbox), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
-			mustSerializeToBytes(testFederatedPerson2), nil)
+			mustSerializeWithSharedInbox(testFederatedPerson2, testFederatedSharedInbox), nil)
 		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
 		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
 			mustParse(testPersonIRI), nil)
@@ -1365,12 +1758,12 @@ func TestDeliver(t *testing.T) {
 		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		mockTp.EXPECT().BatchDeliver(ctx, gomock.Any(), expectRecip)
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
 	})
-	t.Run("RecursivelyResolveOrderedCollectionActors", func(t *testing.T) {
+	t.Run("DoesNotCollapseLoneSharedInbox", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -1378,7 +1771,8 @@ func TestDeliver(t *testing.T) {
 		mockTp := NewMockTransport(ctl)
 		act := baseActivityFn()
 		to := streams.NewActivityStreamsToProperty()
-		to.AppendIRI(mustParse(testAudienceIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
 		act.SetActivityStreamsTo(to)
 		expectRecip := []*url.URL{
 			mustParse(testFederatedInboxIRI),
@@ -1387,13 +1781,11 @@ func TestDeliver(t *testing.T) {
 		// Mock
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
-		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
-			mustSerializeToBytes(testOrderedCollectionOfActors), nil)
-		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI3)).Return(
-			mustSerializeToBytes(testFederatedPerson1), nil)
-		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI4)).Return(
-			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeWithSharedInbox(testFederatedPerson1, testFederatedSharedInbox), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeWithSharedInbox(testFederatedPerson2, "https://other.example.com/other-inbox"), nil)
 		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
 		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
 			mustParse(testPersonIRI), nil)
@@ -1404,27 +1796,38 @@ func TestDeliver(t *testing.T) {
 		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		mockTp.EXPECT().BatchDeliver(ctx, gomock.Any(), expectRecip)
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
 	})
-	t.Run("DoesNotRecursivelyResolveCollectionActorsIfExceedingMaxDepth", func(t *testing.T) {
+	t.Run("SendsPublicToKnownSharedInboxes", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		provider := NewMockPublicSharedInboxesProvider(ctl)
+		a.(*sideEffectActor).s2s = publicSharedInboxesFederatingProtocol{mockFp, provider}
 		mockTp := NewMockTransport(ctl)
 		act := baseActivityFn()
 		to := streams.NewActivityStreamsToProperty()
-		to.AppendIRI(mustParse(testAudienceIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		to.AppendIRI(mustParse(PublicActivityPubIRI))
 		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testKnownSharedInbox),
+		}
 		// Mock
+		provider.EXPECT().PublicSharedInboxes(ctx).Return([]*url.URL{mustParse(testKnownSharedInbox)}, nil)
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
-		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
-			mustSerializeToBytes(testCollectionOfActors), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeWithSharedInbox(testFederatedPerson1, testFederatedSharedInbox), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeWithSharedInbox(testFederatedPerson2, testKnownSharedInbox), nil)
 		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
 		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
 			mustParse(testPersonIRI), nil)
@@ -1435,12 +1838,12 @@ func TestDeliver(t *testing.T) {
 		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), nil)
+		mockTp.EXPECT().BatchDeliver(ctx, gomock.Any(), expectRecip)
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
 	})
-	t.Run("DedupesRecipients", func(t *testing.T) {
+	t.Run("SendsToPersonalInboxOfHiddenRecipients", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -1449,23 +1852,10 @@ func TestDeliver(t *testing.T) {
 		act := baseActivityFn()
 		to := streams.NewActivityStreamsToProperty()
 		to.AppendIRI(mustParse(testFederatedActorIRI))
-		to.AppendIRI(mustParse(testFederatedActorIRI2))
 		act.SetActivityStreamsTo(to)
-		bto := streams.NewActivityStreamsBtoProperty()
-		bto.AppendIRI(mustParse(testFederatedActorIRI))
-		bto.AppendIRI(mustParse(testFederatedActorIRI2))
-		act.SetActivityStreamsBto(bto)
-		cc := streams.NewActivityStreamsCcProperty()
-		cc.AppendIRI(mustParse(testFederatedActorIRI))
-		cc.AppendIRI(mustParse(testFederatedActorIRI2))
-		act.SetActivityStreamsCc(cc)
 		bcc := streams.NewActivityStreamsBccProperty()
-		bcc.AppendIRI(mustParse(testFederatedActorIRI))
 		bcc.AppendIRI(mustParse(testFederatedActorIRI2))
 		act.SetActivityStreamsBcc(bcc)
-		expectAct := baseActivityFn() // Ensure Bcc & Bto are stripped
-		expectAct.SetActivityStreamsTo(to)
-		expectAct.SetActivityStreamsCc(cc)
 		expectRecip := []*url.URL{
 			mustParse(testFederatedInboxIRI),
 			mustParse(testFederatedInboxIRI2),
@@ -1475,9 +1865,9 @@ func TestDeliver(t *testing.T) {
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
-			mustSerializeToBytes(testFederatedPerson1), nil).Times(4)
+			mustSerializeWithSharedInbox(testFederatedPerson1, testFederatedSharedInbox), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
-			mustSerializeToBytes(testFederatedPerson2), nil).Times(4)
+			mustSerializeWithSharedInbox(testFederatedPerson2, testFederatedSharedInbox), nil)
 		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
 		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
 			mustParse(testPersonIRI), nil)
@@ -1488,24 +1878,21 @@ func TestDeliver(t *testing.T) {
 		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(expectAct), expectRecip)
+		mockTp.EXPECT().BatchDeliver(ctx, gomock.Any(), expectRecip)
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
 	})
-	t.Run("StripsBtoOnObject", func(t *testing.T) {
+	t.Run("RecursivelyResolveCollectionActors", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		c, mockFp, _, mockDb, _, a := setupFn(ctl)
 		mockTp := NewMockTransport(ctl)
 		act := baseActivityFn()
-		bto := streams.NewActivityStreamsBtoProperty()
-		bto.AppendIRI(mustParse(testFederatedActorIRI))
-		bto.AppendIRI(mustParse(testFederatedActorIRI2))
-		act.SetActivityStreamsBto(bto)
-		act.GetActivityStreamsObject().At(0).GetActivityStreamsNote().SetActivityStreamsBto(bto)
-		expectAct := baseActivityFn() // Ensure Bto is stripped
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testAudienceIRI))
+		act.SetActivityStreamsTo(to)
 		expectRecip := []*url.URL{
 			mustParse(testFederatedInboxIRI),
 			mustParse(testFederatedInboxIRI2),
@@ -1513,8 +1900,273 @@ func TestDeliver(t *testing.T) {
 		// Mock
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
-		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
+			mustSerializeToBytes(testCollectionOfActors), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("ResolvesActorsOnCollectionPages", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		followers, page1, page2 := newPagedFollowers()
+		person3 := streams.NewActivityStreamsPerson()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActorIRI3))
+		person3.SetJSONLDId(id)
+		inbox := streams.NewActivityStreamsInboxProperty()
+		inbox.SetIRI(mustParse(testFederatedActorIRI3 + "/inbox"))
+		person3.SetActivityStreamsInbox(inbox)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFollowersIRI))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+			mustParse(testFederatedActorIRI3 + "/inbox"),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFollowersIRI)).Return(
+			mustSerializeToBytes(followers), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI)).Return(
+			mustSerializeToBytes(page1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI2)).Return(
+			mustSerializeToBytes(page2), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI3)).Return(
+			mustSerializeToBytes(person3), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("LimitsCollectionPagesWithDeliveryCollectionPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		policy := NewMockDeliveryCollectionPolicy(ctl)
+		a.(*sideEffectActor).s2s = deliveryCollectionFederatingProtocol{mockFp, policy}
+		mockTp := NewMockTransport(ctl)
+		followers, page1, _ := newPagedFollowers()
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFollowersIRI))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
+		policy.EXPECT().MaxDeliveryCollectionPages(ctx).Return(1)
+		policy.EXPECT().MaxDeliveryCollectionItems(ctx).Return(0)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFollowersIRI)).Return(
+			mustSerializeToBytes(followers), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFollowersPageIRI)).Return(
+			mustSerializeToBytes(page1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("RecursivelyResolveOrderedCollectionActors", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testAudienceIRI))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
+			mustSerializeToBytes(testOrderedCollectionOfActors), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI3)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI4)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("DoesNotRecursivelyResolveCollectionActorsIfExceedingMaxDepth", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testAudienceIRI))
+		act.SetActivityStreamsTo(to)
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
+			mustSerializeToBytes(testCollectionOfActors), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), nil)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("DedupesRecipients", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsTo(to)
+		bto := streams.NewActivityStreamsBtoProperty()
+		bto.AppendIRI(mustParse(testFederatedActorIRI))
+		bto.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsBto(bto)
+		cc := streams.NewActivityStreamsCcProperty()
+		cc.AppendIRI(mustParse(testFederatedActorIRI))
+		cc.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsCc(cc)
+		bcc := streams.NewActivityStreamsBccProperty()
+		bcc.AppendIRI(mustParse(testFederatedActorIRI))
+		bcc.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsBcc(bcc)
+		expectAct := baseActivityFn() // Ensure Bcc & Bto are stripped
+		expectAct.SetActivityStreamsTo(to)
+		expectAct.SetActivityStreamsCc(cc)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil).Times(4)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil).Times(4)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(expectAct), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("StripsBtoOnObject", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		bto := streams.NewActivityStreamsBtoProperty()
+		bto.AppendIRI(mustParse(testFederatedActorIRI))
+		bto.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsBto(bto)
+		act.GetActivityStreamsObject().At(0).GetActivityStreamsNote().SetActivityStreamsBto(bto)
+		expectAct := baseActivityFn() // Ensure Bto is stripped
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
 			mustSerializeToBytes(testFederatedPerson2), nil)
@@ -1650,6 +2302,190 @@ func TestDeliver(t *testing.T) {
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, expectErr)
 	})
+	t.Run("EnqueuesWithDeliveryQueue", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		mockQueue := NewMockDeliveryQueue(ctl)
+		provider := NewMockDeliveryQueueProvider(ctl)
+		a.(*sideEffectActor).s2s = deliveryQueueFederatingProtocol{mockFp, provider}
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		provider.EXPECT().DeliveryQueue(ctx).Return(mockQueue)
+		mockQueue.EXPECT().Enqueue(ctx, mustParse(testMyOutboxIRI), mustSerializeToBytes(act), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("SignsPublicActivityWithLDSigner", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		provider := NewMockLDSignerProvider(ctl)
+		a.(*sideEffectActor).s2s = ldSignerFederatingProtocol{mockFp, provider}
+		mockTp := NewMockTransport(ctl)
+		key := mustGenerateRSAKey()
+		keyId := testPersonIRI + "#main-key"
+		signer := NewRsaSignature2017Signer(&fixedClock{now()}, nil, mustParse(keyId), key)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(PublicActivityPubIRI))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+		}
+		var delivered []byte
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		provider.EXPECT().LDSigner(ctx, mustParse(testMyOutboxIRI)).Return(signer, nil)
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, gomock.Any(), expectRecip).DoAndReturn(func(c context.Context, b []byte, recipients []*url.URL) error {
+			delivered = b
+			return nil
+		})
+		mockTp.EXPECT().Dereference(ctx, mustParse(keyId)).Return(
+			mustSerializeToBytes(newPersonWithKey(testPersonIRI, keyId, testPersonIRI, &key.PublicKey)), nil)
+		// Run
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		// Verify
+		assertEqual(t, err, nil)
+		var m map[string]interface{}
+		assertEqual(t, json.Unmarshal(delivered, &m), nil)
+		ldSigner, verified, err := NewRsaSignature2017Verifier(nil).VerifyLD(ctx, m, mockTp)
+		assertEqual(t, err, nil)
+		assertEqual(t, verified, true)
+		assertEqual(t, ldSigner.String(), testPersonIRI)
+	})
+	t.Run("DoesNotSignNonPublicActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		provider := NewMockLDSignerProvider(ctl)
+		a.(*sideEffectActor).s2s = ldSignerFederatingProtocol{mockFp, provider}
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+}
+
+// TestForwardReport ensures reports are only forwarded from the ModerationQueue
+// of a FederatingProtocol implementing ModerationQueueProvider.
+func TestForwardReport(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (fp *MockFederatingProtocol, a DelegateActor) {
+		setupData()
+		fp = NewMockFederatingProtocol(ctl)
+		a = &sideEffectActor{
+			common: NewMockCommonBehavior(ctl),
+			s2s:    fp,
+			db:     NewMockDatabase(ctl),
+			clock:  NewMockClock(ctl),
+		}
+		return
+	}
+	t.Run("ErrorWithoutModerationQueueProvider", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockFp, a := setupFn(ctl)
+		// Mock
+		mockFp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		// Run
+		err := a.ForwardReport(ctx, mustParse(testMyInboxIRI), mustParse(testFederatedActivityIRI))
+		// Verify
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("ObtainsReportFromModerationQueueProvider", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockFp, a := setupFn(ctl)
+		provider := NewMockModerationQueueProvider(ctl)
+		a.(*sideEffectActor).s2s = moderationQueueFederatingProtocol{mockFp, provider}
+		q := NewMemoryModerationQueue()
+		err := q.AddReport(ctx, Report{
+			Id:    mustParse(testFederatedActivityIRI),
+			Local: []*url.URL{mustParse(testNoteId1)},
+		})
+		assertEqual(t, err, nil)
+		// Mock
+		mockFp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		provider.EXPECT().ModerationQueue(ctx).Return(q)
+		// Run
+		err = a.ForwardReport(ctx, mustParse(testMyInboxIRI), mustParse(testFederatedActivityIRI))
+		// Verify
+		if err == nil {
+			t.Fatalf("expected error for report without remote objects, got none")
+		}
+	})
 }
 
 // TestWrapInCreate ensures an object received by the Social Protocol is
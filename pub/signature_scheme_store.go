@@ -0,0 +1,78 @@
+package pub
+
+import (
+	"context"
+	"sync"
+)
+
+// HttpSigScheme is a scheme for signing HTTP requests.
+type HttpSigScheme string
+
+const (
+	// DraftCavageHttpSig is the draft-cavage HTTP Signatures scheme, which
+	// most of the fediverse uses.
+	DraftCavageHttpSig HttpSigScheme = "draft-cavage"
+	// RFC9421HttpSig is the RFC 9421 HTTP Message Signatures scheme.
+	RFC9421HttpSig HttpSigScheme = "rfc9421"
+)
+
+// other returns the scheme to retry with when this one is rejected.
+func (s HttpSigScheme) other() HttpSigScheme {
+	if s == RFC9421HttpSig {
+		return DraftCavageHttpSig
+	}
+	return RFC9421HttpSig
+}
+
+// SignatureSchemeStore remembers the HttpSigScheme each peer host prefers, so
+// that a Transport created with NewDoubleKnockHttpSigTransport signs with it
+// first.
+//
+// It must be safe to use concurrently.
+type SignatureSchemeStore interface {
+	// PreferredScheme returns the scheme preferred by the host, and false
+	// if it is not known.
+	PreferredScheme(c context.Context, host string) (scheme HttpSigScheme, ok bool, err error)
+	// SetPreferredScheme records the scheme preferred by the host.
+	SetPreferredScheme(c context.Context, host string, scheme HttpSigScheme) error
+}
+
+// SignatureSchemeStore must be implemented by MemorySignatureSchemeStore.
+var _ SignatureSchemeStore = &MemorySignatureSchemeStore{}
+
+// MemorySignatureSchemeStore is a SignatureSchemeStore that keeps preferences
+// in memory.
+//
+// Preferences are lost when the process exits, which only costs an extra
+// request to each peer preferring the non-default scheme.
+//
+// It is safe to use concurrently.
+type MemorySignatureSchemeStore struct {
+	mu      *sync.RWMutex
+	schemes map[string]HttpSigScheme
+}
+
+// NewMemorySignatureSchemeStore returns a new, empty MemorySignatureSchemeStore.
+func NewMemorySignatureSchemeStore() *MemorySignatureSchemeStore {
+	return &MemorySignatureSchemeStore{
+		mu:      &sync.RWMutex{},
+		schemes: make(map[string]HttpSigScheme),
+	}
+}
+
+// PreferredScheme returns the scheme preferred by the host, and false if it is
+// not known.
+func (m *MemorySignatureSchemeStore) PreferredScheme(c context.Context, host string) (HttpSigScheme, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	scheme, ok := m.schemes[host]
+	return scheme, ok, nil
+}
+
+// SetPreferredScheme records the scheme preferred by the host.
+func (m *MemorySignatureSchemeStore) SetPreferredScheme(c context.Context, host string, scheme HttpSigScheme) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schemes[host] = scheme
+	return nil
+}
@@ -69,20 +69,35 @@ type SocialWrappedCallbacks struct {
 	// It enforces that the actors on the Undo must correspond to all of the
 	// 'object' actors in some manner.
 	//
+	// It also reverses the side effects of Follow, Like, and Block: the
+	// objects of an undone Follow are removed from the "following"
+	// collection of this actor, the objects of an undone Like from its
+	// "liked" collection, and an undone Block is removed from the
+	// BlockStore.
+	//
 	// It is expected that the application will implement the proper
-	// reversal of activities that are being undone.
+	// reversal of any other activities that are being undone.
 	Undo func(context.Context, vocab.ActivityStreamsUndo) error
 	// Block handles additional side effects for the Block ActivityStreams
 	// type.
 	//
-	// The wrapping callback only ensures the 'Block' has at least one
-	// 'object' entry, but otherwise has no default side effect. It is up
-	// to the wrapped application function to properly enforce the new
-	// blocking behavior.
+	// The wrapping callback ensures the 'Block' has at least one 'object'
+	// entry. It then removes the blocked actors from the "followers" and
+	// "following" collections of this actor, and records the Block in the
+	// BlockStore if the FederatingProtocol provides one. It is up to the
+	// wrapped application function to enforce any other blocking
+	// behavior.
 	//
 	// Note that go-fed does not federate 'Block' activities received in the
 	// Social Protocol.
 	Block func(context.Context, vocab.ActivityStreamsBlock) error
+	// Move handles additional side effects for the Move ActivityStreams
+	// type.
+	//
+	// The wrapping callback ensures the 'Move' has at least one 'object'
+	// and 'target' entry. It then addresses the 'Move' to the "followers"
+	// collection of this actor, so that it is delivered to all of them.
+	Move func(context.Context, vocab.ActivityStreamsMove) error
 
 	// Sidechannel data -- this is set at request handling time. These must
 	// be set before the callbacks are used.
@@ -99,6 +114,9 @@ type SocialWrappedCallbacks struct {
 	clock Clock
 	// newTransport creates a new Transport.
 	newTransport func(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (t Transport, err error)
+	// blockStore obtains the BlockStore, which is nil if Blocks are not
+	// recorded.
+	blockStore func(c context.Context) BlockStore
 	// undeliverable is a sidechannel out, indicating if the handled activity
 	// should not be delivered to a peer.
 	//
@@ -122,6 +140,7 @@ func (w SocialWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 	enableLike := true
 	enableUndo := true
 	enableBlock := true
+	enableMove := true
 	for _, fn := range fns {
 		switch fn.(type) {
 		default:
@@ -144,6 +163,8 @@ func (w SocialWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 			enableUndo = false
 		case func(context.Context, vocab.ActivityStreamsBlock) error:
 			enableBlock = false
+		case func(context.Context, vocab.ActivityStreamsMove) error:
+			enableMove = false
 		}
 	}
 	if enableCreate {
@@ -173,6 +194,9 @@ func (w SocialWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 	if enableBlock {
 		fns = append(fns, w.block)
 	}
+	if enableMove {
+		fns = append(fns, w.move)
+	}
 	return fns
 }
 
@@ -508,15 +532,73 @@ func (w SocialWrappedCallbacks) undo(c context.Context, a vocab.ActivityStreamsU
 		return ErrObjectRequired
 	}
 	actors := a.GetActivityStreamsActor()
-	if err := mustHaveActivityActorsMatchObjectActors(c, actors, op, w.newTransport, w.outboxIRI); err != nil {
+	objects, err := mustHaveActivityActorsMatchObjectActors(c, actors, op, w.newTransport, w.outboxIRI)
+	if err != nil {
 		return err
 	}
+	for _, t := range objects {
+		if streams.IsOrExtendsActivityStreamsFollow(t) {
+			err = w.removeObjectsFromActorCollection(c, t, w.db.Following)
+		} else if streams.IsOrExtendsActivityStreamsLike(t) {
+			err = w.removeObjectsFromActorCollection(c, t, w.db.Liked)
+		} else if streams.IsOrExtendsActivityStreamsBlock(t) {
+			err = w.removeBlocks(c, t)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	if w.Undo != nil {
 		return w.Undo(c, a)
 	}
 	return nil
 }
 
+// removeObjectsFromActorCollection removes the 'object' values of the activity
+// from a collection of this actor, such as 'following' or 'liked'.
+func (w SocialWrappedCallbacks) removeObjectsFromActorCollection(c context.Context, activity vocab.Type, getCollection func(c context.Context, actorIRI *url.URL) (vocab.ActivityStreamsCollection, error)) error {
+	o, ok := activity.(objecter)
+	if !ok {
+		return fmt.Errorf("cannot undo %T: no 'object' property", activity)
+	}
+	op := o.GetActivityStreamsObject()
+	if op == nil {
+		return nil
+	}
+	ids := make(map[string]bool, op.Len())
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		ids[id.String()] = true
+	}
+	// Get this actor's IRI.
+	if err := w.db.Lock(c, w.outboxIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	actorIRI, err := w.db.ActorForOutbox(c, w.outboxIRI)
+	if err != nil {
+		w.db.Unlock(c, w.outboxIRI)
+		return err
+	}
+	w.db.Unlock(c, w.outboxIRI)
+	// Unlock must be called by now and every branch above.
+	if err := w.db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, actorIRI)
+	col, err := getCollection(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	if err = removeFromCollection(col, ids); err != nil {
+		return err
+	}
+	return w.db.Update(c, col)
+}
+
 // block implements the social Block activity side effects.
 func (w SocialWrappedCallbacks) block(c context.Context, a vocab.ActivityStreamsBlock) error {
 	*w.undeliverable = true
@@ -524,8 +606,156 @@ func (w SocialWrappedCallbacks) block(c context.Context, a vocab.ActivityStreams
 	if op == nil || op.Len() == 0 {
 		return ErrObjectRequired
 	}
+	// Sever the follow relationships in both directions.
+	if err := w.removeObjectsFromActorCollection(c, a, w.db.Followers); err != nil {
+		return err
+	}
+	if err := w.removeObjectsFromActorCollection(c, a, w.db.Following); err != nil {
+		return err
+	}
+	if store := w.getBlockStore(c); store != nil {
+		ids, err := objectIds(a)
+		if err != nil {
+			return err
+		}
+		actorIRI, err := w.actorForOutbox(c)
+		if err != nil {
+			return err
+		}
+		if err = store.AddBlocks(c, actorIRI, ids); err != nil {
+			return err
+		}
+	}
 	if w.Block != nil {
 		return w.Block(c, a)
 	}
 	return nil
 }
+
+// move implements the social Move activity side effects.
+func (w SocialWrappedCallbacks) move(c context.Context, a vocab.ActivityStreamsMove) error {
+	*w.undeliverable = false
+	op := a.GetActivityStreamsObject()
+	if op == nil || op.Len() == 0 {
+		return ErrObjectRequired
+	}
+	tp := a.GetActivityStreamsTarget()
+	if tp == nil || tp.Len() == 0 {
+		return ErrTargetRequired
+	}
+	actorIRI, err := w.actorForOutbox(c)
+	if err != nil {
+		return err
+	}
+	// Address the Move to all of this actor's followers.
+	if err = w.db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	// WARNING: Unlock not deferred.
+	followers, err := w.db.Followers(c, actorIRI)
+	if err != nil {
+		w.db.Unlock(c, actorIRI)
+		return err
+	}
+	w.db.Unlock(c, actorIRI)
+	// Unlock must be called by now and every branch above.
+	followersIRI, err := GetId(followers)
+	if err != nil {
+		return err
+	}
+	if err = addRecipient(a, followersIRI); err != nil {
+		return err
+	}
+	if w.Move != nil {
+		return w.Move(c, a)
+	}
+	return nil
+}
+
+// addRecipient adds the IRI to the 'to' property of the activity, unless it is
+// already one of its 'to' or 'cc' recipients.
+func addRecipient(a Activity, iri *url.URL) error {
+	to := a.GetActivityStreamsTo()
+	if to == nil {
+		to = streams.NewActivityStreamsToProperty()
+		a.SetActivityStreamsTo(to)
+	}
+	for iter := to.Begin(); iter != to.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		if id.String() == iri.String() {
+			return nil
+		}
+	}
+	if cc := a.GetActivityStreamsCc(); cc != nil {
+		for iter := cc.Begin(); iter != cc.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return err
+			}
+			if id.String() == iri.String() {
+				return nil
+			}
+		}
+	}
+	to.AppendIRI(iri)
+	return nil
+}
+
+// actorForOutbox obtains the IRI of the actor owning the outbox.
+func (w SocialWrappedCallbacks) actorForOutbox(c context.Context) (*url.URL, error) {
+	if err := w.db.Lock(c, w.outboxIRI); err != nil {
+		return nil, err
+	}
+	defer w.db.Unlock(c, w.outboxIRI)
+	return w.db.ActorForOutbox(c, w.outboxIRI)
+}
+
+// removeBlocks removes the 'object' values of the undone Block from the
+// BlockStore, if there is one.
+func (w SocialWrappedCallbacks) removeBlocks(c context.Context, activity vocab.Type) error {
+	store := w.getBlockStore(c)
+	if store == nil {
+		return nil
+	}
+	o, ok := activity.(objecter)
+	if !ok {
+		return fmt.Errorf("cannot undo %T: no 'object' property", activity)
+	}
+	ids, err := objectIds(o)
+	if err != nil {
+		return err
+	}
+	actorIRI, err := w.actorForOutbox(c)
+	if err != nil {
+		return err
+	}
+	return store.RemoveBlocks(c, actorIRI, ids)
+}
+
+// getBlockStore returns the BlockStore, or nil if Blocks are not recorded.
+func (w SocialWrappedCallbacks) getBlockStore(c context.Context) BlockStore {
+	if w.blockStore == nil {
+		return nil
+	}
+	return w.blockStore(c)
+}
+
+// objectIds returns the ids of the values of the 'object' property.
+func objectIds(o objecter) ([]*url.URL, error) {
+	op := o.GetActivityStreamsObject()
+	if op == nil {
+		return nil, nil
+	}
+	ids := make([]*url.URL, 0, op.Len())
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
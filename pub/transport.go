@@ -49,15 +49,35 @@ type Transport interface {
 	BatchDeliver(c context.Context, b []byte, recipients []*url.URL) error
 }
 
-// Transport must be implemented by HttpSigTransport.
+// ConditionalTransport is a Transport that is also able to send conditional
+// GET requests and report the response headers, so that dereferenced values
+// may be cached and later revalidated.
+type ConditionalTransport interface {
+	Transport
+	// ConditionalDereference fetches the ActivityStreams object located at
+	// this IRI with a GET request that has the additional request headers,
+	// such as If-None-Match or If-Modified-Since.
+	//
+	// If the peer responds that the object is not modified, then
+	// notModified is true, the error is nil and there is no content.
+	// Otherwise the response headers are returned along with the content.
+	ConditionalDereference(c context.Context, iri *url.URL, reqHeader http.Header) (b []byte, respHeader http.Header, notModified bool, err error)
+}
+
+// Transport and ConditionalTransport must be implemented by HttpSigTransport.
 var _ Transport = &HttpSigTransport{}
+var _ ConditionalTransport = &HttpSigTransport{}
 
 // HttpSigTransport makes a dereference call using HTTP signatures to
 // authenticate the request on behalf of a particular actor.
 //
 // No rate limiting is applied.
 //
-// Only one request is tried per call.
+// Only one request is tried per call, unless it was created with
+// NewDoubleKnockHttpSigTransport and the peer rejects the first signature.
+//
+// Deliveries include both the Digest and Content-Digest headers of the body
+// using SHA-256.
 type HttpSigTransport struct {
 	client       HttpClient
 	appAgent     string
@@ -69,6 +89,18 @@ type HttpSigTransport struct {
 	postSignerMu *sync.Mutex
 	pubKeyId     string
 	privKey      crypto.PrivateKey
+	knock        *doubleKnock
+}
+
+// doubleKnock is what a HttpSigTransport needs to retry a rejected request
+// signed with one HttpSigScheme using the other.
+type doubleKnock struct {
+	preferred    HttpSigScheme
+	store        SignatureSchemeStore
+	getSigner    httpsig.Signer
+	getSignerMu  *sync.Mutex
+	postSigner   httpsig.Signer
+	postSignerMu *sync.Mutex
 }
 
 // NewHttpSigTransport returns a new Transport.
@@ -86,6 +118,9 @@ type HttpSigTransport struct {
 // agent string will also include one for go-fed, so at minimum peer servers can
 // reach out to the go-fed library to aid in notifying implementors of malformed
 // or unsupported requests.
+//
+// The httpsig package's signers only support RSA and HMAC keys. Actors with
+// Ed25519 keys must use a Hs2019Signer or HttpMessageSigner instead.
 func NewHttpSigTransport(
 	client HttpClient,
 	appAgent string,
@@ -107,55 +142,111 @@ func NewHttpSigTransport(
 	}
 }
 
+// NewDoubleKnockHttpSigTransport returns a new Transport that signs requests
+// with either draft-cavage HTTP Signatures or RFC 9421 HTTP Message
+// Signatures, retrying a request rejected by the peer with the other scheme.
+//
+// The getSigner and postSigner create draft-cavage signatures as they do for
+// NewHttpSigTransport. The RFC 9421 signatures use the algorithm, or one
+// determined from the private key if it is empty, and cover the method,
+// target URI and, for deliveries, the Content-Digest of the request.
+//
+// Requests are first signed with the preferred scheme, unless the store knows
+// that the peer prefers the other one. A retried request is a new request,
+// including a new body for deliveries.
+func NewDoubleKnockHttpSigTransport(
+	client HttpClient,
+	appAgent string,
+	clock Clock,
+	getSigner, postSigner httpsig.Signer,
+	algo MessageSignatureAlgorithm,
+	preferred HttpSigScheme,
+	store SignatureSchemeStore,
+	pubKeyId string,
+	privKey crypto.PrivateKey) *HttpSigTransport {
+	t := NewHttpSigTransport(client, appAgent, clock, getSigner, postSigner, pubKeyId, privKey)
+	t.knock = &doubleKnock{
+		preferred:    preferred,
+		store:        store,
+		getSigner:    NewHttpMessageSigner(clock, algo, []string{"@method", "@target-uri"}),
+		getSignerMu:  &sync.Mutex{},
+		postSigner:   NewHttpMessageSigner(clock, algo, []string{"@method", "@target-uri", "content-digest"}),
+		postSignerMu: &sync.Mutex{},
+	}
+	return t
+}
+
 // Dereference sends a GET request signed with an HTTP Signature to obtain an
 // ActivityStreams value.
 func (h HttpSigTransport) Dereference(c context.Context, iri *url.URL) ([]byte, error) {
-	req, err := http.NewRequest("GET", iri.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(c)
-	req.Header.Add(acceptHeader, acceptHeaderValue)
-	req.Header.Add("Accept-Charset", "utf-8")
-	req.Header.Add("Date", h.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
-	req.Header.Add("User-Agent", fmt.Sprintf("%s %s", h.appAgent, h.gofedAgent))
-	req.Header.Set("Host", iri.Host)
-	h.getSignerMu.Lock()
-	err = h.getSigner.SignRequest(h.privKey, h.pubKeyId, req, nil)
-	h.getSignerMu.Unlock()
-	if err != nil {
-		return nil, err
-	}
-	resp, err := h.client.Do(req)
+	b, _, _, err := h.dereference(c, iri, nil)
+	return b, err
+}
+
+// ConditionalDereference sends a GET request signed with an HTTP Signature
+// with the additional request headers, such as If-None-Match, to obtain an
+// ActivityStreams value.
+func (h HttpSigTransport) ConditionalDereference(c context.Context, iri *url.URL, reqHeader http.Header) ([]byte, http.Header, bool, error) {
+	return h.dereference(c, iri, reqHeader)
+}
+
+// dereference sends a GET request signed with an HTTP Signature, adding the
+// extra request headers. A http.StatusNotModified response is only permitted
+// when extra headers are given.
+func (h HttpSigTransport) dereference(c context.Context, iri *url.URL, reqHeader http.Header) (b []byte, respHeader http.Header, notModified bool, err error) {
+	var resp *http.Response
+	resp, err = h.send(c, iri, true, func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", iri.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(c)
+		req.Header.Add(acceptHeader, acceptHeaderValue)
+		req.Header.Add("Accept-Charset", "utf-8")
+		req.Header.Add("Date", h.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
+		req.Header.Add("User-Agent", fmt.Sprintf("%s %s", h.appAgent, h.gofedAgent))
+		req.Header.Set("Host", iri.Host)
+		for k, v := range reqHeader {
+			req.Header[k] = v
+		}
+		return req, nil
+	})
 	if err != nil {
-		return nil, err
+		return
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GET request to %s failed (%d): %s", iri.String(), resp.StatusCode, resp.Status)
+	respHeader = resp.Header
+	if resp.StatusCode == http.StatusNotModified && len(reqHeader) > 0 {
+		notModified = true
+		return
+	} else if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("GET request to %s failed (%d): %s", iri.String(), resp.StatusCode, resp.Status)
+		return
 	}
-	return ioutil.ReadAll(resp.Body)
+	b, err = ioutil.ReadAll(resp.Body)
+	return
 }
 
 // Deliver sends a POST request with an HTTP Signature.
 func (h HttpSigTransport) Deliver(c context.Context, b []byte, to *url.URL) error {
-	req, err := http.NewRequest("POST", to.String(), bytes.NewReader(b))
-	if err != nil {
-		return err
-	}
-	req = req.WithContext(c)
-	req.Header.Add(contentTypeHeader, contentTypeHeaderValue)
-	req.Header.Add("Accept-Charset", "utf-8")
-	req.Header.Add("Date", h.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
-	req.Header.Add("User-Agent", fmt.Sprintf("%s %s", h.appAgent, h.gofedAgent))
-	req.Header.Set("Host", to.Host)
-	h.postSignerMu.Lock()
-	err = h.postSigner.SignRequest(h.privKey, h.pubKeyId, req, b)
-	h.postSignerMu.Unlock()
-	if err != nil {
-		return err
-	}
-	resp, err := h.client.Do(req)
+	resp, err := h.send(c, to, false, func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", to.String(), bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(c)
+		req.Header.Add(contentTypeHeader, contentTypeHeaderValue)
+		req.Header.Add("Accept-Charset", "utf-8")
+		req.Header.Add("Date", h.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
+		req.Header.Add("User-Agent", fmt.Sprintf("%s %s", h.appAgent, h.gofedAgent))
+		req.Header.Set("Host", to.Host)
+		// Set the Digest and Content-Digest headers here rather than
+		// have the signer compute the Digest, so both are present for
+		// the peer to verify. The signer will still sign the headers
+		// if configured to.
+		setDigestHeaders(req.Header, DigestSha256, b)
+		return req, nil
+	})
 	if err != nil {
 		return err
 	}
@@ -166,6 +257,78 @@ func (h HttpSigTransport) Deliver(c context.Context, b []byte, to *url.URL) erro
 	return nil
 }
 
+// send signs and sends the request built by newReq.
+//
+// When double-knocking, the request is first signed with the scheme the peer
+// is known to prefer. If the peer rejects the signature, a new request is
+// built and signed with the other scheme. The scheme the peer accepts, or the
+// RFC 9421 scheme if the peer advertises it with an Accept-Signature header,
+// is remembered for the next request to the peer.
+func (h HttpSigTransport) send(c context.Context, to *url.URL, isGet bool, newReq func() (*http.Request, error)) (*http.Response, error) {
+	schemes := []HttpSigScheme{DraftCavageHttpSig}
+	if h.knock != nil {
+		preferred, ok, err := h.knock.store.PreferredScheme(c, to.Host)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			preferred = h.knock.preferred
+		}
+		schemes = []HttpSigScheme{preferred, preferred.other()}
+	}
+	for i, scheme := range schemes {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		if err = h.sign(scheme, isGet, req); err != nil {
+			return nil, err
+		}
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return nil, err
+		} else if h.knock == nil {
+			return resp, nil
+		}
+		rejected := resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden
+		if rejected && i+1 < len(schemes) {
+			resp.Body.Close()
+			continue
+		}
+		var remember HttpSigScheme
+		if len(resp.Header.Get(acceptSignatureHeader)) > 0 {
+			remember = RFC9421HttpSig
+		} else if !rejected {
+			remember = scheme
+		}
+		if len(remember) > 0 && remember != schemes[0] {
+			if err = h.knock.store.SetPreferredScheme(c, to.Host, remember); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+	// Unreachable, as the last scheme always returns.
+	return nil, fmt.Errorf("no signature scheme for request to %s", to)
+}
+
+// sign signs the request with the GET or POST signer of the scheme.
+func (h HttpSigTransport) sign(scheme HttpSigScheme, isGet bool, req *http.Request) error {
+	signer, mu := h.postSigner, h.postSignerMu
+	if isGet {
+		signer, mu = h.getSigner, h.getSignerMu
+	}
+	if scheme == RFC9421HttpSig && h.knock != nil {
+		signer, mu = h.knock.postSigner, h.knock.postSignerMu
+		if isGet {
+			signer, mu = h.knock.getSigner, h.knock.getSignerMu
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return signer.SignRequest(h.privKey, h.pubKeyId, req, nil)
+}
+
 // BatchDeliver sends concurrent POST requests. Returns an error if any of the
 // requests had an error.
 func (h HttpSigTransport) BatchDeliver(c context.Context, b []byte, recipients []*url.URL) error {
@@ -199,6 +362,9 @@ outer:
 
 // HttpClient sends http requests, and is an abstraction only needed by the
 // HttpSigTransport. The standard library's Client satisfies this interface.
+//
+// Since peers control the IRIs that are dereferenced, applications should use
+// a SafeHttpClient or a client with similar protections in production.
 type HttpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
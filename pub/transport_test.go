@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/go-fed/httpsig"
 	"github.com/golang/mock/gomock"
 )
 
@@ -66,6 +67,7 @@ func TestHttpSigTransportDereference(t *testing.T) {
 		expectReq.Header.Add("Accept-Charset", "utf-8")
 		expectReq.Header.Add("Date", nowDateHeader())
 		expectReq.Header.Add("User-Agent", fmt.Sprintf("%s %s", testAppAgent, goFedUserAgent()))
+		expectReq.Header.Set("Host", mustParse(testNoteId1).Host)
 		respR := httptest.NewRecorder()
 		respR.Write(testRespBody)
 		resp := respR.Result()
@@ -78,6 +80,152 @@ func TestHttpSigTransportDereference(t *testing.T) {
 		assertByteEqual(t, b, testRespBody)
 		assertEqual(t, err, nil)
 	})
+	t.Run("ConditionallyDereferencesNotModified", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, gs, _ := httpSigSetupFn(ctl)
+		reqHeader := http.Header{}
+		reqHeader.Set(ifNoneMatchHeader, "\"1\"")
+		expectReq, err := http.NewRequest("GET", testNoteId1, nil)
+		assertEqual(t, err, nil)
+		expectReq = expectReq.WithContext(ctx)
+		expectReq.Header.Add(acceptHeader, acceptHeaderValue)
+		expectReq.Header.Add("Accept-Charset", "utf-8")
+		expectReq.Header.Add("Date", nowDateHeader())
+		expectReq.Header.Add("User-Agent", fmt.Sprintf("%s %s", testAppAgent, goFedUserAgent()))
+		expectReq.Header.Set("Host", mustParse(testNoteId1).Host)
+		expectReq.Header.Set(ifNoneMatchHeader, "\"1\"")
+		respR := httptest.NewRecorder()
+		respR.Header().Set(etagHeader, "\"1\"")
+		respR.WriteHeader(http.StatusNotModified)
+		resp := respR.Result()
+		// Mock
+		c.EXPECT().Now().Return(now())
+		gs.EXPECT().SignRequest(testPrivKey, testPubKeyId, expectReq, nil)
+		hc.EXPECT().Do(expectReq).Return(resp, nil)
+		// Run & Verify
+		b, respHeader, notModified, err := tp.ConditionalDereference(ctx, mustParse(testNoteId1), reqHeader)
+		assertEqual(t, len(b), 0)
+		assertEqual(t, respHeader.Get(etagHeader), "\"1\"")
+		assertEqual(t, notModified, true)
+		assertEqual(t, err, nil)
+	})
+}
+
+func TestHttpSigTransportDoubleKnock(t *testing.T) {
+	ctx := context.Background()
+	key := mustGenerateRSAKey()
+	host := mustParse(testFederatedActorIRI).Host
+	setupFn := func(ctl *gomock.Controller, preferred HttpSigScheme) (tp *HttpSigTransport, hc *MockHttpClient, ps *MockSigner, store *MemorySignatureSchemeStore) {
+		c := NewMockClock(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		hc = NewMockHttpClient(ctl)
+		gs := NewMockSigner(ctl)
+		ps = NewMockSigner(ctl)
+		store = NewMemorySignatureSchemeStore()
+		tp = NewDoubleKnockHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			gs,
+			ps,
+			"",
+			preferred,
+			store,
+			testPubKeyId,
+			key)
+		return
+	}
+	respondFn := func(code int, h http.Header) func(*http.Request) (*http.Response, error) {
+		return func(*http.Request) (*http.Response, error) {
+			respR := httptest.NewRecorder()
+			for k, v := range h {
+				respR.Header()[k] = v
+			}
+			respR.WriteHeader(code)
+			return respR.Result(), nil
+		}
+	}
+	t.Run("RetriesRejectedDeliveryWithOtherScheme", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, hc, ps, store := setupFn(ctl, DraftCavageHttpSig)
+		var retried *http.Request
+		// Mock
+		ps.EXPECT().SignRequest(key, testPubKeyId, gomock.Any(), nil)
+		gomock.InOrder(
+			hc.EXPECT().Do(gomock.Any()).DoAndReturn(respondFn(http.StatusUnauthorized, nil)),
+			hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+				retried = r
+				return respondFn(http.StatusOK, nil)(r)
+			}),
+		)
+		// Run
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertNotEqual(t, retried.Header.Get(signatureInputHeader), "")
+		assertNotEqual(t, retried.Header.Get(contentDigestHeader), "")
+		scheme, ok, err := store.PreferredScheme(ctx, host)
+		assertEqual(t, err, nil)
+		assertEqual(t, ok, true)
+		assertEqual(t, scheme, RFC9421HttpSig)
+	})
+	t.Run("SignsWithStoredSchemeFirst", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, hc, _, store := setupFn(ctl, DraftCavageHttpSig)
+		assertEqual(t, store.SetPreferredScheme(ctx, host, RFC9421HttpSig), nil)
+		var sent *http.Request
+		// Mock
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			sent = r
+			return respondFn(http.StatusOK, nil)(r)
+		})
+		// Run
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertNotEqual(t, sent.Header.Get(signatureInputHeader), "")
+	})
+	t.Run("RemembersAdvertisedScheme", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, hc, ps, store := setupFn(ctl, DraftCavageHttpSig)
+		h := http.Header{}
+		h.Set(acceptSignatureHeader, "sig1=(\"@method\" \"@target-uri\")")
+		// Mock
+		ps.EXPECT().SignRequest(key, testPubKeyId, gomock.Any(), nil)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(respondFn(http.StatusAccepted, h))
+		// Run
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		scheme, ok, err := store.PreferredScheme(ctx, host)
+		assertEqual(t, err, nil)
+		assertEqual(t, ok, true)
+		assertEqual(t, scheme, RFC9421HttpSig)
+	})
+	t.Run("ReturnsErrorWhenBothSchemesRejected", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, hc, ps, store := setupFn(ctl, RFC9421HttpSig)
+		// Mock
+		ps.EXPECT().SignRequest(key, testPubKeyId, gomock.Any(), nil)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(respondFn(http.StatusUnauthorized, nil)).Times(2)
+		// Run
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		// Verify
+		assertNotEqual(t, err, nil)
+		_, ok, err := store.PreferredScheme(ctx, host)
+		assertEqual(t, err, nil)
+		assertEqual(t, ok, false)
+	})
 }
 
 func TestHttpSigTransportDeliver(t *testing.T) {
@@ -108,12 +256,40 @@ func TestHttpSigTransportDeliver(t *testing.T) {
 		resp := respR.Result()
 		// Mock
 		c.EXPECT().Now().Return(now())
-		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), testRespBody)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil)
 		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
 		// Run & Verify
 		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
 		assertEqual(t, err, nil)
 	})
+	t.Run("DeliversSignedWithEd25519Key", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		hc := NewMockHttpClient(ctl)
+		key := mustGenerateEd25519Key()
+		signer := NewHs2019Signer([]string{httpsig.RequestTarget, "host", "date", "digest"})
+		tp := NewHttpSigTransport(hc, testAppAgent, c, signer, signer, testPubKeyId, key)
+		respR := httptest.NewRecorder()
+		respR.WriteHeader(http.StatusOK)
+		resp := respR.Result()
+		var sent *http.Request
+		// Mock
+		c.EXPECT().Now().Return(now())
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			sent = r
+			return resp, nil
+		})
+		// Run
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		sig, err := parseCavageSignature(sent.Header)
+		assertEqual(t, err, nil)
+		assertEqual(t, sig.keyId, testPubKeyId)
+		assertEqual(t, sig.verify(sent, key.Public()), true)
+	})
 }
 
 func TestHttpSigTransportBatchDeliver(t *testing.T) {
@@ -129,7 +305,7 @@ func TestHttpSigTransportBatchDeliver(t *testing.T) {
 		resp := respR.Result()
 		// Mock
 		c.EXPECT().Now().Return(now()).Times(2)
-		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), testRespBody).Times(2)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(2)
 		hc.EXPECT().Do(gomock.Any()).Return(resp, nil).Times(2)
 		// Run & Verify
 		err := tp.BatchDeliver(ctx, testRespBody, []*url.URL{mustParse(testFederatedActorIRI), mustParse(testFederatedActorIRI2)})
@@ -148,7 +324,7 @@ func TestHttpSigTransportBatchDeliver(t *testing.T) {
 		testErr := fmt.Errorf("test error")
 		// Mock
 		c.EXPECT().Now().Return(now()).Times(2)
-		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), testRespBody).Times(2)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(2)
 		first := hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
 		hc.EXPECT().Do(gomock.Any()).Return(errResp, testErr).After(first)
 		// Run & Verify
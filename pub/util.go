@@ -1,10 +1,7 @@
 package pub
 
 import (
-	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,6 +22,19 @@ var (
 	// set. Can be returned by DelegateActor's PostInbox or PostOutbox so a
 	// Bad Request response is set.
 	ErrTargetRequired = errors.New("target property required on the provided activity")
+	// ErrPollClosed indicates a vote was received for a Question that is
+	// closed or has ended. Can be returned by DelegateActor's PostInbox so
+	// a Bad Request response is set.
+	ErrPollClosed = errors.New("vote received for a closed poll")
+	// ErrInvalidVote indicates a vote was received for an option a
+	// Question does not have, or on behalf of an actor other than the one
+	// that sent it. Can be returned by DelegateActor's PostInbox so a Bad
+	// Request response is set.
+	ErrInvalidVote = errors.New("invalid vote received for a poll")
+	// ErrBlocked indicates an activity was received from an actor blocked
+	// by the actor owning the inbox. Can be returned by DelegateActor's
+	// PostInbox so a Forbidden response is set.
+	ErrBlocked = errors.New("activity received from a blocked actor")
 )
 
 // activityStreamsMediaTypes contains all of the accepted ActivityStreams media
@@ -127,8 +137,6 @@ const (
 	digestHeader = "Digest"
 	// The delimiter used in the Digest header.
 	digestDelimiter = "="
-	// SHA-256 string for the Digest header.
-	sha256Digest = "SHA-256"
 )
 
 // addResponseHeaders sets headers needed in the HTTP response, such but not
@@ -138,12 +146,7 @@ func addResponseHeaders(h http.Header, c Clock, responseContent []byte) {
 	// RFC 7231 §7.1.1.2
 	h.Set(dateHeader, c.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
 	// RFC 3230 and RFC 5843
-	var b bytes.Buffer
-	b.WriteString(sha256Digest)
-	b.WriteString(digestDelimiter)
-	hashed := sha256.Sum256(responseContent)
-	b.WriteString(base64.StdEncoding.EncodeToString(hashed[:]))
-	h.Set(digestHeader, b.String())
+	h.Set(digestHeader, digestHeaderValue(DigestSha256, responseContent))
 }
 
 // IdProperty is a property that can readily have its id obtained
@@ -381,6 +384,70 @@ func getInbox(t vocab.Type) (u *url.URL, err error) {
 	return ToId(inbox)
 }
 
+const (
+	// The ActivityPub 'endpoints' property of an actor.
+	endpointsProperty = "endpoints"
+	// The 'sharedInbox' within the 'endpoints' of an actor.
+	sharedInboxProperty = "sharedInbox"
+)
+
+// getSharedInbox extracts the 'sharedInbox' IRI from the 'endpoints' of an
+// actor type, returning nil if it does not have one.
+//
+// The 'endpoints' property is not part of the ActivityStreams vocabulary, so
+// it is obtained from the actor's unknown properties.
+func getSharedInbox(t vocab.Type) *url.URL {
+	up, ok := t.(unknownPropertieser)
+	if !ok {
+		return nil
+	}
+	endpoints, ok := up.GetUnknownProperties()[endpointsProperty].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	s, ok := endpoints[sharedInboxProperty].(string)
+	if !ok {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() {
+		return nil
+	}
+	return u
+}
+
+// getSharedInboxes extracts the inbox IRIs to deliver to for actor types,
+// collapsing actors with the same 'sharedInbox' into a single delivery to it.
+//
+// An actor's 'sharedInbox' is used instead of its 'inbox' when it is shared
+// with another of the actors, or when it is one of the known shared inboxes
+// already being delivered to.
+func getSharedInboxes(t []vocab.Type, known []*url.URL) (u []*url.URL, err error) {
+	counts := make(map[string]int, len(t)+len(known))
+	for _, k := range known {
+		counts[k.String()] += 2
+	}
+	shared := make([]*url.URL, len(t))
+	for i, elem := range t {
+		if shared[i] = getSharedInbox(elem); shared[i] != nil {
+			counts[shared[i].String()]++
+		}
+	}
+	for i, elem := range t {
+		if shared[i] != nil && counts[shared[i].String()] > 1 {
+			u = append(u, shared[i])
+			continue
+		}
+		var iri *url.URL
+		iri, err = getInbox(elem)
+		if err != nil {
+			return
+		}
+		u = append(u, iri)
+	}
+	return
+}
+
 // dedupeIRIs will deduplicate final inbox IRIs. The ignore list is applied to
 // the final list.
 func dedupeIRIs(recipients, ignored []*url.URL) (out []*url.URL) {
@@ -738,58 +805,61 @@ func toTombstone(obj vocab.Type, id *url.URL, now time.Time) vocab.ActivityStrea
 
 // mustHaveActivityActorsMatchObjectActors ensures that the actors on types in
 // the 'object' property are all listed in the 'actor' property.
+//
+// Returns the dereferenced values of the 'object' property.
 func mustHaveActivityActorsMatchObjectActors(c context.Context,
 	actors vocab.ActivityStreamsActorProperty,
 	op vocab.ActivityStreamsObjectProperty,
 	newTransport func(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (t Transport, err error),
-	boxIRI *url.URL) error {
+	boxIRI *url.URL) (objects []vocab.Type, err error) {
 	activityActorMap := make(map[string]bool, actors.Len())
 	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
 		id, err := ToId(iter)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		activityActorMap[id.String()] = true
 	}
 	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
 		iri, err := ToId(iter)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		// Attempt to dereference the IRI, regardless whether it is a
 		// type or IRI
 		tport, err := newTransport(c, boxIRI, goFedUserAgent())
 		if err != nil {
-			return err
+			return nil, err
 		}
 		b, err := tport.Dereference(c, iri)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		var m map[string]interface{}
 		if err = json.Unmarshal(b, &m); err != nil {
-			return err
+			return nil, err
 		}
 		t, err := streams.ToType(c, m)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		ac, ok := t.(actorer)
 		if !ok {
-			return fmt.Errorf("cannot verify actors: object value has no 'actor' property")
+			return nil, fmt.Errorf("cannot verify actors: object value has no 'actor' property")
 		}
 		objActors := ac.GetActivityStreamsActor()
 		for iter := objActors.Begin(); iter != objActors.End(); iter = iter.Next() {
 			id, err := ToId(iter)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if !activityActorMap[id.String()] {
-				return fmt.Errorf("activity does not have all actors from its object's actors")
+				return nil, fmt.Errorf("activity does not have all actors from its object's actors")
 			}
 		}
+		objects = append(objects, t)
 	}
-	return nil
+	return
 }
 
 // add implements the logic of adding object ids to a target Collection or
@@ -911,47 +981,12 @@ func remove(c context.Context,
 		if err != nil {
 			return err
 		}
-		if streams.IsOrExtendsActivityStreamsOrderedCollection(tp) {
-			oi, ok := tp.(orderedItemser)
-			if !ok {
-				return fmt.Errorf("type extending from OrderedCollection cannot convert to orderedItemser interface")
-			}
-			oiProp := oi.GetActivityStreamsOrderedItems()
-			if oiProp != nil {
-				for i := 0; i < oiProp.Len(); /*Conditional*/ {
-					id, err := ToId(oiProp.At(i))
-					if err != nil {
-						return err
-					}
-					if opIds[id.String()] {
-						oiProp.Remove(i)
-					} else {
-						i++
-					}
-				}
-			}
-		} else if streams.IsOrExtendsActivityStreamsCollection(tp) {
-			i, ok := tp.(itemser)
-			if !ok {
-				return fmt.Errorf("type extending from Collection cannot convert to itemser interface")
-			}
-			iProp := i.GetActivityStreamsItems()
-			if iProp != nil {
-				for i := 0; i < iProp.Len(); /*Conditional*/ {
-					id, err := ToId(iProp.At(i))
-					if err != nil {
-						return err
-					}
-					if opIds[id.String()] {
-						iProp.Remove(i)
-					} else {
-						i++
-					}
-				}
-			}
-		} else {
+		if !streams.IsOrExtendsActivityStreamsOrderedCollection(tp) && !streams.IsOrExtendsActivityStreamsCollection(tp) {
 			return fmt.Errorf("target in Remove is neither a Collection nor an OrderedCollection")
 		}
+		if err = removeFromCollection(tp, opIds); err != nil {
+			return err
+		}
 		err = db.Update(c, tp)
 		if err != nil {
 			return err
@@ -966,6 +1001,101 @@ func remove(c context.Context,
 	return nil
 }
 
+// removeFromCollection removes the items with the given ids from a Collection
+// or OrderedCollection.
+func removeFromCollection(tp vocab.Type, ids map[string]bool) error {
+	if streams.IsOrExtendsActivityStreamsOrderedCollection(tp) {
+		oi, ok := tp.(orderedItemser)
+		if !ok {
+			return fmt.Errorf("type extending from OrderedCollection cannot convert to orderedItemser interface")
+		}
+		oiProp := oi.GetActivityStreamsOrderedItems()
+		if oiProp != nil {
+			for i := 0; i < oiProp.Len(); /*Conditional*/ {
+				id, err := ToId(oiProp.At(i))
+				if err != nil {
+					return err
+				}
+				if ids[id.String()] {
+					oiProp.Remove(i)
+				} else {
+					i++
+				}
+			}
+		}
+	} else if streams.IsOrExtendsActivityStreamsCollection(tp) {
+		i, ok := tp.(itemser)
+		if !ok {
+			return fmt.Errorf("type extending from Collection cannot convert to itemser interface")
+		}
+		iProp := i.GetActivityStreamsItems()
+		if iProp != nil {
+			for i := 0; i < iProp.Len(); /*Conditional*/ {
+				id, err := ToId(iProp.At(i))
+				if err != nil {
+					return err
+				}
+				if ids[id.String()] {
+					iProp.Remove(i)
+				} else {
+					i++
+				}
+			}
+		}
+	} else {
+		return fmt.Errorf("%T is neither a Collection nor an OrderedCollection", tp)
+	}
+	return nil
+}
+
+// prependToCollection prepends the id to the items of a Collection or
+// OrderedCollection.
+func prependToCollection(tp vocab.Type, id *url.URL) error {
+	if col, ok := tp.(itemser); ok {
+		items := col.GetActivityStreamsItems()
+		if items == nil {
+			items = streams.NewActivityStreamsItemsProperty()
+			col.SetActivityStreamsItems(items)
+		}
+		items.PrependIRI(id)
+	} else if oCol, ok := tp.(orderedItemser); ok {
+		oItems := oCol.GetActivityStreamsOrderedItems()
+		if oItems == nil {
+			oItems = streams.NewActivityStreamsOrderedItemsProperty()
+			oCol.SetActivityStreamsOrderedItems(oItems)
+		}
+		oItems.PrependIRI(id)
+	} else {
+		return fmt.Errorf("%T is neither a Collection nor an OrderedCollection", tp)
+	}
+	return nil
+}
+
+// setTotalItems sets the 'totalItems' of a Collection or OrderedCollection to
+// the number of its items.
+func setTotalItems(tp vocab.Type) error {
+	ti, ok := tp.(totalItemser)
+	if !ok {
+		return fmt.Errorf("%T has no totalItems property", tp)
+	}
+	n := 0
+	if col, ok := tp.(itemser); ok {
+		if items := col.GetActivityStreamsItems(); items != nil {
+			n = items.Len()
+		}
+	} else if oCol, ok := tp.(orderedItemser); ok {
+		if oItems := oCol.GetActivityStreamsOrderedItems(); oItems != nil {
+			n = oItems.Len()
+		}
+	} else {
+		return fmt.Errorf("%T is neither a Collection nor an OrderedCollection", tp)
+	}
+	totalItems := streams.NewActivityStreamsTotalItemsProperty()
+	totalItems.Set(n)
+	ti.SetActivityStreamsTotalItems(totalItems)
+	return nil
+}
+
 // clearSensitiveFields removes the 'bto' and 'bcc' entries on the given value
 // and recursively on every 'object' property value.
 func clearSensitiveFields(obj vocab.Type) {
@@ -993,3 +1123,14 @@ func requestId(r *http.Request, scheme string) *url.URL {
 	id.Scheme = scheme
 	return id
 }
+
+// collectionId returns the id of the inbox or outbox being requested, without
+// any query parameters selecting a page.
+func collectionId(r *http.Request, scheme string) *url.URL {
+	id := *r.URL
+	id.Host = r.Host
+	id.Scheme = scheme
+	id.RawQuery = ""
+	id.Fragment = ""
+	return &id
+}
@@ -0,0 +1,71 @@
+package pub
+
+import (
+	"context"
+	"net/url"
+	"sync"
+)
+
+// Vote is a vote received for an option of a local Question.
+type Vote struct {
+	// Voter is the actor that voted.
+	Voter *url.URL
+	// Option is the 'name' of the chosen option.
+	Option string
+}
+
+// VoteStore records who voted for which options of the local Questions, so
+// that the Questions themselves only need to hold the counts.
+//
+// It is obtained from the VoteStoreProvider's VoteStore method, and is updated
+// when a vote in a local Question is received. The lock for the Question is
+// held by the library while it is used.
+//
+// It must be safe to use concurrently.
+type VoteStore interface {
+	// AddVote records the vote in the Question.
+	AddVote(c context.Context, questionIRI *url.URL, v Vote) error
+	// Votes lists the votes in the Question, in the order they were
+	// added.
+	Votes(c context.Context, questionIRI *url.URL) ([]Vote, error)
+}
+
+// VoteStore must be implemented by MemoryVoteStore.
+var _ VoteStore = &MemoryVoteStore{}
+
+// MemoryVoteStore is a VoteStore that keeps votes in memory.
+//
+// Votes are lost when the process exits, so it is best suited for tests and
+// applications that persist votes elsewhere as well.
+//
+// It is safe to use concurrently.
+type MemoryVoteStore struct {
+	mu    *sync.RWMutex
+	votes map[string][]Vote
+}
+
+// NewMemoryVoteStore returns a new, empty MemoryVoteStore.
+func NewMemoryVoteStore() *MemoryVoteStore {
+	return &MemoryVoteStore{
+		mu:    &sync.RWMutex{},
+		votes: make(map[string][]Vote),
+	}
+}
+
+// AddVote records the vote in the Question.
+func (m *MemoryVoteStore) AddVote(c context.Context, questionIRI *url.URL, v Vote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.votes[questionIRI.String()] = append(m.votes[questionIRI.String()], v)
+	return nil
+}
+
+// Votes lists the votes in the Question, in the order they were added.
+func (m *MemoryVoteStore) Votes(c context.Context, questionIRI *url.URL) ([]Vote, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	votes := m.votes[questionIRI.String()]
+	out := make([]Vote, len(votes))
+	copy(out, votes)
+	return out, nil
+}
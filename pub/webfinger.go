@@ -0,0 +1,210 @@
+package pub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// WebFingerPath is the path at which WebFinger requests are served.
+	WebFingerPath = "/.well-known/webfinger"
+	// The media type of WebFinger responses.
+	jrdContentType = "application/jrd+json"
+	// The scheme of account handles.
+	acctScheme = "acct"
+	// The link relation of the actor in a WebFinger response.
+	selfRel = "self"
+	// The query parameter naming the resource being queried.
+	resourceQueryParam = "resource"
+	// The query parameter naming the link relations to return.
+	relQueryParam = "rel"
+)
+
+// WebFingerLookup maps account handles to the IRIs of local actors.
+type WebFingerLookup interface {
+	// ActorIRI returns the IRI of the actor with the given username on the
+	// given host.
+	//
+	// If there is no such actor, or the host is not served by this
+	// application, then a nil IRI and nil error must be returned.
+	ActorIRI(c context.Context, username, host string) (*url.URL, error)
+}
+
+// WebFingerLink is a link in a WebFinger response.
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href,omitempty"`
+}
+
+// WebFingerResource is the JSON Resource Descriptor returned in response to a
+// WebFinger request, as defined in RFC 7033.
+type WebFingerResource struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases,omitempty"`
+	Links   []WebFingerLink `json:"links,omitempty"`
+}
+
+// NewWebFingerHandler creates a HandlerFunc to serve WebFinger requests for
+// 'acct:' handles of local actors, as defined in RFC 7033.
+//
+// The response links to the actor IRI returned by the WebFingerLookup with
+// the 'self' relation. Requests without a 'resource' query parameter are
+// rejected with a 400 status code, and requests for anything other than a
+// known actor get a 404 status code.
+//
+// Requests to paths other than WebFingerPath are not handled, so 'isASRequest'
+// is false and nothing is written to the ResponseWriter.
+func NewWebFingerHandler(lookup WebFingerLookup) HandlerFunc {
+	return func(c context.Context, w http.ResponseWriter, r *http.Request) (isASRequest bool, err error) {
+		if r.Method != "GET" || r.URL.Path != WebFingerPath {
+			return
+		}
+		isASRequest = true
+		q := r.URL.Query()
+		resource := q.Get(resourceQueryParam)
+		if len(resource) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		username, host, perr := parseAcct(resource)
+		if perr != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		actorIRI, err := lookup.ActorIRI(c, username, host)
+		if err != nil {
+			return
+		} else if actorIRI == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		jrd := WebFingerResource{
+			Subject: fmt.Sprintf("%s:%s@%s", acctScheme, username, host),
+			Aliases: []string{actorIRI.String()},
+		}
+		self := WebFingerLink{
+			Rel:  selfRel,
+			Type: activityStreamsMediaTypes[0],
+			Href: actorIRI.String(),
+		}
+		if rels, ok := q[relQueryParam]; !ok || containsString(rels, selfRel) {
+			jrd.Links = append(jrd.Links, self)
+		}
+		raw, err := json.Marshal(jrd)
+		if err != nil {
+			return
+		}
+		w.Header().Set(contentTypeHeader, jrdContentType)
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.WriteHeader(http.StatusOK)
+		n, err := w.Write(raw)
+		if err != nil {
+			return
+		} else if n != len(raw) {
+			err = fmt.Errorf("only wrote %d of %d bytes", n, len(raw))
+			return
+		}
+		return
+	}
+}
+
+// ResolveAcct finds the IRI of the actor with the given handle by querying its
+// host with WebFinger through the Transport.
+//
+// The handle may be given as 'acct:user@host', 'user@host', or '@user@host'.
+func ResolveAcct(c context.Context, t Transport, handle string) (*url.URL, error) {
+	username, host, err := parseAcct(handle)
+	if err != nil {
+		return nil, err
+	}
+	q := make(url.Values, 1)
+	q.Set(resourceQueryParam, fmt.Sprintf("%s:%s@%s", acctScheme, username, host))
+	wfIRI := &url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     WebFingerPath,
+		RawQuery: q.Encode(),
+	}
+	b, err := t.Dereference(c, wfIRI)
+	if err != nil {
+		return nil, err
+	}
+	var jrd WebFingerResource
+	if err = json.Unmarshal(b, &jrd); err != nil {
+		return nil, err
+	}
+	for _, link := range jrd.Links {
+		if link.Rel != selfRel || !headerIsActivityPubMediaType(link.Type) {
+			continue
+		}
+		return url.Parse(link.Href)
+	}
+	return nil, fmt.Errorf("WebFinger response for %q has no ActivityStreams actor link", handle)
+}
+
+// ResolveAccts finds the IRIs of the actors with the given handles, so they
+// may be used as recipients of an activity.
+func ResolveAccts(c context.Context, t Transport, handles []string) ([]*url.URL, error) {
+	iris := make([]*url.URL, 0, len(handles))
+	for _, handle := range handles {
+		iri, err := ResolveAcct(c, t, handle)
+		if err != nil {
+			return nil, err
+		}
+		iris = append(iris, iri)
+	}
+	return iris, nil
+}
+
+// DereferenceAcct finds the actor with the given handle with WebFinger and
+// obtains its ActivityStreams representation through the Transport.
+func DereferenceAcct(c context.Context, t Transport, handle string) (vocab.Type, error) {
+	iri, err := ResolveAcct(c, t, handle)
+	if err != nil {
+		return nil, err
+	}
+	b, err := t.Dereference(c, iri)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return streams.ToType(c, m)
+}
+
+// parseAcct splits a handle of the form 'acct:user@host', 'user@host', or
+// '@user@host' into its username and host.
+func parseAcct(handle string) (username, host string, err error) {
+	s := strings.TrimPrefix(handle, acctScheme+":")
+	s = strings.TrimPrefix(s, "@")
+	i := strings.LastIndex(s, "@")
+	if i <= 0 || i == len(s)-1 {
+		err = fmt.Errorf("%q is not an account handle", handle)
+		return
+	}
+	username, host = s[:i], strings.ToLower(s[i+1:])
+	if strings.ContainsAny(host, "/?#@") {
+		err = fmt.Errorf("%q is not an account handle", handle)
+	}
+	return
+}
+
+// containsString determines whether the string is in the slice.
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
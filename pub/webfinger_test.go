@@ -0,0 +1,200 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testMyActorIRI      = "https://example.com/addison"
+	testWebFingerIRI    = "https://example.com/.well-known/webfinger?resource=acct%3Aaddison%40example.com"
+	testFederatedJRDIRI = "https://other.example.com/.well-known/webfinger?resource=acct%3Adakota%40other.example.com"
+	testWebFingerJRD    = `{"subject":"acct:addison@example.com","aliases":["https://example.com/addison"],"links":[{"rel":"self","type":"application/activity+json","href":"https://example.com/addison"}]}`
+	testFederatedJRD    = `{"subject":"acct:dakota@other.example.com","links":[{"rel":"http://webfinger.net/rel/profile-page","type":"text/html","href":"https://other.example.com/@dakota"},{"rel":"self","type":"application/activity+json","href":"https://other.example.com/dakota"}]}`
+)
+
+// TestWebFingerHandler tests serving WebFinger requests for local actors.
+func TestWebFingerHandler(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (l *MockWebFingerLookup, h HandlerFunc) {
+		l = NewMockWebFingerLookup(ctl)
+		h = NewWebFingerHandler(l)
+		return
+	}
+	t.Run("IgnoresOtherPaths", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", testPersonIRI, nil)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, false)
+	})
+	t.Run("RespondsWithActorLink", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", testWebFingerIRI, nil)
+		// Mock
+		l.EXPECT().ActorIRI(ctx, "addison", "example.com").Return(mustParse(testMyActorIRI), nil)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+		assertEqual(t, resp.Header().Get(contentTypeHeader), jrdContentType)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(testWebFingerJRD))
+	})
+	t.Run("FiltersLinksByRel", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", testWebFingerIRI+"&rel=http%3A%2F%2Fwebfinger.net%2Frel%2Favatar", nil)
+		// Mock
+		l.EXPECT().ActorIRI(ctx, "addison", "example.com").Return(mustParse(testMyActorIRI), nil)
+		// Run
+		_, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		b, err := ioutil.ReadAll(resp.Result().Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, []byte(`{"subject":"acct:addison@example.com","aliases":["https://example.com/addison"]}`))
+	})
+	t.Run("RejectsMissingResource", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "https://example.com"+WebFingerPath, nil)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
+	t.Run("NotFoundForUnknownActor", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", testWebFingerIRI, nil)
+		// Mock
+		l.EXPECT().ActorIRI(ctx, "addison", "example.com").Return(nil, nil)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusNotFound)
+	})
+	t.Run("NotFoundForNonAcctResource", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, h := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "https://example.com"+WebFingerPath+"?resource=https%3A%2F%2Fexample.com%2Faddison", nil)
+		// Run
+		handled, err := h(ctx, resp, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusNotFound)
+	})
+}
+
+// TestResolveAcct tests finding actors by their account handles.
+func TestResolveAcct(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ResolvesHandleForms", func(t *testing.T) {
+		for _, handle := range []string{
+			"acct:dakota@other.example.com",
+			"dakota@other.example.com",
+			"@dakota@Other.Example.com",
+		} {
+			// Setup
+			ctl := gomock.NewController(t)
+			tp := NewMockTransport(ctl)
+			// Mock
+			tp.EXPECT().Dereference(ctx, mustParse(testFederatedJRDIRI)).Return([]byte(testFederatedJRD), nil)
+			// Run
+			iri, err := ResolveAcct(ctx, tp, handle)
+			// Verify
+			assertEqual(t, err, nil)
+			assertEqual(t, iri.String(), testFederatedActorIRI)
+			ctl.Finish()
+		}
+	})
+	t.Run("RejectsInvalidHandle", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Run
+		_, err := ResolveAcct(ctx, tp, "https://other.example.com/dakota")
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorsWithoutActorLink", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedJRDIRI)).Return([]byte(`{"subject":"acct:dakota@other.example.com"}`), nil)
+		// Run
+		_, err := ResolveAcct(ctx, tp, "dakota@other.example.com")
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ReturnsTransportError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		testErr := fmt.Errorf("test error")
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedJRDIRI)).Return(nil, testErr)
+		// Run
+		_, err := ResolveAcct(ctx, tp, "dakota@other.example.com")
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("DereferencesActor", func(t *testing.T) {
+		// Setup
+		setupData()
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedJRDIRI)).Return([]byte(testFederatedJRD), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(mustSerializeToBytes(testFederatedPerson1), nil)
+		// Run
+		actor, err := DereferenceAcct(ctx, tp, "dakota@other.example.com")
+		// Verify
+		assertEqual(t, err, nil)
+		id, err := GetId(actor)
+		assertEqual(t, err, nil)
+		assertEqual(t, id.String(), testFederatedActorIRI)
+	})
+}
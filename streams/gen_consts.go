@@ -104,6 +104,9 @@ var ActivityStreamsMentionName string = "Mention"
 // ActivityStreamsMoveName is the string literal of the name for the Move type in the ActivityStreams vocabulary.
 var ActivityStreamsMoveName string = "Move"
 
+// W3IDSecurityDataIntegrityV1MultikeyName is the string literal of the name for the Multikey type in the W3IDSecurityDataIntegrityV1 vocabulary.
+var W3IDSecurityDataIntegrityV1MultikeyName string = "Multikey"
+
 // ActivityStreamsNoteName is the string literal of the name for the Note type in the ActivityStreams vocabulary.
 var ActivityStreamsNoteName string = "Note"
 
@@ -197,12 +200,18 @@ var ActivityStreamsAccuracyPropertyName string = "accuracy"
 // ActivityStreamsActorPropertyName is the string literal of the name for the actor property in the ActivityStreams vocabulary.
 var ActivityStreamsActorPropertyName string = "actor"
 
+// ActivityStreamsAlsoKnownAsPropertyName is the string literal of the name for the alsoKnownAs property in the ActivityStreams vocabulary.
+var ActivityStreamsAlsoKnownAsPropertyName string = "alsoKnownAs"
+
 // ActivityStreamsAltitudePropertyName is the string literal of the name for the altitude property in the ActivityStreams vocabulary.
 var ActivityStreamsAltitudePropertyName string = "altitude"
 
 // ActivityStreamsAnyOfPropertyName is the string literal of the name for the anyOf property in the ActivityStreams vocabulary.
 var ActivityStreamsAnyOfPropertyName string = "anyOf"
 
+// W3IDSecurityDataIntegrityV1AssertionMethodPropertyName is the string literal of the name for the assertionMethod property in the W3IDSecurityDataIntegrityV1 vocabulary.
+var W3IDSecurityDataIntegrityV1AssertionMethodPropertyName string = "assertionMethod"
+
 // ForgeFedAssignedToPropertyName is the string literal of the name for the assignedTo property in the ForgeFed vocabulary.
 var ForgeFedAssignedToPropertyName string = "assignedTo"
 
@@ -245,6 +254,9 @@ var ActivityStreamsContentPropertyMapName string = "contentMap"
 // ActivityStreamsContextPropertyName is the string literal of the name for the context property in the ActivityStreams vocabulary.
 var ActivityStreamsContextPropertyName string = "context"
 
+// W3IDSecurityDataIntegrityV1ControllerPropertyName is the string literal of the name for the controller property in the W3IDSecurityDataIntegrityV1 vocabulary.
+var W3IDSecurityDataIntegrityV1ControllerPropertyName string = "controller"
+
 // ActivityStreamsCurrentPropertyName is the string literal of the name for the current property in the ActivityStreams vocabulary.
 var ActivityStreamsCurrentPropertyName string = "current"
 
@@ -365,6 +377,9 @@ var ActivityStreamsLongitudePropertyName string = "longitude"
 // ActivityStreamsMediaTypePropertyName is the string literal of the name for the mediaType property in the ActivityStreams vocabulary.
 var ActivityStreamsMediaTypePropertyName string = "mediaType"
 
+// ActivityStreamsMovedToPropertyName is the string literal of the name for the movedTo property in the ActivityStreams vocabulary.
+var ActivityStreamsMovedToPropertyName string = "movedTo"
+
 // ActivityStreamsNamePropertyName is the string literal of the name for the name property in the ActivityStreams vocabulary.
 var ActivityStreamsNamePropertyName string = "name"
 
@@ -410,6 +425,9 @@ var ActivityStreamsPreviewPropertyName string = "preview"
 // W3IDSecurityV1PublicKeyPropertyName is the string literal of the name for the publicKey property in the W3IDSecurityV1 vocabulary.
 var W3IDSecurityV1PublicKeyPropertyName string = "publicKey"
 
+// W3IDSecurityDataIntegrityV1PublicKeyMultibasePropertyName is the string literal of the name for the publicKeyMultibase property in the W3IDSecurityDataIntegrityV1 vocabulary.
+var W3IDSecurityDataIntegrityV1PublicKeyMultibasePropertyName string = "publicKeyMultibase"
+
 // W3IDSecurityV1PublicKeyPemPropertyName is the string literal of the name for the publicKeyPem property in the W3IDSecurityV1 vocabulary.
 var W3IDSecurityV1PublicKeyPemPropertyName string = "publicKeyPem"
 
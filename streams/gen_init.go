@@ -5,6 +5,7 @@ package streams
 import (
 	propertyaccuracy "github.com/go-fed/activity/streams/impl/activitystreams/property_accuracy"
 	propertyactor "github.com/go-fed/activity/streams/impl/activitystreams/property_actor"
+	propertyalsoknownas "github.com/go-fed/activity/streams/impl/activitystreams/property_alsoknownas"
 	propertyaltitude "github.com/go-fed/activity/streams/impl/activitystreams/property_altitude"
 	propertyanyof "github.com/go-fed/activity/streams/impl/activitystreams/property_anyof"
 	propertyattachment "github.com/go-fed/activity/streams/impl/activitystreams/property_attachment"
@@ -42,6 +43,7 @@ import (
 	propertylocation "github.com/go-fed/activity/streams/impl/activitystreams/property_location"
 	propertylongitude "github.com/go-fed/activity/streams/impl/activitystreams/property_longitude"
 	propertymediatype "github.com/go-fed/activity/streams/impl/activitystreams/property_mediatype"
+	propertymovedto "github.com/go-fed/activity/streams/impl/activitystreams/property_movedto"
 	propertyname "github.com/go-fed/activity/streams/impl/activitystreams/property_name"
 	propertynext "github.com/go-fed/activity/streams/impl/activitystreams/property_next"
 	propertyobject "github.com/go-fed/activity/streams/impl/activitystreams/property_object"
@@ -161,6 +163,10 @@ import (
 	propertyvoterscount "github.com/go-fed/activity/streams/impl/toot/property_voterscount"
 	typeemoji "github.com/go-fed/activity/streams/impl/toot/type_emoji"
 	typeidentityproof "github.com/go-fed/activity/streams/impl/toot/type_identityproof"
+	propertyassertionmethod "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_assertionmethod"
+	propertycontroller "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_controller"
+	propertypublickeymultibase "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_publickeymultibase"
+	typemultikey "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/type_multikey"
 	propertyowner "github.com/go-fed/activity/streams/impl/w3idsecurityv1/property_owner"
 	propertypublickey "github.com/go-fed/activity/streams/impl/w3idsecurityv1/property_publickey"
 	propertypublickeypem "github.com/go-fed/activity/streams/impl/w3idsecurityv1/property_publickeypem"
@@ -182,6 +188,7 @@ func init() {
 	mgr = &Manager{}
 	propertyaccuracy.SetManager(mgr)
 	propertyactor.SetManager(mgr)
+	propertyalsoknownas.SetManager(mgr)
 	propertyaltitude.SetManager(mgr)
 	propertyanyof.SetManager(mgr)
 	propertyattachment.SetManager(mgr)
@@ -219,6 +226,7 @@ func init() {
 	propertylocation.SetManager(mgr)
 	propertylongitude.SetManager(mgr)
 	propertymediatype.SetManager(mgr)
+	propertymovedto.SetManager(mgr)
 	propertyname.SetManager(mgr)
 	propertynext.SetManager(mgr)
 	propertyobject.SetManager(mgr)
@@ -338,6 +346,10 @@ func init() {
 	propertyvoterscount.SetManager(mgr)
 	typeemoji.SetManager(mgr)
 	typeidentityproof.SetManager(mgr)
+	propertyassertionmethod.SetManager(mgr)
+	propertycontroller.SetManager(mgr)
+	propertypublickeymultibase.SetManager(mgr)
+	typemultikey.SetManager(mgr)
 	propertyowner.SetManager(mgr)
 	propertypublickey.SetManager(mgr)
 	propertypublickeypem.SetManager(mgr)
@@ -404,5 +416,6 @@ func init() {
 	typeticketdependency.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeemoji.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeidentityproof.SetTypePropertyConstructor(NewJSONLDTypeProperty)
+	typemultikey.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typepublickey.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 }
@@ -99,6 +99,8 @@ func NewJSONResolver(callbacks ...interface{}) (*JSONResolver, error) {
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsMove) error:
 			// Do nothing, this callback has a correct signature.
+		case func(context.Context, vocab.W3IDSecurityDataIntegrityV1Multikey) error:
+			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsNote) error:
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsObject) error:
@@ -250,6 +252,13 @@ func (this JSONResolver) Resolve(ctx context.Context, m map[string]interface{})
 		if len(TootAlias) > 0 {
 			TootAlias += ":"
 		}
+		W3IDSecurityDataIntegrityV1Alias, ok := aliasMap["https://w3id.org/security/data-integrity/v1"]
+		if !ok {
+			W3IDSecurityDataIntegrityV1Alias = aliasMap["http://w3id.org/security/data-integrity/v1"]
+		}
+		if len(W3IDSecurityDataIntegrityV1Alias) > 0 {
+			W3IDSecurityDataIntegrityV1Alias += ":"
+		}
 		W3IDSecurityV1Alias, ok := aliasMap["https://w3id.org/security/v1"]
 		if !ok {
 			W3IDSecurityV1Alias = aliasMap["http://w3id.org/security/v1"]
@@ -632,6 +641,17 @@ func (this JSONResolver) Resolve(ctx context.Context, m map[string]interface{})
 				}
 			}
 			return ErrNoCallbackMatch
+		} else if typeString == W3IDSecurityDataIntegrityV1Alias+"Multikey" {
+			v, err := mgr.DeserializeMultikeyW3IDSecurityDataIntegrityV1()(m, aliasMap)
+			if err != nil {
+				return err
+			}
+			for _, i := range this.callbacks {
+				if fn, ok := i.(func(context.Context, vocab.W3IDSecurityDataIntegrityV1Multikey) error); ok {
+					return fn(ctx, v)
+				}
+			}
+			return ErrNoCallbackMatch
 		} else if typeString == ActivityStreamsAlias+"Note" {
 			v, err := mgr.DeserializeNoteActivityStreams()(m, aliasMap)
 			if err != nil {
@@ -5,6 +5,7 @@ package streams
 import (
 	propertyaccuracy "github.com/go-fed/activity/streams/impl/activitystreams/property_accuracy"
 	propertyactor "github.com/go-fed/activity/streams/impl/activitystreams/property_actor"
+	propertyalsoknownas "github.com/go-fed/activity/streams/impl/activitystreams/property_alsoknownas"
 	propertyaltitude "github.com/go-fed/activity/streams/impl/activitystreams/property_altitude"
 	propertyanyof "github.com/go-fed/activity/streams/impl/activitystreams/property_anyof"
 	propertyattachment "github.com/go-fed/activity/streams/impl/activitystreams/property_attachment"
@@ -42,6 +43,7 @@ import (
 	propertylocation "github.com/go-fed/activity/streams/impl/activitystreams/property_location"
 	propertylongitude "github.com/go-fed/activity/streams/impl/activitystreams/property_longitude"
 	propertymediatype "github.com/go-fed/activity/streams/impl/activitystreams/property_mediatype"
+	propertymovedto "github.com/go-fed/activity/streams/impl/activitystreams/property_movedto"
 	propertyname "github.com/go-fed/activity/streams/impl/activitystreams/property_name"
 	propertynext "github.com/go-fed/activity/streams/impl/activitystreams/property_next"
 	propertyobject "github.com/go-fed/activity/streams/impl/activitystreams/property_object"
@@ -163,6 +165,10 @@ import (
 	propertyvoterscount "github.com/go-fed/activity/streams/impl/toot/property_voterscount"
 	typeemoji "github.com/go-fed/activity/streams/impl/toot/type_emoji"
 	typeidentityproof "github.com/go-fed/activity/streams/impl/toot/type_identityproof"
+	propertyassertionmethod "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_assertionmethod"
+	propertycontroller "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_controller"
+	propertypublickeymultibase "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_publickeymultibase"
+	typemultikey "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/type_multikey"
 	propertyowner "github.com/go-fed/activity/streams/impl/w3idsecurityv1/property_owner"
 	propertypublickey "github.com/go-fed/activity/streams/impl/w3idsecurityv1/property_publickey"
 	propertypublickeypem "github.com/go-fed/activity/streams/impl/w3idsecurityv1/property_publickeypem"
@@ -241,6 +247,19 @@ func (this Manager) DeserializeAddActivityStreams() func(map[string]interface{},
 	}
 }
 
+// DeserializeAlsoKnownAsPropertyActivityStreams returns the deserialization
+// method for the "ActivityStreamsAlsoKnownAsProperty" non-functional property
+// in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeAlsoKnownAsPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsAlsoKnownAsProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsAlsoKnownAsProperty, error) {
+		i, err := propertyalsoknownas.DeserializeAlsoKnownAsProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeAltitudePropertyActivityStreams returns the deserialization method
 // for the "ActivityStreamsAltitudeProperty" non-functional property in the
 // vocabulary "ActivityStreams"
@@ -319,6 +338,20 @@ func (this Manager) DeserializeArticleActivityStreams() func(map[string]interfac
 	}
 }
 
+// DeserializeAssertionMethodPropertyW3IDSecurityDataIntegrityV1 returns the
+// deserialization method for the
+// "W3IDSecurityDataIntegrityV1AssertionMethodProperty" non-functional
+// property in the vocabulary "W3IDSecurityDataIntegrityV1"
+func (this Manager) DeserializeAssertionMethodPropertyW3IDSecurityDataIntegrityV1() func(map[string]interface{}, map[string]string) (vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty, error) {
+		i, err := propertyassertionmethod.DeserializeAssertionMethodProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeAssignedToPropertyForgeFed returns the deserialization method for
 // the "ForgeFedAssignedToProperty" non-functional property in the vocabulary
 // "ForgeFed"
@@ -563,6 +596,20 @@ func (this Manager) DeserializeContextPropertyActivityStreams() func(map[string]
 	}
 }
 
+// DeserializeControllerPropertyW3IDSecurityDataIntegrityV1 returns the
+// deserialization method for the
+// "W3IDSecurityDataIntegrityV1ControllerProperty" non-functional property in
+// the vocabulary "W3IDSecurityDataIntegrityV1"
+func (this Manager) DeserializeControllerPropertyW3IDSecurityDataIntegrityV1() func(map[string]interface{}, map[string]string) (vocab.W3IDSecurityDataIntegrityV1ControllerProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.W3IDSecurityDataIntegrityV1ControllerProperty, error) {
+		i, err := propertycontroller.DeserializeControllerProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeCreateActivityStreams returns the deserialization method for the
 // "ActivityStreamsCreate" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -1362,6 +1409,32 @@ func (this Manager) DeserializeMoveActivityStreams() func(map[string]interface{}
 	}
 }
 
+// DeserializeMovedToPropertyActivityStreams returns the deserialization method
+// for the "ActivityStreamsMovedToProperty" non-functional property in the
+// vocabulary "ActivityStreams"
+func (this Manager) DeserializeMovedToPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsMovedToProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsMovedToProperty, error) {
+		i, err := propertymovedto.DeserializeMovedToProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
+// DeserializeMultikeyW3IDSecurityDataIntegrityV1 returns the deserialization
+// method for the "W3IDSecurityDataIntegrityV1Multikey" non-functional
+// property in the vocabulary "W3IDSecurityDataIntegrityV1"
+func (this Manager) DeserializeMultikeyW3IDSecurityDataIntegrityV1() func(map[string]interface{}, map[string]string) (vocab.W3IDSecurityDataIntegrityV1Multikey, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.W3IDSecurityDataIntegrityV1Multikey, error) {
+		i, err := typemultikey.DeserializeMultikey(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeNamePropertyActivityStreams returns the deserialization method for
 // the "ActivityStreamsNameProperty" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -1648,6 +1721,20 @@ func (this Manager) DeserializeProfileActivityStreams() func(map[string]interfac
 	}
 }
 
+// DeserializePublicKeyMultibasePropertyW3IDSecurityDataIntegrityV1 returns the
+// deserialization method for the
+// "W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty" non-functional
+// property in the vocabulary "W3IDSecurityDataIntegrityV1"
+func (this Manager) DeserializePublicKeyMultibasePropertyW3IDSecurityDataIntegrityV1() func(map[string]interface{}, map[string]string) (vocab.W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty, error) {
+		i, err := propertypublickeymultibase.DeserializePublicKeyMultibaseProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializePublicKeyPemPropertyW3IDSecurityV1 returns the deserialization
 // method for the "W3IDSecurityV1PublicKeyPemProperty" non-functional property
 // in the vocabulary "W3IDSecurityV1"
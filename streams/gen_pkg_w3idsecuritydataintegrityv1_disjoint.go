@@ -0,0 +1,14 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typemultikey "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/type_multikey"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// W3IDSecurityDataIntegrityV1MultikeyIsDisjointWith returns true if Multikey is
+// disjoint with the other's type.
+func W3IDSecurityDataIntegrityV1MultikeyIsDisjointWith(other vocab.Type) bool {
+	return typemultikey.MultikeyIsDisjointWith(other)
+}
@@ -0,0 +1,15 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typemultikey "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/type_multikey"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// W3IDSecurityDataIntegrityV1MultikeyIsExtendedBy returns true if the other's
+// type extends from Multikey. Note that it returns false if the types are the
+// same; see the "IsOrExtends" variant instead.
+func W3IDSecurityDataIntegrityV1MultikeyIsExtendedBy(other vocab.Type) bool {
+	return typemultikey.MultikeyIsExtendedBy(other)
+}
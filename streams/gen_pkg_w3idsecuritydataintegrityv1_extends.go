@@ -0,0 +1,14 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typemultikey "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/type_multikey"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// W3IDSecurityDataIntegrityV1W3IDSecurityDataIntegrityV1MultikeyExtends returns
+// true if Multikey extends from the other's type.
+func W3IDSecurityDataIntegrityV1W3IDSecurityDataIntegrityV1MultikeyExtends(other vocab.Type) bool {
+	return typemultikey.W3IDSecurityDataIntegrityV1MultikeyExtends(other)
+}
@@ -0,0 +1,14 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typemultikey "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/type_multikey"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// IsOrExtendsW3IDSecurityDataIntegrityV1Multikey returns true if the other
+// provided type is the Multikey type or extends from the Multikey type.
+func IsOrExtendsW3IDSecurityDataIntegrityV1Multikey(other vocab.Type) bool {
+	return typemultikey.IsOrExtendsMultikey(other)
+}
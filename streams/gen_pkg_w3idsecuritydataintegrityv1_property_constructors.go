@@ -0,0 +1,28 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	propertyassertionmethod "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_assertionmethod"
+	propertycontroller "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_controller"
+	propertypublickeymultibase "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/property_publickeymultibase"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// NewW3IDSecurityDataIntegrityV1W3IDSecurityDataIntegrityV1AssertionMethodProperty
+// creates a new W3IDSecurityDataIntegrityV1AssertionMethodProperty
+func NewW3IDSecurityDataIntegrityV1AssertionMethodProperty() vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty {
+	return propertyassertionmethod.NewW3IDSecurityDataIntegrityV1AssertionMethodProperty()
+}
+
+// NewW3IDSecurityDataIntegrityV1W3IDSecurityDataIntegrityV1ControllerProperty
+// creates a new W3IDSecurityDataIntegrityV1ControllerProperty
+func NewW3IDSecurityDataIntegrityV1ControllerProperty() vocab.W3IDSecurityDataIntegrityV1ControllerProperty {
+	return propertycontroller.NewW3IDSecurityDataIntegrityV1ControllerProperty()
+}
+
+// NewW3IDSecurityDataIntegrityV1W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty
+// creates a new W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty
+func NewW3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty() vocab.W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty {
+	return propertypublickeymultibase.NewW3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty()
+}
@@ -0,0 +1,14 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typemultikey "github.com/go-fed/activity/streams/impl/w3idsecuritydataintegrityv1/type_multikey"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// NewW3IDSecurityDataIntegrityV1Multikey creates a new
+// W3IDSecurityDataIntegrityV1Multikey
+func NewW3IDSecurityDataIntegrityV1Multikey() vocab.W3IDSecurityDataIntegrityV1Multikey {
+	return typemultikey.NewW3IDSecurityDataIntegrityV1Multikey()
+}
@@ -148,6 +148,9 @@ func ToType(c context.Context, m map[string]interface{}) (t vocab.Type, err erro
 	}, func(ctx context.Context, i vocab.ActivityStreamsMove) error {
 		t = i
 		return nil
+	}, func(ctx context.Context, i vocab.W3IDSecurityDataIntegrityV1Multikey) error {
+		t = i
+		return nil
 	}, func(ctx context.Context, i vocab.ActivityStreamsNote) error {
 		t = i
 		return nil
@@ -97,6 +97,8 @@ func NewTypePredicatedResolver(delegate Resolver, predicate interface{}) (*TypeP
 		// Do nothing, this predicate has a correct signature.
 	case func(context.Context, vocab.ActivityStreamsMove) (bool, error):
 		// Do nothing, this predicate has a correct signature.
+	case func(context.Context, vocab.W3IDSecurityDataIntegrityV1Multikey) (bool, error):
+		// Do nothing, this predicate has a correct signature.
 	case func(context.Context, vocab.ActivityStreamsNote) (bool, error):
 		// Do nothing, this predicate has a correct signature.
 	case func(context.Context, vocab.ActivityStreamsObject) (bool, error):
@@ -548,6 +550,17 @@ func (this TypePredicatedResolver) Apply(ctx context.Context, o ActivityStreamsI
 		} else {
 			return false, ErrPredicateUnmatched
 		}
+	} else if o.VocabularyURI() == "https://w3id.org/security/data-integrity/v1" && o.GetTypeName() == "Multikey" {
+		if fn, ok := this.predicate.(func(context.Context, vocab.W3IDSecurityDataIntegrityV1Multikey) (bool, error)); ok {
+			if v, ok := o.(vocab.W3IDSecurityDataIntegrityV1Multikey); ok {
+				predicatePasses, err = fn(ctx, v)
+			} else {
+				// This occurs when the value is either not a go-fed type and is improperly satisfying various interfaces, or there is a bug in the go-fed generated code.
+				return false, errCannotTypeAssertType
+			}
+		} else {
+			return false, ErrPredicateUnmatched
+		}
 	} else if o.VocabularyURI() == "https://www.w3.org/ns/activitystreams" && o.GetTypeName() == "Note" {
 		if fn, ok := this.predicate.(func(context.Context, vocab.ActivityStreamsNote) (bool, error)); ok {
 			if v, ok := o.(vocab.ActivityStreamsNote); ok {
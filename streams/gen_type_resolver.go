@@ -96,6 +96,8 @@ func NewTypeResolver(callbacks ...interface{}) (*TypeResolver, error) {
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsMove) error:
 			// Do nothing, this callback has a correct signature.
+		case func(context.Context, vocab.W3IDSecurityDataIntegrityV1Multikey) error:
+			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsNote) error:
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsObject) error:
@@ -475,6 +477,15 @@ func (this TypeResolver) Resolve(ctx context.Context, o ActivityStreamsInterface
 					return errCannotTypeAssertType
 				}
 			}
+		} else if o.VocabularyURI() == "https://w3id.org/security/data-integrity/v1" && o.GetTypeName() == "Multikey" {
+			if fn, ok := i.(func(context.Context, vocab.W3IDSecurityDataIntegrityV1Multikey) error); ok {
+				if v, ok := o.(vocab.W3IDSecurityDataIntegrityV1Multikey); ok {
+					return fn(ctx, v)
+				} else {
+					// This occurs when the value is either not a go-fed type and is improperly satisfying various interfaces, or there is a bug in the go-fed generated code.
+					return errCannotTypeAssertType
+				}
+			}
 		} else if o.VocabularyURI() == "https://www.w3.org/ns/activitystreams" && o.GetTypeName() == "Note" {
 			if fn, ok := i.(func(context.Context, vocab.ActivityStreamsNote) error); ok {
 				if v, ok := o.(vocab.ActivityStreamsNote); ok {
@@ -16,54 +16,57 @@ import (
 //     "type": "Person"
 //   }
 type ActivityStreamsPerson struct {
-	ActivityStreamsAltitude          vocab.ActivityStreamsAltitudeProperty
-	ActivityStreamsAttachment        vocab.ActivityStreamsAttachmentProperty
-	ActivityStreamsAttributedTo      vocab.ActivityStreamsAttributedToProperty
-	ActivityStreamsAudience          vocab.ActivityStreamsAudienceProperty
-	ActivityStreamsBcc               vocab.ActivityStreamsBccProperty
-	ActivityStreamsBto               vocab.ActivityStreamsBtoProperty
-	ActivityStreamsCc                vocab.ActivityStreamsCcProperty
-	ActivityStreamsContent           vocab.ActivityStreamsContentProperty
-	ActivityStreamsContext           vocab.ActivityStreamsContextProperty
-	TootDiscoverable                 vocab.TootDiscoverableProperty
-	ActivityStreamsDuration          vocab.ActivityStreamsDurationProperty
-	ActivityStreamsEndTime           vocab.ActivityStreamsEndTimeProperty
-	TootFeatured                     vocab.TootFeaturedProperty
-	ActivityStreamsFollowers         vocab.ActivityStreamsFollowersProperty
-	ActivityStreamsFollowing         vocab.ActivityStreamsFollowingProperty
-	ActivityStreamsGenerator         vocab.ActivityStreamsGeneratorProperty
-	ActivityStreamsIcon              vocab.ActivityStreamsIconProperty
-	JSONLDId                         vocab.JSONLDIdProperty
-	ActivityStreamsImage             vocab.ActivityStreamsImageProperty
-	ActivityStreamsInReplyTo         vocab.ActivityStreamsInReplyToProperty
-	ActivityStreamsInbox             vocab.ActivityStreamsInboxProperty
-	ActivityStreamsLiked             vocab.ActivityStreamsLikedProperty
-	ActivityStreamsLikes             vocab.ActivityStreamsLikesProperty
-	ActivityStreamsLocation          vocab.ActivityStreamsLocationProperty
-	ActivityStreamsMediaType         vocab.ActivityStreamsMediaTypeProperty
-	ActivityStreamsName              vocab.ActivityStreamsNameProperty
-	ActivityStreamsObject            vocab.ActivityStreamsObjectProperty
-	ActivityStreamsOutbox            vocab.ActivityStreamsOutboxProperty
-	ActivityStreamsPreferredUsername vocab.ActivityStreamsPreferredUsernameProperty
-	ActivityStreamsPreview           vocab.ActivityStreamsPreviewProperty
-	W3IDSecurityV1PublicKey          vocab.W3IDSecurityV1PublicKeyProperty
-	ActivityStreamsPublished         vocab.ActivityStreamsPublishedProperty
-	ActivityStreamsReplies           vocab.ActivityStreamsRepliesProperty
-	ActivityStreamsShares            vocab.ActivityStreamsSharesProperty
-	ActivityStreamsSource            vocab.ActivityStreamsSourceProperty
-	ActivityStreamsStartTime         vocab.ActivityStreamsStartTimeProperty
-	ActivityStreamsStreams           vocab.ActivityStreamsStreamsProperty
-	ActivityStreamsSummary           vocab.ActivityStreamsSummaryProperty
-	ActivityStreamsTag               vocab.ActivityStreamsTagProperty
-	ForgeFedTeam                     vocab.ForgeFedTeamProperty
-	ForgeFedTicketsTrackedBy         vocab.ForgeFedTicketsTrackedByProperty
-	ActivityStreamsTo                vocab.ActivityStreamsToProperty
-	ForgeFedTracksTicketsFor         vocab.ForgeFedTracksTicketsForProperty
-	JSONLDType                       vocab.JSONLDTypeProperty
-	ActivityStreamsUpdated           vocab.ActivityStreamsUpdatedProperty
-	ActivityStreamsUrl               vocab.ActivityStreamsUrlProperty
-	alias                            string
-	unknown                          map[string]interface{}
+	ActivityStreamsAlsoKnownAs                 vocab.ActivityStreamsAlsoKnownAsProperty
+	ActivityStreamsAltitude                    vocab.ActivityStreamsAltitudeProperty
+	W3IDSecurityDataIntegrityV1AssertionMethod vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty
+	ActivityStreamsAttachment                  vocab.ActivityStreamsAttachmentProperty
+	ActivityStreamsAttributedTo                vocab.ActivityStreamsAttributedToProperty
+	ActivityStreamsAudience                    vocab.ActivityStreamsAudienceProperty
+	ActivityStreamsBcc                         vocab.ActivityStreamsBccProperty
+	ActivityStreamsBto                         vocab.ActivityStreamsBtoProperty
+	ActivityStreamsCc                          vocab.ActivityStreamsCcProperty
+	ActivityStreamsContent                     vocab.ActivityStreamsContentProperty
+	ActivityStreamsContext                     vocab.ActivityStreamsContextProperty
+	TootDiscoverable                           vocab.TootDiscoverableProperty
+	ActivityStreamsDuration                    vocab.ActivityStreamsDurationProperty
+	ActivityStreamsEndTime                     vocab.ActivityStreamsEndTimeProperty
+	TootFeatured                               vocab.TootFeaturedProperty
+	ActivityStreamsFollowers                   vocab.ActivityStreamsFollowersProperty
+	ActivityStreamsFollowing                   vocab.ActivityStreamsFollowingProperty
+	ActivityStreamsGenerator                   vocab.ActivityStreamsGeneratorProperty
+	ActivityStreamsIcon                        vocab.ActivityStreamsIconProperty
+	JSONLDId                                   vocab.JSONLDIdProperty
+	ActivityStreamsImage                       vocab.ActivityStreamsImageProperty
+	ActivityStreamsInReplyTo                   vocab.ActivityStreamsInReplyToProperty
+	ActivityStreamsInbox                       vocab.ActivityStreamsInboxProperty
+	ActivityStreamsLiked                       vocab.ActivityStreamsLikedProperty
+	ActivityStreamsLikes                       vocab.ActivityStreamsLikesProperty
+	ActivityStreamsLocation                    vocab.ActivityStreamsLocationProperty
+	ActivityStreamsMediaType                   vocab.ActivityStreamsMediaTypeProperty
+	ActivityStreamsMovedTo                     vocab.ActivityStreamsMovedToProperty
+	ActivityStreamsName                        vocab.ActivityStreamsNameProperty
+	ActivityStreamsObject                      vocab.ActivityStreamsObjectProperty
+	ActivityStreamsOutbox                      vocab.ActivityStreamsOutboxProperty
+	ActivityStreamsPreferredUsername           vocab.ActivityStreamsPreferredUsernameProperty
+	ActivityStreamsPreview                     vocab.ActivityStreamsPreviewProperty
+	W3IDSecurityV1PublicKey                    vocab.W3IDSecurityV1PublicKeyProperty
+	ActivityStreamsPublished                   vocab.ActivityStreamsPublishedProperty
+	ActivityStreamsReplies                     vocab.ActivityStreamsRepliesProperty
+	ActivityStreamsShares                      vocab.ActivityStreamsSharesProperty
+	ActivityStreamsSource                      vocab.ActivityStreamsSourceProperty
+	ActivityStreamsStartTime                   vocab.ActivityStreamsStartTimeProperty
+	ActivityStreamsStreams                     vocab.ActivityStreamsStreamsProperty
+	ActivityStreamsSummary                     vocab.ActivityStreamsSummaryProperty
+	ActivityStreamsTag                         vocab.ActivityStreamsTagProperty
+	ForgeFedTeam                               vocab.ForgeFedTeamProperty
+	ForgeFedTicketsTrackedBy                   vocab.ForgeFedTicketsTrackedByProperty
+	ActivityStreamsTo                          vocab.ActivityStreamsToProperty
+	ForgeFedTracksTicketsFor                   vocab.ForgeFedTracksTicketsForProperty
+	JSONLDType                                 vocab.JSONLDTypeProperty
+	ActivityStreamsUpdated                     vocab.ActivityStreamsUpdatedProperty
+	ActivityStreamsUrl                         vocab.ActivityStreamsUrlProperty
+	alias                                      string
+	unknown                                    map[string]interface{}
 }
 
 // ActivityStreamsPersonExtends returns true if the Person type extends from the
@@ -115,11 +118,21 @@ func DeserializePerson(m map[string]interface{}, aliasMap map[string]string) (*A
 		return nil, fmt.Errorf("\"type\" property is unrecognized type: %T", typeValue)
 	}
 	// Begin: Known property deserialization
+	if p, err := mgr.DeserializeAlsoKnownAsPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsAlsoKnownAs = p
+	}
 	if p, err := mgr.DeserializeAltitudePropertyActivityStreams()(m, aliasMap); err != nil {
 		return nil, err
 	} else if p != nil {
 		this.ActivityStreamsAltitude = p
 	}
+	if p, err := mgr.DeserializeAssertionMethodPropertyW3IDSecurityDataIntegrityV1()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.W3IDSecurityDataIntegrityV1AssertionMethod = p
+	}
 	if p, err := mgr.DeserializeAttachmentPropertyActivityStreams()(m, aliasMap); err != nil {
 		return nil, err
 	} else if p != nil {
@@ -240,6 +253,11 @@ func DeserializePerson(m map[string]interface{}, aliasMap map[string]string) (*A
 	} else if p != nil {
 		this.ActivityStreamsMediaType = p
 	}
+	if p, err := mgr.DeserializeMovedToPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsMovedTo = p
+	}
 	if p, err := mgr.DeserializeNamePropertyActivityStreams()(m, aliasMap); err != nil {
 		return nil, err
 	} else if p != nil {
@@ -350,7 +368,11 @@ func DeserializePerson(m map[string]interface{}, aliasMap map[string]string) (*A
 	// Begin: Unknown deserialization
 	for k, v := range m {
 		// Begin: Code that ensures a property name is unknown
-		if k == "altitude" {
+		if k == "alsoKnownAs" {
+			continue
+		} else if k == "altitude" {
+			continue
+		} else if k == "assertionMethod" {
 			continue
 		} else if k == "attachment" {
 			continue
@@ -402,6 +424,8 @@ func DeserializePerson(m map[string]interface{}, aliasMap map[string]string) (*A
 			continue
 		} else if k == "mediaType" {
 			continue
+		} else if k == "movedTo" {
+			continue
 		} else if k == "name" {
 			continue
 		} else if k == "nameMap" {
@@ -499,6 +523,12 @@ func PersonIsExtendedBy(other vocab.Type) bool {
 	return false
 }
 
+// GetActivityStreamsAlsoKnownAs returns the "alsoKnownAs" property if it exists,
+// and nil otherwise.
+func (this ActivityStreamsPerson) GetActivityStreamsAlsoKnownAs() vocab.ActivityStreamsAlsoKnownAsProperty {
+	return this.ActivityStreamsAlsoKnownAs
+}
+
 // GetActivityStreamsAltitude returns the "altitude" property if it exists, and
 // nil otherwise.
 func (this ActivityStreamsPerson) GetActivityStreamsAltitude() vocab.ActivityStreamsAltitudeProperty {
@@ -630,6 +660,12 @@ func (this ActivityStreamsPerson) GetActivityStreamsMediaType() vocab.ActivitySt
 	return this.ActivityStreamsMediaType
 }
 
+// GetActivityStreamsMovedTo returns the "movedTo" property if it exists, and nil
+// otherwise.
+func (this ActivityStreamsPerson) GetActivityStreamsMovedTo() vocab.ActivityStreamsMovedToProperty {
+	return this.ActivityStreamsMovedTo
+}
+
 // GetActivityStreamsName returns the "name" property if it exists, and nil
 // otherwise.
 func (this ActivityStreamsPerson) GetActivityStreamsName() vocab.ActivityStreamsNameProperty {
@@ -778,6 +814,12 @@ func (this ActivityStreamsPerson) GetUnknownProperties() map[string]interface{}
 	return this.unknown
 }
 
+// GetW3IDSecurityDataIntegrityV1AssertionMethod returns the "assertionMethod"
+// property if it exists, and nil otherwise.
+func (this ActivityStreamsPerson) GetW3IDSecurityDataIntegrityV1AssertionMethod() vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty {
+	return this.W3IDSecurityDataIntegrityV1AssertionMethod
+}
+
 // GetW3IDSecurityV1PublicKey returns the "publicKey" property if it exists, and
 // nil otherwise.
 func (this ActivityStreamsPerson) GetW3IDSecurityV1PublicKey() vocab.W3IDSecurityV1PublicKeyProperty {
@@ -794,7 +836,9 @@ func (this ActivityStreamsPerson) IsExtending(other vocab.Type) bool {
 // alias used to import the type and its properties.
 func (this ActivityStreamsPerson) JSONLDContext() map[string]string {
 	m := map[string]string{"https://www.w3.org/ns/activitystreams": this.alias}
+	m = this.helperJSONLDContext(this.ActivityStreamsAlsoKnownAs, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsAltitude, m)
+	m = this.helperJSONLDContext(this.W3IDSecurityDataIntegrityV1AssertionMethod, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsAttachment, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsAttributedTo, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsAudience, m)
@@ -819,6 +863,7 @@ func (this ActivityStreamsPerson) JSONLDContext() map[string]string {
 	m = this.helperJSONLDContext(this.ActivityStreamsLikes, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsLocation, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsMediaType, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsMovedTo, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsName, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsObject, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsOutbox, m)
@@ -848,6 +893,20 @@ func (this ActivityStreamsPerson) JSONLDContext() map[string]string {
 // determination.
 func (this ActivityStreamsPerson) LessThan(o vocab.ActivityStreamsPerson) bool {
 	// Begin: Compare known properties
+	// Compare property "alsoKnownAs"
+	if lhs, rhs := this.ActivityStreamsAlsoKnownAs, o.GetActivityStreamsAlsoKnownAs(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
 	// Compare property "altitude"
 	if lhs, rhs := this.ActivityStreamsAltitude, o.GetActivityStreamsAltitude(); lhs != nil && rhs != nil {
 		if lhs.LessThan(rhs) {
@@ -862,6 +921,20 @@ func (this ActivityStreamsPerson) LessThan(o vocab.ActivityStreamsPerson) bool {
 		// Anything else is greater than nil
 		return false
 	} // Else: Both are nil
+	// Compare property "assertionMethod"
+	if lhs, rhs := this.W3IDSecurityDataIntegrityV1AssertionMethod, o.GetW3IDSecurityDataIntegrityV1AssertionMethod(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
 	// Compare property "attachment"
 	if lhs, rhs := this.ActivityStreamsAttachment, o.GetActivityStreamsAttachment(); lhs != nil && rhs != nil {
 		if lhs.LessThan(rhs) {
@@ -1198,6 +1271,20 @@ func (this ActivityStreamsPerson) LessThan(o vocab.ActivityStreamsPerson) bool {
 		// Anything else is greater than nil
 		return false
 	} // Else: Both are nil
+	// Compare property "movedTo"
+	if lhs, rhs := this.ActivityStreamsMovedTo, o.GetActivityStreamsMovedTo(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
 	// Compare property "name"
 	if lhs, rhs := this.ActivityStreamsName, o.GetActivityStreamsName(); lhs != nil && rhs != nil {
 		if lhs.LessThan(rhs) {
@@ -1515,6 +1602,14 @@ func (this ActivityStreamsPerson) Serialize() (map[string]interface{}, error) {
 	}
 	m["type"] = typeName
 	// Begin: Serialize known properties
+	// Maybe serialize property "alsoKnownAs"
+	if this.ActivityStreamsAlsoKnownAs != nil {
+		if i, err := this.ActivityStreamsAlsoKnownAs.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsAlsoKnownAs.Name()] = i
+		}
+	}
 	// Maybe serialize property "altitude"
 	if this.ActivityStreamsAltitude != nil {
 		if i, err := this.ActivityStreamsAltitude.Serialize(); err != nil {
@@ -1523,6 +1618,14 @@ func (this ActivityStreamsPerson) Serialize() (map[string]interface{}, error) {
 			m[this.ActivityStreamsAltitude.Name()] = i
 		}
 	}
+	// Maybe serialize property "assertionMethod"
+	if this.W3IDSecurityDataIntegrityV1AssertionMethod != nil {
+		if i, err := this.W3IDSecurityDataIntegrityV1AssertionMethod.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.W3IDSecurityDataIntegrityV1AssertionMethod.Name()] = i
+		}
+	}
 	// Maybe serialize property "attachment"
 	if this.ActivityStreamsAttachment != nil {
 		if i, err := this.ActivityStreamsAttachment.Serialize(); err != nil {
@@ -1715,6 +1818,14 @@ func (this ActivityStreamsPerson) Serialize() (map[string]interface{}, error) {
 			m[this.ActivityStreamsMediaType.Name()] = i
 		}
 	}
+	// Maybe serialize property "movedTo"
+	if this.ActivityStreamsMovedTo != nil {
+		if i, err := this.ActivityStreamsMovedTo.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsMovedTo.Name()] = i
+		}
+	}
 	// Maybe serialize property "name"
 	if this.ActivityStreamsName != nil {
 		if i, err := this.ActivityStreamsName.Serialize(); err != nil {
@@ -1897,6 +2008,11 @@ func (this ActivityStreamsPerson) Serialize() (map[string]interface{}, error) {
 	return m, nil
 }
 
+// SetActivityStreamsAlsoKnownAs sets the "alsoKnownAs" property.
+func (this *ActivityStreamsPerson) SetActivityStreamsAlsoKnownAs(i vocab.ActivityStreamsAlsoKnownAsProperty) {
+	this.ActivityStreamsAlsoKnownAs = i
+}
+
 // SetActivityStreamsAltitude sets the "altitude" property.
 func (this *ActivityStreamsPerson) SetActivityStreamsAltitude(i vocab.ActivityStreamsAltitudeProperty) {
 	this.ActivityStreamsAltitude = i
@@ -2007,6 +2123,11 @@ func (this *ActivityStreamsPerson) SetActivityStreamsMediaType(i vocab.ActivityS
 	this.ActivityStreamsMediaType = i
 }
 
+// SetActivityStreamsMovedTo sets the "movedTo" property.
+func (this *ActivityStreamsPerson) SetActivityStreamsMovedTo(i vocab.ActivityStreamsMovedToProperty) {
+	this.ActivityStreamsMovedTo = i
+}
+
 // SetActivityStreamsName sets the "name" property.
 func (this *ActivityStreamsPerson) SetActivityStreamsName(i vocab.ActivityStreamsNameProperty) {
 	this.ActivityStreamsName = i
@@ -2122,6 +2243,12 @@ func (this *ActivityStreamsPerson) SetTootFeatured(i vocab.TootFeaturedProperty)
 	this.TootFeatured = i
 }
 
+// SetW3IDSecurityDataIntegrityV1AssertionMethod sets the "assertionMethod"
+// property.
+func (this *ActivityStreamsPerson) SetW3IDSecurityDataIntegrityV1AssertionMethod(i vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty) {
+	this.W3IDSecurityDataIntegrityV1AssertionMethod = i
+}
+
 // SetW3IDSecurityV1PublicKey sets the "publicKey" property.
 func (this *ActivityStreamsPerson) SetW3IDSecurityV1PublicKey(i vocab.W3IDSecurityV1PublicKeyProperty) {
 	this.W3IDSecurityV1PublicKey = i
@@ -0,0 +1,633 @@
+// Code generated by astool. DO NOT EDIT.
+
+package propertyassertionmethod
+
+import (
+	"fmt"
+	vocab "github.com/go-fed/activity/streams/vocab"
+	"net/url"
+)
+
+// W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator is an iterator for a
+// property. It is permitted to be a single nilable value type.
+type W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator struct {
+	w3idsecuritydataintegrityv1MultikeyMember vocab.W3IDSecurityDataIntegrityV1Multikey
+	unknown                                   interface{}
+	iri                                       *url.URL
+	alias                                     string
+	myIdx                                     int
+	parent                                    vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty
+}
+
+// NewW3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator creates a new
+// W3IDSecurityDataIntegrityV1AssertionMethod property.
+func NewW3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator() *W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator {
+	return &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{alias: ""}
+}
+
+// deserializeW3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator creates
+// an iterator from an element that has been unmarshalled from a text or
+// binary format.
+func deserializeW3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator(i interface{}, aliasMap map[string]string) (*W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator, error) {
+	alias := ""
+	if a, ok := aliasMap["https://w3id.org/security/data-integrity/v1"]; ok {
+		alias = a
+	}
+	if s, ok := i.(string); ok {
+		u, err := url.Parse(s)
+		// If error exists, don't error out -- skip this and treat as unknown string ([]byte) at worst
+		// Also, if no scheme exists, don't treat it as a URL -- net/url is greedy
+		if err == nil && len(u.Scheme) > 0 {
+			this := &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+				alias: alias,
+				iri:   u,
+			}
+			return this, nil
+		}
+	}
+	if m, ok := i.(map[string]interface{}); ok {
+		if v, err := mgr.DeserializeMultikeyW3IDSecurityDataIntegrityV1()(m, aliasMap); err == nil {
+			this := &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+				alias: alias,
+				w3idsecuritydataintegrityv1MultikeyMember: v,
+			}
+			return this, nil
+		}
+	}
+	this := &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:   alias,
+		unknown: i,
+	}
+	return this, nil
+}
+
+// Get returns the value of this property. When
+// IsW3IDSecurityDataIntegrityV1Multikey returns false, Get will return any
+// arbitrary value.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) Get() vocab.W3IDSecurityDataIntegrityV1Multikey {
+	return this.w3idsecuritydataintegrityv1MultikeyMember
+}
+
+// GetIRI returns the IRI of this property. When IsIRI returns false, GetIRI will
+// return any arbitrary value.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) GetIRI() *url.URL {
+	return this.iri
+}
+
+// GetType returns the value in this property as a Type. Returns nil if the value
+// is not an ActivityStreams type, such as an IRI or another value.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) GetType() vocab.Type {
+	if this.IsW3IDSecurityDataIntegrityV1Multikey() {
+		return this.Get()
+	}
+
+	return nil
+}
+
+// HasAny returns true if the value or IRI is set.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) HasAny() bool {
+	return this.IsW3IDSecurityDataIntegrityV1Multikey() || this.iri != nil
+}
+
+// IsIRI returns true if this property is an IRI.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) IsIRI() bool {
+	return this.iri != nil
+}
+
+// IsW3IDSecurityDataIntegrityV1Multikey returns true if this property is set and
+// not an IRI.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) IsW3IDSecurityDataIntegrityV1Multikey() bool {
+	return this.w3idsecuritydataintegrityv1MultikeyMember != nil
+}
+
+// JSONLDContext returns the JSONLD URIs required in the context string for this
+// property and the specific values that are set. The value in the map is the
+// alias used to import the property's value or values.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) JSONLDContext() map[string]string {
+	m := map[string]string{"https://w3id.org/security/data-integrity/v1": this.alias}
+	var child map[string]string
+	if this.IsW3IDSecurityDataIntegrityV1Multikey() {
+		child = this.Get().JSONLDContext()
+	}
+	/*
+	   Since the literal maps in this function are determined at
+	   code-generation time, this loop should not overwrite an existing key with a
+	   new value.
+	*/
+	for k, v := range child {
+		m[k] = v
+	}
+	return m
+}
+
+// KindIndex computes an arbitrary value for indexing this kind of value. This is
+// a leaky API detail only for folks looking to replace the go-fed
+// implementation. Applications should not use this method.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) KindIndex() int {
+	if this.IsW3IDSecurityDataIntegrityV1Multikey() {
+		return 0
+	}
+	if this.IsIRI() {
+		return -2
+	}
+	return -1
+}
+
+// LessThan compares two instances of this property with an arbitrary but stable
+// comparison. Applications should not use this because it is only meant to
+// help alternative implementations to go-fed to be able to normalize
+// nonfunctional properties.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) LessThan(o vocab.W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) bool {
+	// LessThan comparison for if either or both are IRIs.
+	if this.IsIRI() && o.IsIRI() {
+		return this.iri.String() < o.GetIRI().String()
+	} else if this.IsIRI() {
+		// IRIs are always less than other values, none, or unknowns
+		return true
+	} else if o.IsIRI() {
+		// This other, none, or unknown value is always greater than IRIs
+		return false
+	}
+	// LessThan comparison for the single value or unknown value.
+	if !this.IsW3IDSecurityDataIntegrityV1Multikey() && !o.IsW3IDSecurityDataIntegrityV1Multikey() {
+		// Both are unknowns.
+		return false
+	} else if this.IsW3IDSecurityDataIntegrityV1Multikey() && !o.IsW3IDSecurityDataIntegrityV1Multikey() {
+		// Values are always greater than unknown values.
+		return false
+	} else if !this.IsW3IDSecurityDataIntegrityV1Multikey() && o.IsW3IDSecurityDataIntegrityV1Multikey() {
+		// Unknowns are always less than known values.
+		return true
+	} else {
+		// Actual comparison.
+		return this.Get().LessThan(o.Get())
+	}
+}
+
+// Name returns the name of this property:
+// "W3IDSecurityDataIntegrityV1AssertionMethod".
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) Name() string {
+	if len(this.alias) > 0 {
+		return this.alias + ":" + "W3IDSecurityDataIntegrityV1AssertionMethod"
+	} else {
+		return "W3IDSecurityDataIntegrityV1AssertionMethod"
+	}
+}
+
+// Next returns the next iterator, or nil if there is no next iterator.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) Next() vocab.W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator {
+	if this.myIdx+1 >= this.parent.Len() {
+		return nil
+	} else {
+		return this.parent.At(this.myIdx + 1)
+	}
+}
+
+// Prev returns the previous iterator, or nil if there is no previous iterator.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) Prev() vocab.W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator {
+	if this.myIdx-1 < 0 {
+		return nil
+	} else {
+		return this.parent.At(this.myIdx - 1)
+	}
+}
+
+// Set sets the value of this property. Calling
+// IsW3IDSecurityDataIntegrityV1Multikey afterwards will return true.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) Set(v vocab.W3IDSecurityDataIntegrityV1Multikey) {
+	this.clear()
+	this.w3idsecuritydataintegrityv1MultikeyMember = v
+}
+
+// SetIRI sets the value of this property. Calling IsIRI afterwards will return
+// true.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) SetIRI(v *url.URL) {
+	this.clear()
+	this.iri = v
+}
+
+// SetType attempts to set the property for the arbitrary type. Returns an error
+// if it is not a valid type to set on this property.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) SetType(t vocab.Type) error {
+	if v, ok := t.(vocab.W3IDSecurityDataIntegrityV1Multikey); ok {
+		this.Set(v)
+		return nil
+	}
+
+	return fmt.Errorf("illegal type to set on W3IDSecurityDataIntegrityV1AssertionMethod property: %T", t)
+}
+
+// clear ensures no value of this property is set. Calling
+// IsW3IDSecurityDataIntegrityV1Multikey afterwards will return false.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) clear() {
+	this.unknown = nil
+	this.iri = nil
+	this.w3idsecuritydataintegrityv1MultikeyMember = nil
+}
+
+// serialize converts this into an interface representation suitable for
+// marshalling into a text or binary format. Applications should not need this
+// function as most typical use cases serialize types instead of individual
+// properties. It is exposed for alternatives to go-fed implementations to use.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator) serialize() (interface{}, error) {
+	if this.IsW3IDSecurityDataIntegrityV1Multikey() {
+		return this.Get().Serialize()
+	} else if this.IsIRI() {
+		return this.iri.String(), nil
+	}
+	return this.unknown, nil
+}
+
+// W3IDSecurityDataIntegrityV1AssertionMethodProperty is the non-functional
+// property "assertionMethod". It is permitted to have one or more values, and
+// of different value types.
+type W3IDSecurityDataIntegrityV1AssertionMethodProperty struct {
+	properties []*W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator
+	alias      string
+}
+
+// DeserializeAssertionMethodProperty creates a "assertionMethod" property from an
+// interface representation that has been unmarshalled from a text or binary
+// format.
+func DeserializeAssertionMethodProperty(m map[string]interface{}, aliasMap map[string]string) (vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty, error) {
+	alias := ""
+	if a, ok := aliasMap["https://w3id.org/security/data-integrity/v1"]; ok {
+		alias = a
+	}
+	propName := "assertionMethod"
+	if len(alias) > 0 {
+		propName = fmt.Sprintf("%s:%s", alias, "assertionMethod")
+	}
+	i, ok := m[propName]
+
+	if ok {
+		this := &W3IDSecurityDataIntegrityV1AssertionMethodProperty{
+			alias:      alias,
+			properties: []*W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{},
+		}
+		if list, ok := i.([]interface{}); ok {
+			for _, iterator := range list {
+				if p, err := deserializeW3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator(iterator, aliasMap); err != nil {
+					return this, err
+				} else if p != nil {
+					this.properties = append(this.properties, p)
+				}
+			}
+		} else {
+			if p, err := deserializeW3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator(i, aliasMap); err != nil {
+				return this, err
+			} else if p != nil {
+				this.properties = append(this.properties, p)
+			}
+		}
+		// Set up the properties for iteration.
+		for idx, ele := range this.properties {
+			ele.parent = this
+			ele.myIdx = idx
+		}
+		return this, nil
+	}
+	return nil, nil
+}
+
+// NewW3IDSecurityDataIntegrityV1AssertionMethodProperty creates a new
+// assertionMethod property.
+func NewW3IDSecurityDataIntegrityV1AssertionMethodProperty() *W3IDSecurityDataIntegrityV1AssertionMethodProperty {
+	return &W3IDSecurityDataIntegrityV1AssertionMethodProperty{alias: ""}
+}
+
+// AppendIRI appends an IRI value to the back of a list of the property
+// "assertionMethod"
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) AppendIRI(v *url.URL) {
+	this.properties = append(this.properties, &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		iri:    v,
+		myIdx:  this.Len(),
+		parent: this,
+	})
+}
+
+// PrependType prepends an arbitrary type value to the front of a list of the
+// property "assertionMethod". Invalidates iterators that are traversing using
+// Prev. Returns an error if the type is not a valid one to set for this
+// property.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) AppendType(t vocab.Type) error {
+	n := &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		myIdx:  this.Len(),
+		parent: this,
+	}
+	if err := n.SetType(t); err != nil {
+		return err
+	}
+	this.properties = append(this.properties, n)
+	return nil
+}
+
+// AppendW3IDSecurityDataIntegrityV1Multikey appends a Multikey value to the back
+// of a list of the property "assertionMethod". Invalidates iterators that are
+// traversing using Prev.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) AppendW3IDSecurityDataIntegrityV1Multikey(v vocab.W3IDSecurityDataIntegrityV1Multikey) {
+	this.properties = append(this.properties, &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		myIdx:  this.Len(),
+		parent: this,
+		w3idsecuritydataintegrityv1MultikeyMember: v,
+	})
+}
+
+// At returns the property value for the specified index. Panics if the index is
+// out of bounds.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) At(index int) vocab.W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator {
+	return this.properties[index]
+}
+
+// Begin returns the first iterator, or nil if empty. Can be used with the
+// iterator's Next method and this property's End method to iterate from front
+// to back through all values.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) Begin() vocab.W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator {
+	if this.Empty() {
+		return nil
+	} else {
+		return this.properties[0]
+	}
+}
+
+// Empty returns returns true if there are no elements.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) Empty() bool {
+	return this.Len() == 0
+}
+
+// End returns beyond-the-last iterator, which is nil. Can be used with the
+// iterator's Next method and this property's Begin method to iterate from
+// front to back through all values.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) End() vocab.W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator {
+	return nil
+}
+
+// Insert inserts an IRI value at the specified index for a property
+// "assertionMethod". Existing elements at that index and higher are shifted
+// back once. Invalidates all iterators.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) InsertIRI(idx int, v *url.URL) {
+	this.properties = append(this.properties, nil)
+	copy(this.properties[idx+1:], this.properties[idx:])
+	this.properties[idx] = &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		iri:    v,
+		myIdx:  idx,
+		parent: this,
+	}
+	for i := idx; i < this.Len(); i++ {
+		(this.properties)[i].myIdx = i
+	}
+}
+
+// PrependType prepends an arbitrary type value to the front of a list of the
+// property "assertionMethod". Invalidates all iterators. Returns an error if
+// the type is not a valid one to set for this property.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) InsertType(idx int, t vocab.Type) error {
+	n := &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		myIdx:  idx,
+		parent: this,
+	}
+	if err := n.SetType(t); err != nil {
+		return err
+	}
+	this.properties = append(this.properties, nil)
+	copy(this.properties[idx+1:], this.properties[idx:])
+	this.properties[idx] = n
+	for i := idx; i < this.Len(); i++ {
+		(this.properties)[i].myIdx = i
+	}
+	return nil
+}
+
+// InsertW3IDSecurityDataIntegrityV1Multikey inserts a Multikey value at the
+// specified index for a property "assertionMethod". Existing elements at that
+// index and higher are shifted back once. Invalidates all iterators.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) InsertW3IDSecurityDataIntegrityV1Multikey(idx int, v vocab.W3IDSecurityDataIntegrityV1Multikey) {
+	this.properties = append(this.properties, nil)
+	copy(this.properties[idx+1:], this.properties[idx:])
+	this.properties[idx] = &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		myIdx:  idx,
+		parent: this,
+		w3idsecuritydataintegrityv1MultikeyMember: v,
+	}
+	for i := idx; i < this.Len(); i++ {
+		(this.properties)[i].myIdx = i
+	}
+}
+
+// JSONLDContext returns the JSONLD URIs required in the context string for this
+// property and the specific values that are set. The value in the map is the
+// alias used to import the property's value or values.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) JSONLDContext() map[string]string {
+	m := map[string]string{"https://w3id.org/security/data-integrity/v1": this.alias}
+	for _, elem := range this.properties {
+		child := elem.JSONLDContext()
+		/*
+		   Since the literal maps in this function are determined at
+		   code-generation time, this loop should not overwrite an existing key with a
+		   new value.
+		*/
+		for k, v := range child {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// KindIndex computes an arbitrary value for indexing this kind of value. This is
+// a leaky API method specifically needed only for alternate implementations
+// for go-fed. Applications should not use this method. Panics if the index is
+// out of bounds.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) KindIndex(idx int) int {
+	return this.properties[idx].KindIndex()
+}
+
+// Len returns the number of values that exist for the "assertionMethod" property.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) Len() (length int) {
+	return len(this.properties)
+}
+
+// Less computes whether another property is less than this one. Mixing types
+// results in a consistent but arbitrary ordering
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) Less(i, j int) bool {
+	idx1 := this.KindIndex(i)
+	idx2 := this.KindIndex(j)
+	if idx1 < idx2 {
+		return true
+	} else if idx1 == idx2 {
+		if idx1 == 0 {
+			lhs := this.properties[i].Get()
+			rhs := this.properties[j].Get()
+			return lhs.LessThan(rhs)
+		} else if idx1 == -2 {
+			lhs := this.properties[i].GetIRI()
+			rhs := this.properties[j].GetIRI()
+			return lhs.String() < rhs.String()
+		}
+	}
+	return false
+}
+
+// LessThan compares two instances of this property with an arbitrary but stable
+// comparison. Applications should not use this because it is only meant to
+// help alternative implementations to go-fed to be able to normalize
+// nonfunctional properties.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) LessThan(o vocab.W3IDSecurityDataIntegrityV1AssertionMethodProperty) bool {
+	l1 := this.Len()
+	l2 := o.Len()
+	l := l1
+	if l2 < l1 {
+		l = l2
+	}
+	for i := 0; i < l; i++ {
+		if this.properties[i].LessThan(o.At(i)) {
+			return true
+		} else if o.At(i).LessThan(this.properties[i]) {
+			return false
+		}
+	}
+	return l1 < l2
+}
+
+// Name returns the name of this property ("assertionMethod") with any alias.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) Name() string {
+	if len(this.alias) > 0 {
+		return this.alias + ":" + "assertionMethod"
+	} else {
+		return "assertionMethod"
+	}
+}
+
+// PrependIRI prepends an IRI value to the front of a list of the property
+// "assertionMethod".
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) PrependIRI(v *url.URL) {
+	this.properties = append([]*W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{{
+		alias:  this.alias,
+		iri:    v,
+		myIdx:  0,
+		parent: this,
+	}}, this.properties...)
+	for i := 1; i < this.Len(); i++ {
+		(this.properties)[i].myIdx = i
+	}
+}
+
+// PrependType prepends an arbitrary type value to the front of a list of the
+// property "assertionMethod". Invalidates all iterators. Returns an error if
+// the type is not a valid one to set for this property.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) PrependType(t vocab.Type) error {
+	n := &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		myIdx:  0,
+		parent: this,
+	}
+	if err := n.SetType(t); err != nil {
+		return err
+	}
+	this.properties = append([]*W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{n}, this.properties...)
+	for i := 1; i < this.Len(); i++ {
+		(this.properties)[i].myIdx = i
+	}
+	return nil
+}
+
+// PrependW3IDSecurityDataIntegrityV1Multikey prepends a Multikey value to the
+// front of a list of the property "assertionMethod". Invalidates all
+// iterators.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) PrependW3IDSecurityDataIntegrityV1Multikey(v vocab.W3IDSecurityDataIntegrityV1Multikey) {
+	this.properties = append([]*W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{{
+		alias:  this.alias,
+		myIdx:  0,
+		parent: this,
+		w3idsecuritydataintegrityv1MultikeyMember: v,
+	}}, this.properties...)
+	for i := 1; i < this.Len(); i++ {
+		(this.properties)[i].myIdx = i
+	}
+}
+
+// Remove deletes an element at the specified index from a list of the property
+// "assertionMethod", regardless of its type. Panics if the index is out of
+// bounds. Invalidates all iterators.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) Remove(idx int) {
+	(this.properties)[idx].parent = nil
+	copy((this.properties)[idx:], (this.properties)[idx+1:])
+	(this.properties)[len(this.properties)-1] = &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{}
+	this.properties = (this.properties)[:len(this.properties)-1]
+	for i := idx; i < this.Len(); i++ {
+		(this.properties)[i].myIdx = i
+	}
+}
+
+// Serialize converts this into an interface representation suitable for
+// marshalling into a text or binary format. Applications should not need this
+// function as most typical use cases serialize types instead of individual
+// properties. It is exposed for alternatives to go-fed implementations to use.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) Serialize() (interface{}, error) {
+	s := make([]interface{}, 0, len(this.properties))
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return s, err
+		} else {
+			s = append(s, b)
+		}
+	}
+	// Shortcut: if serializing one value, don't return an array -- pretty sure other Fediverse software would choke on a "type" value with array, for example.
+	if len(s) == 1 {
+		return s[0], nil
+	}
+	return s, nil
+}
+
+// Set sets a Multikey value to be at the specified index for the property
+// "assertionMethod". Panics if the index is out of bounds. Invalidates all
+// iterators.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) Set(idx int, v vocab.W3IDSecurityDataIntegrityV1Multikey) {
+	(this.properties)[idx].parent = nil
+	(this.properties)[idx] = &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		myIdx:  idx,
+		parent: this,
+		w3idsecuritydataintegrityv1MultikeyMember: v,
+	}
+}
+
+// SetIRI sets an IRI value to be at the specified index for the property
+// "assertionMethod". Panics if the index is out of bounds.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) SetIRI(idx int, v *url.URL) {
+	(this.properties)[idx].parent = nil
+	(this.properties)[idx] = &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		iri:    v,
+		myIdx:  idx,
+		parent: this,
+	}
+}
+
+// SetType sets an arbitrary type value to the specified index of the property
+// "assertionMethod". Invalidates all iterators. Returns an error if the type
+// is not a valid one to set for this property. Panics if the index is out of
+// bounds.
+func (this *W3IDSecurityDataIntegrityV1AssertionMethodProperty) SetType(idx int, t vocab.Type) error {
+	n := &W3IDSecurityDataIntegrityV1AssertionMethodPropertyIterator{
+		alias:  this.alias,
+		myIdx:  idx,
+		parent: this,
+	}
+	if err := n.SetType(t); err != nil {
+		return err
+	}
+	(this.properties)[idx] = n
+	return nil
+}
+
+// Swap swaps the location of values at two indices for the "assertionMethod"
+// property.
+func (this W3IDSecurityDataIntegrityV1AssertionMethodProperty) Swap(i, j int) {
+	this.properties[i], this.properties[j] = this.properties[j], this.properties[i]
+}
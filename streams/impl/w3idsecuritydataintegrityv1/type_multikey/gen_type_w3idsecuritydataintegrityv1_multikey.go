@@ -0,0 +1,368 @@
+// Code generated by astool. DO NOT EDIT.
+
+package typemultikey
+
+import (
+	"fmt"
+	vocab "github.com/go-fed/activity/streams/vocab"
+	"strings"
+)
+
+// A Multikey represents a public cryptographic key, such as an Ed25519 key,
+// encoded with the Multikey format
+type W3IDSecurityDataIntegrityV1Multikey struct {
+	W3IDSecurityDataIntegrityV1Controller         vocab.W3IDSecurityDataIntegrityV1ControllerProperty
+	JSONLDId                                      vocab.JSONLDIdProperty
+	W3IDSecurityDataIntegrityV1PublicKeyMultibase vocab.W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty
+	JSONLDType                                    vocab.JSONLDTypeProperty
+	alias                                         string
+	unknown                                       map[string]interface{}
+}
+
+// DeserializeMultikey creates a Multikey from a map representation that has been
+// unmarshalled from a text or binary format.
+func DeserializeMultikey(m map[string]interface{}, aliasMap map[string]string) (*W3IDSecurityDataIntegrityV1Multikey, error) {
+	alias := ""
+	aliasPrefix := ""
+	if a, ok := aliasMap["https://w3id.org/security/data-integrity/v1"]; ok {
+		alias = a
+		aliasPrefix = a + ":"
+	}
+	this := &W3IDSecurityDataIntegrityV1Multikey{
+		alias:   alias,
+		unknown: make(map[string]interface{}),
+	}
+	if typeValue, ok := m["type"]; !ok {
+		return nil, fmt.Errorf("no \"type\" property in map")
+	} else if typeString, ok := typeValue.(string); ok {
+		typeName := strings.TrimPrefix(typeString, aliasPrefix)
+		if typeName != "Multikey" {
+			return nil, fmt.Errorf("\"type\" property is not of %q type: %s", "Multikey", typeName)
+		}
+		// Fall through, success in finding a proper Type
+	} else if arrType, ok := typeValue.([]interface{}); ok {
+		found := false
+		for _, elemVal := range arrType {
+			if typeString, ok := elemVal.(string); ok && strings.TrimPrefix(typeString, aliasPrefix) == "Multikey" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("could not find a \"type\" property of value %q", "Multikey")
+		}
+		// Fall through, success in finding a proper Type
+	} else {
+		return nil, fmt.Errorf("\"type\" property is unrecognized type: %T", typeValue)
+	}
+	// Begin: Known property deserialization
+	if p, err := mgr.DeserializeControllerPropertyW3IDSecurityDataIntegrityV1()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.W3IDSecurityDataIntegrityV1Controller = p
+	}
+	if p, err := mgr.DeserializeIdPropertyJSONLD()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.JSONLDId = p
+	}
+	if p, err := mgr.DeserializePublicKeyMultibasePropertyW3IDSecurityDataIntegrityV1()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.W3IDSecurityDataIntegrityV1PublicKeyMultibase = p
+	}
+	if p, err := mgr.DeserializeTypePropertyJSONLD()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.JSONLDType = p
+	}
+	// End: Known property deserialization
+
+	// Begin: Unknown deserialization
+	for k, v := range m {
+		// Begin: Code that ensures a property name is unknown
+		if k == "controller" {
+			continue
+		} else if k == "id" {
+			continue
+		} else if k == "publicKeyMultibase" {
+			continue
+		} else if k == "type" {
+			continue
+		} // End: Code that ensures a property name is unknown
+
+		this.unknown[k] = v
+	}
+	// End: Unknown deserialization
+
+	return this, nil
+}
+
+// IsOrExtendsMultikey returns true if the other provided type is the Multikey
+// type or extends from the Multikey type.
+func IsOrExtendsMultikey(other vocab.Type) bool {
+	if other.GetTypeName() == "Multikey" {
+		return true
+	}
+	return MultikeyIsExtendedBy(other)
+}
+
+// MultikeyIsDisjointWith returns true if the other provided type is disjoint with
+// the Multikey type.
+func MultikeyIsDisjointWith(other vocab.Type) bool {
+	// Shortcut implementation: is not disjoint with anything.
+	return false
+}
+
+// MultikeyIsExtendedBy returns true if the other provided type extends from the
+// Multikey type. Note that it returns false if the types are the same; see
+// the "IsOrExtendsMultikey" variant instead.
+func MultikeyIsExtendedBy(other vocab.Type) bool {
+	// Shortcut implementation: is not extended by anything.
+	return false
+}
+
+// NewW3IDSecurityDataIntegrityV1Multikey creates a new Multikey type
+func NewW3IDSecurityDataIntegrityV1Multikey() *W3IDSecurityDataIntegrityV1Multikey {
+	typeProp := typePropertyConstructor()
+	typeProp.AppendXMLSchemaString("Multikey")
+	return &W3IDSecurityDataIntegrityV1Multikey{
+		JSONLDType: typeProp,
+		alias:      "",
+		unknown:    make(map[string]interface{}),
+	}
+}
+
+// W3IDSecurityDataIntegrityV1MultikeyExtends returns true if the Multikey type
+// extends from the other type.
+func W3IDSecurityDataIntegrityV1MultikeyExtends(other vocab.Type) bool {
+	// Shortcut implementation: this does not extend anything.
+	return false
+}
+
+// GetJSONLDId returns the "id" property if it exists, and nil otherwise.
+func (this W3IDSecurityDataIntegrityV1Multikey) GetJSONLDId() vocab.JSONLDIdProperty {
+	return this.JSONLDId
+}
+
+// GetJSONLDType returns the "type" property if it exists, and nil otherwise.
+func (this W3IDSecurityDataIntegrityV1Multikey) GetJSONLDType() vocab.JSONLDTypeProperty {
+	return this.JSONLDType
+}
+
+// GetTypeName returns the name of this type.
+func (this W3IDSecurityDataIntegrityV1Multikey) GetTypeName() string {
+	return "Multikey"
+}
+
+// GetUnknownProperties returns the unknown properties for the Multikey type. Note
+// that this should not be used by app developers. It is only used to help
+// determine which implementation is LessThan the other. Developers who are
+// creating a different implementation of this type's interface can use this
+// method in their LessThan implementation, but routine ActivityPub
+// applications should not use this to bypass the code generation tool.
+func (this W3IDSecurityDataIntegrityV1Multikey) GetUnknownProperties() map[string]interface{} {
+	return this.unknown
+}
+
+// GetW3IDSecurityDataIntegrityV1Controller returns the "controller" property if
+// it exists, and nil otherwise.
+func (this W3IDSecurityDataIntegrityV1Multikey) GetW3IDSecurityDataIntegrityV1Controller() vocab.W3IDSecurityDataIntegrityV1ControllerProperty {
+	return this.W3IDSecurityDataIntegrityV1Controller
+}
+
+// GetW3IDSecurityDataIntegrityV1PublicKeyMultibase returns the
+// "publicKeyMultibase" property if it exists, and nil otherwise.
+func (this W3IDSecurityDataIntegrityV1Multikey) GetW3IDSecurityDataIntegrityV1PublicKeyMultibase() vocab.W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty {
+	return this.W3IDSecurityDataIntegrityV1PublicKeyMultibase
+}
+
+// IsExtending returns true if the Multikey type extends from the other type.
+func (this W3IDSecurityDataIntegrityV1Multikey) IsExtending(other vocab.Type) bool {
+	return W3IDSecurityDataIntegrityV1MultikeyExtends(other)
+}
+
+// JSONLDContext returns the JSONLD URIs required in the context string for this
+// type and the specific properties that are set. The value in the map is the
+// alias used to import the type and its properties.
+func (this W3IDSecurityDataIntegrityV1Multikey) JSONLDContext() map[string]string {
+	m := map[string]string{"https://w3id.org/security/data-integrity/v1": this.alias}
+	m = this.helperJSONLDContext(this.W3IDSecurityDataIntegrityV1Controller, m)
+	m = this.helperJSONLDContext(this.JSONLDId, m)
+	m = this.helperJSONLDContext(this.W3IDSecurityDataIntegrityV1PublicKeyMultibase, m)
+	m = this.helperJSONLDContext(this.JSONLDType, m)
+
+	return m
+}
+
+// LessThan computes if this Multikey is lesser, with an arbitrary but stable
+// determination.
+func (this W3IDSecurityDataIntegrityV1Multikey) LessThan(o vocab.W3IDSecurityDataIntegrityV1Multikey) bool {
+	// Begin: Compare known properties
+	// Compare property "controller"
+	if lhs, rhs := this.W3IDSecurityDataIntegrityV1Controller, o.GetW3IDSecurityDataIntegrityV1Controller(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "id"
+	if lhs, rhs := this.JSONLDId, o.GetJSONLDId(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "publicKeyMultibase"
+	if lhs, rhs := this.W3IDSecurityDataIntegrityV1PublicKeyMultibase, o.GetW3IDSecurityDataIntegrityV1PublicKeyMultibase(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "type"
+	if lhs, rhs := this.JSONLDType, o.GetJSONLDType(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// End: Compare known properties
+
+	// Begin: Compare unknown properties (only by number of them)
+	if len(this.unknown) < len(o.GetUnknownProperties()) {
+		return true
+	} else if len(o.GetUnknownProperties()) < len(this.unknown) {
+		return false
+	} // End: Compare unknown properties (only by number of them)
+
+	// All properties are the same.
+	return false
+}
+
+// Serialize converts this into an interface representation suitable for
+// marshalling into a text or binary format.
+func (this W3IDSecurityDataIntegrityV1Multikey) Serialize() (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	typeName := "Multikey"
+	if len(this.alias) > 0 {
+		typeName = this.alias + ":" + "Multikey"
+	}
+	m["type"] = typeName
+	// Begin: Serialize known properties
+	// Maybe serialize property "controller"
+	if this.W3IDSecurityDataIntegrityV1Controller != nil {
+		if i, err := this.W3IDSecurityDataIntegrityV1Controller.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.W3IDSecurityDataIntegrityV1Controller.Name()] = i
+		}
+	}
+	// Maybe serialize property "id"
+	if this.JSONLDId != nil {
+		if i, err := this.JSONLDId.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.JSONLDId.Name()] = i
+		}
+	}
+	// Maybe serialize property "publicKeyMultibase"
+	if this.W3IDSecurityDataIntegrityV1PublicKeyMultibase != nil {
+		if i, err := this.W3IDSecurityDataIntegrityV1PublicKeyMultibase.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.W3IDSecurityDataIntegrityV1PublicKeyMultibase.Name()] = i
+		}
+	}
+	// Maybe serialize property "type"
+	if this.JSONLDType != nil {
+		if i, err := this.JSONLDType.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.JSONLDType.Name()] = i
+		}
+	}
+	// End: Serialize known properties
+
+	// Begin: Serialize unknown properties
+	for k, v := range this.unknown {
+		// To be safe, ensure we aren't overwriting a known property
+		if _, has := m[k]; !has {
+			m[k] = v
+		}
+	}
+	// End: Serialize unknown properties
+
+	return m, nil
+}
+
+// SetJSONLDId sets the "id" property.
+func (this *W3IDSecurityDataIntegrityV1Multikey) SetJSONLDId(i vocab.JSONLDIdProperty) {
+	this.JSONLDId = i
+}
+
+// SetJSONLDType sets the "type" property.
+func (this *W3IDSecurityDataIntegrityV1Multikey) SetJSONLDType(i vocab.JSONLDTypeProperty) {
+	this.JSONLDType = i
+}
+
+// SetW3IDSecurityDataIntegrityV1Controller sets the "controller" property.
+func (this *W3IDSecurityDataIntegrityV1Multikey) SetW3IDSecurityDataIntegrityV1Controller(i vocab.W3IDSecurityDataIntegrityV1ControllerProperty) {
+	this.W3IDSecurityDataIntegrityV1Controller = i
+}
+
+// SetW3IDSecurityDataIntegrityV1PublicKeyMultibase sets the "publicKeyMultibase"
+// property.
+func (this *W3IDSecurityDataIntegrityV1Multikey) SetW3IDSecurityDataIntegrityV1PublicKeyMultibase(i vocab.W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty) {
+	this.W3IDSecurityDataIntegrityV1PublicKeyMultibase = i
+}
+
+// VocabularyURI returns the vocabulary's URI as a string.
+func (this W3IDSecurityDataIntegrityV1Multikey) VocabularyURI() string {
+	return "https://w3id.org/security/data-integrity/v1"
+}
+
+// helperJSONLDContext obtains the context uris and their aliases from a property,
+// if it is not nil.
+func (this W3IDSecurityDataIntegrityV1Multikey) helperJSONLDContext(i jsonldContexter, toMerge map[string]string) map[string]string {
+	if i == nil {
+		return toMerge
+	}
+	for k, v := range i.JSONLDContext() {
+		/*
+		   Since the literal maps in this function are determined at
+		   code-generation time, this loop should not overwrite an existing key with a
+		   new value.
+		*/
+		toMerge[k] = v
+	}
+	return toMerge
+}
@@ -10,6 +10,9 @@ package vocab
 //     "type": "Group"
 //   }
 type ActivityStreamsGroup interface {
+	// GetActivityStreamsAlsoKnownAs returns the "alsoKnownAs" property if it
+	// exists, and nil otherwise.
+	GetActivityStreamsAlsoKnownAs() ActivityStreamsAlsoKnownAsProperty
 	// GetActivityStreamsAltitude returns the "altitude" property if it
 	// exists, and nil otherwise.
 	GetActivityStreamsAltitude() ActivityStreamsAltitudeProperty
@@ -76,6 +79,9 @@ type ActivityStreamsGroup interface {
 	// GetActivityStreamsMediaType returns the "mediaType" property if it
 	// exists, and nil otherwise.
 	GetActivityStreamsMediaType() ActivityStreamsMediaTypeProperty
+	// GetActivityStreamsMovedTo returns the "movedTo" property if it exists,
+	// and nil otherwise.
+	GetActivityStreamsMovedTo() ActivityStreamsMovedToProperty
 	// GetActivityStreamsName returns the "name" property if it exists, and
 	// nil otherwise.
 	GetActivityStreamsName() ActivityStreamsNameProperty
@@ -154,6 +160,9 @@ type ActivityStreamsGroup interface {
 	// implementation, but routine ActivityPub applications should not use
 	// this to bypass the code generation tool.
 	GetUnknownProperties() map[string]interface{}
+	// GetW3IDSecurityDataIntegrityV1AssertionMethod returns the
+	// "assertionMethod" property if it exists, and nil otherwise.
+	GetW3IDSecurityDataIntegrityV1AssertionMethod() W3IDSecurityDataIntegrityV1AssertionMethodProperty
 	// GetW3IDSecurityV1PublicKey returns the "publicKey" property if it
 	// exists, and nil otherwise.
 	GetW3IDSecurityV1PublicKey() W3IDSecurityV1PublicKeyProperty
@@ -169,6 +178,8 @@ type ActivityStreamsGroup interface {
 	// Serialize converts this into an interface representation suitable for
 	// marshalling into a text or binary format.
 	Serialize() (map[string]interface{}, error)
+	// SetActivityStreamsAlsoKnownAs sets the "alsoKnownAs" property.
+	SetActivityStreamsAlsoKnownAs(i ActivityStreamsAlsoKnownAsProperty)
 	// SetActivityStreamsAltitude sets the "altitude" property.
 	SetActivityStreamsAltitude(i ActivityStreamsAltitudeProperty)
 	// SetActivityStreamsAttachment sets the "attachment" property.
@@ -213,6 +224,8 @@ type ActivityStreamsGroup interface {
 	SetActivityStreamsLocation(i ActivityStreamsLocationProperty)
 	// SetActivityStreamsMediaType sets the "mediaType" property.
 	SetActivityStreamsMediaType(i ActivityStreamsMediaTypeProperty)
+	// SetActivityStreamsMovedTo sets the "movedTo" property.
+	SetActivityStreamsMovedTo(i ActivityStreamsMovedToProperty)
 	// SetActivityStreamsName sets the "name" property.
 	SetActivityStreamsName(i ActivityStreamsNameProperty)
 	// SetActivityStreamsObject sets the "object" property.
@@ -260,6 +273,9 @@ type ActivityStreamsGroup interface {
 	SetTootDiscoverable(i TootDiscoverableProperty)
 	// SetTootFeatured sets the "featured" property.
 	SetTootFeatured(i TootFeaturedProperty)
+	// SetW3IDSecurityDataIntegrityV1AssertionMethod sets the
+	// "assertionMethod" property.
+	SetW3IDSecurityDataIntegrityV1AssertionMethod(i W3IDSecurityDataIntegrityV1AssertionMethodProperty)
 	// SetW3IDSecurityV1PublicKey sets the "publicKey" property.
 	SetW3IDSecurityV1PublicKey(i W3IDSecurityV1PublicKeyProperty)
 	// VocabularyURI returns the vocabulary's URI as a string.
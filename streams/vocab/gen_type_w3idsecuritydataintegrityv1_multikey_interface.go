@@ -0,0 +1,53 @@
+// Code generated by astool. DO NOT EDIT.
+
+package vocab
+
+// A Multikey represents a public cryptographic key, such as an Ed25519 key,
+// encoded with the Multikey format
+type W3IDSecurityDataIntegrityV1Multikey interface {
+	// GetJSONLDId returns the "id" property if it exists, and nil otherwise.
+	GetJSONLDId() JSONLDIdProperty
+	// GetJSONLDType returns the "type" property if it exists, and nil
+	// otherwise.
+	GetJSONLDType() JSONLDTypeProperty
+	// GetTypeName returns the name of this type.
+	GetTypeName() string
+	// GetUnknownProperties returns the unknown properties for the Multikey
+	// type. Note that this should not be used by app developers. It is
+	// only used to help determine which implementation is LessThan the
+	// other. Developers who are creating a different implementation of
+	// this type's interface can use this method in their LessThan
+	// implementation, but routine ActivityPub applications should not use
+	// this to bypass the code generation tool.
+	GetUnknownProperties() map[string]interface{}
+	// GetW3IDSecurityDataIntegrityV1Controller returns the "controller"
+	// property if it exists, and nil otherwise.
+	GetW3IDSecurityDataIntegrityV1Controller() W3IDSecurityDataIntegrityV1ControllerProperty
+	// GetW3IDSecurityDataIntegrityV1PublicKeyMultibase returns the
+	// "publicKeyMultibase" property if it exists, and nil otherwise.
+	GetW3IDSecurityDataIntegrityV1PublicKeyMultibase() W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty
+	// IsExtending returns true if the Multikey type extends from the other
+	// type.
+	IsExtending(other Type) bool
+	// JSONLDContext returns the JSONLD URIs required in the context string
+	// for this type and the specific properties that are set. The value
+	// in the map is the alias used to import the type and its properties.
+	JSONLDContext() map[string]string
+	// LessThan computes if this Multikey is lesser, with an arbitrary but
+	// stable determination.
+	LessThan(o W3IDSecurityDataIntegrityV1Multikey) bool
+	// Serialize converts this into an interface representation suitable for
+	// marshalling into a text or binary format.
+	Serialize() (map[string]interface{}, error)
+	// SetJSONLDId sets the "id" property.
+	SetJSONLDId(i JSONLDIdProperty)
+	// SetJSONLDType sets the "type" property.
+	SetJSONLDType(i JSONLDTypeProperty)
+	// SetW3IDSecurityDataIntegrityV1Controller sets the "controller" property.
+	SetW3IDSecurityDataIntegrityV1Controller(i W3IDSecurityDataIntegrityV1ControllerProperty)
+	// SetW3IDSecurityDataIntegrityV1PublicKeyMultibase sets the
+	// "publicKeyMultibase" property.
+	SetW3IDSecurityDataIntegrityV1PublicKeyMultibase(i W3IDSecurityDataIntegrityV1PublicKeyMultibaseProperty)
+	// VocabularyURI returns the vocabulary's URI as a string.
+	VocabularyURI() string
+}